@@ -58,7 +58,7 @@ func initConfig() {
 		)
 	}
 
-	err = viper.Unmarshal(&config.Config)
+	err = viper.Unmarshal(&config.Config, config.DecodeHook)
 	if err != nil {
 		logger.Fatalw("unable to decode app config", "error", err)
 	}
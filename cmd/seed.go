@@ -40,7 +40,11 @@ func seedDatabase(_ context.Context) {
 		logger.Fatal("no data path provided")
 	}
 
-	err := storage.SeedDatabase(config.Config.CRDB, path)
+	err := storage.SeedDatabase(
+		config.Config.CRDB,
+		path,
+		storage.WithIssuerSecretEncryptionKey(config.Config.OAuth.IssuerSecrets.EncryptionKey),
+	)
 	if err != nil {
 		logger.Fatalf("error seeding database: %s", err)
 	}
@@ -1,11 +1,15 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-
-	"github.com/MicahParks/keyfunc/v3"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/golang-jwt/jwt/v5"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -24,13 +28,19 @@ import (
 	"go.infratographer.com/identity-api/internal/api/httpsrv"
 	"go.infratographer.com/identity-api/internal/auditx"
 	"go.infratographer.com/identity-api/internal/config"
+	"go.infratographer.com/identity-api/internal/discovery"
 	"go.infratographer.com/identity-api/internal/events"
 	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/groups"
 	"go.infratographer.com/identity-api/internal/jwks"
+	"go.infratographer.com/identity-api/internal/lastseen"
 	"go.infratographer.com/identity-api/internal/oauth2"
+	"go.infratographer.com/identity-api/internal/rfc7662"
 	"go.infratographer.com/identity-api/internal/rfc8693"
 	"go.infratographer.com/identity-api/internal/routes"
+	"go.infratographer.com/identity-api/internal/signingkeys"
 	"go.infratographer.com/identity-api/internal/storage"
+	"go.infratographer.com/identity-api/internal/tokengc"
 	"go.infratographer.com/identity-api/internal/userinfo"
 
 	"github.com/metal-toolbox/auditevent/middleware/echoaudit"
@@ -46,6 +56,8 @@ var serveCmd = &cobra.Command{
 
 var defaultListen = ":8080"
 
+var errUnknownSigningKey = errors.New("unknown signing key")
+
 func init() {
 	rootCmd.AddCommand(serveCmd)
 
@@ -91,16 +103,25 @@ func serve(ctx context.Context) {
 		logger.Fatal("failed to initialize permissions", zap.Error(err))
 	}
 
-	storageEngine, err := storage.NewEngine(config.Config.CRDB)
+	storageEngine, err := storage.NewEngine(
+		config.Config.CRDB,
+		storage.WithSigningKeyEncryptionKey(config.Config.OAuth.SigningKeys.EncryptionKey),
+		storage.WithIssuerSecretEncryptionKey(config.Config.OAuth.IssuerSecrets.EncryptionKey),
+		storage.WithIssuerCache(config.Config.OAuth.IssuerCache.TTL),
+	)
 	if err != nil {
 		logger.Fatalf("error initializing storage: %s", err)
 	}
 
 	mappingStrategy := rfc8693.NewClaimMappingStrategy(storageEngine)
+	scopeMappingStrategy := rfc8693.NewScopeMappingStrategy(storageEngine)
 	conditionStrategy := rfc8693.NewClaimConditionStrategy(storageEngine)
+	actorConditionStrategy := rfc8693.NewActorConditionStrategy(storageEngine)
 
 	issuerJWKSURIProvider := jwks.NewIssuerJWKSURIProvider(storageEngine)
 
+	es := events.NewEvents(events.WithLogger(logger.Desugar()), events.WithPublisher(nc))
+
 	oauth2Config, err := fositex.NewOAuth2Config(config.Config.OAuth)
 	if err != nil {
 		logger.Fatalf("error loading config: %s", err)
@@ -108,15 +129,51 @@ func serve(ctx context.Context) {
 
 	oauth2Config.IssuerJWKSURIProvider = issuerJWKSURIProvider
 	oauth2Config.ClaimMappingStrategy = mappingStrategy
+	oauth2Config.ScopeMappingStrategy = scopeMappingStrategy
 	oauth2Config.ClaimConditionStrategy = conditionStrategy
+	oauth2Config.ActorConditionStrategy = actorConditionStrategy
+	oauth2Config.IssuerStrategy = storageEngine
+	oauth2Config.IntrospectionStrategy = rfc7662.NewIntrospectionStrategy(storageEngine, rfc7662.NewClient(nil))
 	oauth2Config.UserInfoStrategy = storageEngine
+	oauth2Config.UserInfoFetchStrategy = userinfo.NewClient(nil)
+	oauth2Config.UserEventStrategy = es
+
+	lastSeenRecorder := lastseen.NewRecorder(storageEngine, config.Config.OAuth.LastSeen, lastseen.WithLogger(logger.Desugar()))
+	oauth2Config.LastSeenStrategy = lastSeenRecorder
+
+	go lastSeenRecorder.Run(ctx)
+
+	oauth2Config.OwnerAccessStrategy = groups.NewOwnerAccessStrategy(storageEngine)
+
+	if config.Config.OAuth.GroupsClaim.Enabled {
+		oauth2Config.GroupsClaimStrategy = groups.NewClaimStrategy(storageEngine, config.Config.OAuth.GroupsClaim)
+	}
+
+	watchSigningKeys(ctx, oauth2Config.KeyRing)
+
+	apiHandlerOpts := []httpsrv.Opt{
+		httpsrv.WithMiddleware(perms.Middleware()),
+	}
+
+	if config.Config.OAuth.SigningKeys.Enabled() {
+		loader := signingkeys.NewLoader(
+			storageEngine, oauth2Config.KeyRing, config.Config.OAuth,
+			signingkeys.WithLogger(logger.Desugar()),
+		)
+
+		if err := loader.Load(ctx); err != nil {
+			logger.Fatal("failed to load signing keys", zap.Error(err))
+		}
+
+		go loader.Run(ctx)
 
-	keyGetter := func(ctx context.Context) (any, error) {
-		return oauth2Config.GetSigningKey(ctx), nil
+		apiHandlerOpts = append(apiHandlerOpts,
+			httpsrv.WithSigningKeys(config.Config.OAuth.SigningKeys.OwnerID, loader.Interval()),
+		)
 	}
 
 	hmacStrategy := compose.NewOAuth2HMACStrategy(oauth2Config)
-	jwtStrategy := compose.NewOAuth2JWTStrategy(keyGetter, hmacStrategy, oauth2Config)
+	jwtStrategy := fositex.NewJTIStrategy(fositex.NewOAuth2JWTStrategy(oauth2Config, hmacStrategy))
 
 	provider := fositex.NewOAuth2Provider(
 		oauth2Config,
@@ -124,11 +181,27 @@ func serve(ctx context.Context) {
 		jwtStrategy,
 		rfc8693.NewTokenExchangeHandler,
 		oauth2.NewClientCredentialsHandlerFactory,
+		oauth2.NewRefreshTokenHandlerFactory,
+		oauth2.NewTokenIntrospectorFactory,
+		oauth2.NewTokenRevocationHandlerFactory,
 	)
 
-	es := events.NewEvents(events.WithLogger(logger.Desugar()))
+	collector := tokengc.NewCollector(storageEngine, config.Config.OAuth.TokenGC, tokengc.WithLogger(logger.Desugar()))
 
-	apiHandler, err := httpsrv.NewAPIHandler(storageEngine, es, auditMiddleware, perms.Middleware())
+	go collector.Run(ctx)
+
+	discoveryClient := discovery.NewClient(nil)
+
+	refresher := discovery.NewRefresher(
+		storageEngine, discoveryClient, config.Config.OAuth.IssuerDiscovery,
+		discovery.WithLogger(logger.Desugar()),
+	)
+
+	go refresher.Run(ctx)
+
+	apiHandlerOpts = append(apiHandlerOpts, httpsrv.WithDiscoveryClient(discoveryClient))
+
+	apiHandler, err := httpsrv.NewAPIHandler(storageEngine, es, auditMiddleware, apiHandlerOpts...)
 	if err != nil {
 		logger.Fatal("error initializing API server: %s", err)
 	}
@@ -180,25 +253,13 @@ func serve(ctx context.Context) {
 func getAuthMiddleware(ctx context.Context, config fositex.OAuth2Configurator, skippers ...middleware.Skipper) (echo.MiddlewareFunc, error) {
 	issuer := config.GetAccessTokenIssuer(ctx)
 
-	var buff bytes.Buffer
-
-	err := json.NewEncoder(&buff).Encode(config.GetSigningJWKS(ctx))
-	if err != nil {
-		return nil, err
-	}
-
-	jwks, err := keyfunc.NewJWKSetJSON(json.RawMessage(buff.Bytes()))
-	if err != nil {
-		return nil, err
-	}
-
 	authConfig := echojwtx.AuthConfig{
 		Issuer: issuer,
 	}
 
 	auth, err := echojwtx.NewAuth(ctx, authConfig, echojwtx.WithJWTConfig(echojwt.Config{
 		Skipper: multiSkipper(skippers...),
-		KeyFunc: jwks.Keyfunc,
+		KeyFunc: signingKeyFunc(ctx, config),
 	}))
 	if err != nil {
 		return nil, err
@@ -207,6 +268,86 @@ func getAuthMiddleware(ctx context.Context, config fositex.OAuth2Configurator, s
 	return auth.Middleware(), nil
 }
 
+// signingKeyFunc returns a jwt.Keyfunc which looks up token verification keys in the signing JWKS
+// on every request, so tokens signed with keys loaded after startup are accepted.
+func signingKeyFunc(ctx context.Context, config fositex.OAuth2Configurator) jwt.Keyfunc {
+	return func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		keys := config.GetSigningJWKS(ctx).Key(kid)
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("%w: %q", errUnknownSigningKey, kid)
+		}
+
+		key := keys[0]
+
+		if token.Method.Alg() != key.Algorithm {
+			return nil, fmt.Errorf("%w: token algorithm %s does not match key %q", errUnknownSigningKey, token.Method.Alg(), kid)
+		}
+
+		// Symmetric keys have no public part, and verify tokens themselves.
+		if public := key.Public(); public.Valid() {
+			return public.Key, nil
+		}
+
+		return key.Key, nil
+	}
+}
+
+// watchSigningKeys reloads the signing keys when the config file changes or the process receives
+// SIGHUP, so keys can be added, activated and retired without a restart. If the new keys cannot be
+// loaded, the current keys are kept.
+func watchSigningKeys(ctx context.Context, keyRing *fositex.KeyRing) {
+	reload := func() {
+		var keys []fositex.PrivateKey
+
+		if err := viper.UnmarshalKey("oauth.privateKeys", &keys, config.DecodeHook); err != nil {
+			logger.Errorw("failed to decode signing keys", "error", err)
+
+			return
+		}
+
+		if err := keyRing.Load(keys); err != nil {
+			logger.Errorw("failed to reload signing keys", "error", err)
+
+			return
+		}
+
+		logger.Infow("reloaded signing keys", "signing_key_id", keyRing.Signer().Public().KeyID)
+	}
+
+	if viper.ConfigFileUsed() != "" {
+		viper.OnConfigChange(func(fsnotify.Event) {
+			reload()
+		})
+
+		viper.WatchConfig()
+	}
+
+	hup := make(chan os.Signal, 1)
+
+	signal.Notify(hup, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(hup)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				if err := viper.ReadInConfig(); err != nil {
+					logger.Errorw("failed to read config", "error", err)
+
+					continue
+				}
+
+				reload()
+			}
+		}
+	}()
+}
+
 func multiSkipper(skippers ...middleware.Skipper) func(c echo.Context) bool {
 	return func(c echo.Context) bool {
 		for _, skipper := range skippers {
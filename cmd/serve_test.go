@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/labstack/echo/v4"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/compose"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/echojwtx"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/testingx"
+)
+
+const testIssuer = "https://identity.example.com"
+
+func writePKCS8Key(t *testing.T, key crypto.Signer) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	return path
+}
+
+// TestAuthMiddleware checks that access tokens signed with each supported key type are accepted by the
+// middleware protecting the API, which verifies them against the advertised signing JWKS.
+func TestAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	runFn := func(ctx context.Context, input fositex.PrivateKey) testingx.TestResult[string] {
+		oauth2Config, err := fositex.NewOAuth2Config(fositex.Config{
+			Issuer:      testIssuer,
+			Secret:      "abcd1234abcd1234abcd1234abcd1234",
+			PrivateKeys: []fositex.PrivateKey{input},
+		})
+		if err != nil {
+			return testingx.TestResult[string]{
+				Err: err,
+			}
+		}
+
+		strategy := fositex.NewOAuth2JWTStrategy(oauth2Config, compose.NewOAuth2HMACStrategy(oauth2Config))
+
+		request := fosite.NewRequest()
+		request.Session = &oauth2.JWTSession{
+			JWTClaims: &jwt.JWTClaims{
+				Issuer:  testIssuer,
+				Subject: "idntusr-test",
+			},
+			JWTHeader: &jwt.Headers{},
+			ExpiresAt: map[fosite.TokenType]time.Time{
+				fosite.AccessToken: time.Now().Add(time.Hour),
+			},
+		}
+
+		token, _, err := strategy.GenerateAccessToken(ctx, request)
+		if err != nil {
+			return testingx.TestResult[string]{
+				Err: err,
+			}
+		}
+
+		authMdw, err := getAuthMiddleware(ctx, oauth2Config)
+		if err != nil {
+			return testingx.TestResult[string]{
+				Err: err,
+			}
+		}
+
+		e := echo.New()
+		e.GET("/", func(c echo.Context) error {
+			return c.String(http.StatusOK, echojwtx.Actor(c))
+		}, authMdw)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			return testingx.TestResult[string]{
+				Err: echo.NewHTTPError(rec.Code, rec.Body.String()),
+			}
+		}
+
+		return testingx.TestResult[string]{
+			Success: rec.Body.String(),
+		}
+	}
+
+	checkActor := func(_ context.Context, t *testing.T, res testingx.TestResult[string]) {
+		require.NoError(t, res.Err)
+		assert.Equal(t, "idntusr-test", res.Success)
+	}
+
+	testCases := []testingx.TestCase[fositex.PrivateKey, string]{
+		{
+			Name: "RS256",
+			Input: fositex.PrivateKey{
+				KeyID:     "rs256",
+				Algorithm: jose.RS256,
+				Path:      writePKCS8Key(t, rsaKey),
+			},
+			CheckFn: checkActor,
+		},
+		{
+			Name: "ES256",
+			Input: fositex.PrivateKey{
+				KeyID:     "es256",
+				Algorithm: jose.ES256,
+				Path:      writePKCS8Key(t, p256Key),
+			},
+			CheckFn: checkActor,
+		},
+		{
+			Name: "ES384",
+			Input: fositex.PrivateKey{
+				KeyID:     "es384",
+				Algorithm: jose.ES384,
+				Path:      writePKCS8Key(t, p384Key),
+			},
+			CheckFn: checkActor,
+		},
+		{
+			Name: "EdDSA",
+			Input: fositex.PrivateKey{
+				KeyID:     "eddsa",
+				Algorithm: jose.EdDSA,
+				Path:      writePKCS8Key(t, ed25519Key),
+			},
+			CheckFn: checkActor,
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
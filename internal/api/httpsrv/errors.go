@@ -23,6 +23,11 @@ var (
 		message: "not found",
 	}
 
+	errorMissingTokenValidation = errorWithStatus{
+		status:  http.StatusBadRequest,
+		message: "at least one of jwks_uri or introspection_uri is required",
+	}
+
 	// ErrDBRollbackFailed is returned when a database rollback fails
 	ErrDBRollbackFailed = errors.New("failed to rollback database transaction")
 )
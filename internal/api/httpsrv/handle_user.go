@@ -11,7 +11,10 @@ import (
 )
 
 const (
-	actionUserGet = "iam_user_get"
+	actionUserGet          = "iam_user_get"
+	actionUserUpdate       = "iam_user_update"
+	actionUserDelete       = "iam_user_delete"
+	actionUserTokensRevoke = "iam_user_tokens_revoke"
 )
 
 func (h *apiHandler) GetUserByID(ctx context.Context, req GetUserByIDRequestObject) (GetUserByIDResponseObject, error) {
@@ -52,6 +55,132 @@ func (h *apiHandler) GetUserByID(ctx context.Context, req GetUserByIDRequestObje
 	return GetUserByID200JSONResponse(out), nil
 }
 
+// UpdateUser updates a user. Disabling a user revokes all tokens issued to them, and token exchanges
+// for them are refused until they are re-enabled.
+func (h *apiHandler) UpdateUser(ctx context.Context, req UpdateUserRequestObject) (UpdateUserResponseObject, error) {
+	// Find the owner the user's issuer is on to check permissions.
+	ownerID, err := h.engine.LookupUserOwnerID(ctx, req.UserID)
+	switch err {
+	case nil:
+	case types.ErrUserInfoNotFound:
+		return nil, errorWithStatus{
+			status:  http.StatusNotFound,
+			message: err.Error(),
+		}
+	default:
+		return nil, err
+	}
+
+	if err := permissions.CheckAccess(ctx, ownerID, actionUserUpdate); err != nil {
+		return nil, permissionsError(err)
+	}
+
+	update := types.UserInfoUpdate{
+		Disabled: req.Body.Disabled,
+	}
+
+	info, err := h.engine.UpdateUserInfo(ctx, req.UserID, update)
+	switch err {
+	case nil:
+	case types.ErrUserInfoNotFound:
+		return nil, errorWithStatus{
+			status:  http.StatusNotFound,
+			message: err.Error(),
+		}
+	default:
+		return nil, err
+	}
+
+	if info.Disabled {
+		if err := h.engine.RevokeSubjectTokens(ctx, req.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	out, err := info.ToV1User()
+	if err != nil {
+		return nil, err
+	}
+
+	return UpdateUser200JSONResponse(out), nil
+}
+
+// DeleteUser deletes a user, removing them from their groups and revoking all tokens issued to them.
+// Deleting a user does not prevent them from exchanging another token, which stores them again, so
+// users are offboarded by disabling them.
+func (h *apiHandler) DeleteUser(ctx context.Context, req DeleteUserRequestObject) (DeleteUserResponseObject, error) {
+	// Find the owner the user's issuer is on to check permissions.
+	ownerID, err := h.engine.LookupUserOwnerID(ctx, req.UserID)
+	switch err {
+	case nil:
+	case types.ErrUserInfoNotFound:
+		return nil, errorWithStatus{
+			status:  http.StatusNotFound,
+			message: err.Error(),
+		}
+	default:
+		return nil, err
+	}
+
+	if err := permissions.CheckAccess(ctx, ownerID, actionUserDelete); err != nil {
+		return nil, permissionsError(err)
+	}
+
+	groupIDs, err := h.engine.ListGroupIDsBySubject(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, groupID := range groupIDs {
+		if err := h.engine.RemoveGroupMember(ctx, groupID, req.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := h.engine.RevokeSubjectTokens(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	if err := h.engine.DeleteUserInfo(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	for _, groupID := range groupIDs {
+		if err := h.eventService.RemoveGroupMembers(ctx, groupID, req.UserID); err != nil {
+			resperr := h.rollbackAndReturnError(ctx, http.StatusInternalServerError, "failed to remove group member in permissions API")
+			return nil, resperr
+		}
+	}
+
+	return DeleteUser200JSONResponse{Success: true}, nil
+}
+
+// RevokeUserTokens revokes all tokens issued to the user.
+func (h *apiHandler) RevokeUserTokens(ctx context.Context, req RevokeUserTokensRequestObject) (RevokeUserTokensResponseObject, error) {
+	// Find the owner the user's issuer is on to check permissions.
+	ownerID, err := h.engine.LookupUserOwnerID(ctx, req.UserID)
+	switch err {
+	case nil:
+	case types.ErrUserInfoNotFound:
+		return nil, errorWithStatus{
+			status:  http.StatusNotFound,
+			message: err.Error(),
+		}
+	default:
+		return nil, err
+	}
+
+	if err := permissions.CheckAccess(ctx, ownerID, actionUserTokensRevoke); err != nil {
+		return nil, permissionsError(err)
+	}
+
+	if err := h.engine.RevokeSubjectTokens(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	return RevokeUserTokens200JSONResponse{Success: true}, nil
+}
+
 func (h *apiHandler) GetIssuerUsers(ctx context.Context, req GetIssuerUsersRequestObject) (GetIssuerUsersResponseObject, error) {
 	if err := permissions.CheckAccess(ctx, req.IssuerID, actionIssuerGet); err != nil {
 		return nil, permissionsError(err)
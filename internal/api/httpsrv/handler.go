@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/metal-toolbox/auditevent/middleware/echoaudit"
+	"go.infratographer.com/x/gidx"
 
+	"go.infratographer.com/identity-api/internal/discovery"
 	"go.infratographer.com/identity-api/internal/events"
 	"go.infratographer.com/identity-api/internal/storage"
 )
@@ -48,6 +51,11 @@ func storageMiddleware(engine storage.Engine) echo.MiddlewareFunc {
 type apiHandler struct {
 	engine       storage.Engine
 	eventService events.Service
+
+	signingKeyOwnerID         gidx.PrefixedID
+	signingKeyActivationDelay time.Duration
+
+	discoveryClient *discovery.Client
 }
 
 // APIHandler represents an identity-api management API handler.
@@ -58,10 +66,38 @@ type APIHandler struct {
 	middleware           []echo.MiddlewareFunc
 }
 
+// Opt represents an option for configuring an APIHandler.
+type Opt func(*APIHandler)
+
+// WithMiddleware adds middleware run for every API route.
+func WithMiddleware(middleware ...echo.MiddlewareFunc) Opt {
+	return func(h *APIHandler) {
+		h.middleware = append(h.middleware, middleware...)
+	}
+}
+
+// WithSigningKeys enables managing signing keys through the API. Access is checked against the given
+// owner, and activated keys start signing tokens after the given delay, once every replica has
+// loaded them.
+func WithSigningKeys(ownerID gidx.PrefixedID, activationDelay time.Duration) Opt {
+	return func(h *APIHandler) {
+		h.handler.signingKeyOwnerID = ownerID
+		h.handler.signingKeyActivationDelay = activationDelay
+	}
+}
+
+// WithDiscoveryClient sets the client used to discover issuer endpoints from OIDC provider metadata.
+// By default, a client with discovery.DefaultTimeout is used.
+func WithDiscoveryClient(client *discovery.Client) Opt {
+	return func(h *APIHandler) {
+		h.handler.discoveryClient = client
+	}
+}
+
 // NewAPIHandler creates an API handler with the given storage engine.
 func NewAPIHandler(
 	engine storage.Engine, es events.Service,
-	amw *echoaudit.Middleware, middleware ...echo.MiddlewareFunc,
+	amw *echoaudit.Middleware, opts ...Opt,
 ) (*APIHandler, error) {
 	validationMiddleware, err := oapiValidationMiddleware()
 	if err != nil {
@@ -77,7 +113,10 @@ func NewAPIHandler(
 		handler:              &handler,
 		validationMiddleware: validationMiddleware,
 		auditMiddleware:      amw,
-		middleware:           middleware,
+	}
+
+	for _, opt := range opts {
+		opt(out)
 	}
 
 	return out, nil
@@ -2,13 +2,16 @@ package httpsrv
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"go.infratographer.com/permissions-api/pkg/permissions"
 	"go.infratographer.com/x/gidx"
 
+	"go.infratographer.com/identity-api/internal/discovery"
 	"go.infratographer.com/identity-api/internal/types"
 	v1 "go.infratographer.com/identity-api/pkg/api/v1"
 )
@@ -32,6 +35,9 @@ func (h *apiHandler) CreateIssuer(ctx context.Context, req CreateIssuerRequestOb
 	var (
 		claimsMapping   types.ClaimsMapping
 		claimConditions *types.ClaimConditions
+		actorConditions *types.ClaimConditions
+		scopeMapping    *types.ScopeMapping
+		profileMapping  *types.ProfileMapping
 		err             error
 	)
 
@@ -58,6 +64,126 @@ func (h *apiHandler) CreateIssuer(ctx context.Context, req CreateIssuerRequestOb
 		claimConditions = cond
 	}
 
+	if createOp.ActorConditions != nil {
+		cond, err := types.NewClaimConditions(*createOp.ActorConditions)
+		if err != nil {
+			err = echo.NewHTTPError(http.StatusBadRequest, err.Error())
+
+			return nil, err
+		}
+
+		actorConditions = cond
+	}
+
+	if createOp.ScopeMapping != nil {
+		mapping, err := types.NewScopeMapping(*createOp.ScopeMapping)
+		if err != nil {
+			err = echo.NewHTTPError(http.StatusBadRequest, err.Error())
+
+			return nil, err
+		}
+
+		scopeMapping = mapping
+	}
+
+	if createOp.ProfileMapping != nil {
+		mapping, err := types.NewProfileMapping(*createOp.ProfileMapping)
+		if err != nil {
+			err = echo.NewHTTPError(http.StatusBadRequest, err.Error())
+
+			return nil, err
+		}
+
+		profileMapping = mapping
+	}
+
+	var (
+		jwksURI                   string
+		userInfoURI               string
+		clientID                  string
+		introspectionURI          string
+		introspectionClientID     string
+		introspectionClientSecret string
+	)
+
+	if createOp.JWKSURI != nil {
+		jwksURI = *createOp.JWKSURI
+	}
+
+	if createOp.UserInfoURI != nil {
+		userInfoURI = *createOp.UserInfoURI
+	}
+
+	if createOp.ClientID != nil {
+		clientID = *createOp.ClientID
+	}
+
+	if createOp.IntrospectionURI != nil {
+		introspectionURI = *createOp.IntrospectionURI
+	}
+
+	if createOp.IntrospectionClientID != nil {
+		introspectionClientID = *createOp.IntrospectionClientID
+	}
+
+	if createOp.IntrospectionClientSecret != nil {
+		introspectionClientSecret = *createOp.IntrospectionClientSecret
+	}
+
+	allowedAudiences := []string{}
+	if createOp.AllowedAudiences != nil {
+		allowedAudiences = *createOp.AllowedAudiences
+	}
+
+	allowedAlgorithms := []string{}
+	if createOp.AllowedAlgorithms != nil {
+		allowedAlgorithms = *createOp.AllowedAlgorithms
+	}
+
+	if err := types.ValidateIssuerAlgorithms(allowedAlgorithms); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	requiredAudiences := []string{}
+	if createOp.RequiredAudiences != nil {
+		requiredAudiences = *createOp.RequiredAudiences
+	}
+
+	var maxTokenAge, clockSkew time.Duration
+
+	if createOp.MaxTokenAge != nil {
+		maxTokenAge = time.Duration(*createOp.MaxTokenAge) * time.Second
+	}
+
+	if createOp.ClockSkew != nil {
+		clockSkew = time.Duration(*createOp.ClockSkew) * time.Second
+	}
+
+	var userInfoUpdateMode types.UserInfoUpdateMode
+	if createOp.UserInfoUpdateMode != nil {
+		userInfoUpdateMode = types.UserInfoUpdateMode(*createOp.UserInfoUpdateMode)
+	}
+
+	// Issuers created from just their URI discover their endpoints.
+	discover := jwksURI == "" && introspectionURI == ""
+	if createOp.Discovery != nil {
+		discover = *createOp.Discovery
+	}
+
+	if discover {
+		metadata, err := h.discover(ctx, createOp.URI)
+		if err != nil {
+			return nil, err
+		}
+
+		jwksURI = metadata.JWKSURI
+		userInfoURI = metadata.UserInfoEndpoint
+	}
+
+	if jwksURI == "" && introspectionURI == "" {
+		return nil, errorMissingTokenValidation
+	}
+
 	id, err := gidx.NewID(types.IdentityIssuerIDPrefix)
 	if err != nil {
 		err = errorWithStatus{
@@ -73,13 +199,33 @@ func (h *apiHandler) CreateIssuer(ctx context.Context, req CreateIssuerRequestOb
 		ID:              id,
 		Name:            createOp.Name,
 		URI:             createOp.URI,
-		JWKSURI:         createOp.JWKSURI,
+		JWKSURI:         jwksURI,
+		UserInfoURI:     userInfoURI,
+		Discovery:       discover,
+		ClientID:        clientID,
 		ClaimMappings:   claimsMapping,
 		ClaimConditions: claimConditions,
+		ActorConditions: actorConditions,
+		ScopeMapping:    scopeMapping,
+		ProfileMapping:  profileMapping,
+
+		IntrospectionURI:          introspectionURI,
+		IntrospectionClientID:     introspectionClientID,
+		IntrospectionClientSecret: introspectionClientSecret,
+		AllowedAudiences:          allowedAudiences,
+		AllowedAlgorithms:         allowedAlgorithms,
+		RequiredAudiences:         requiredAudiences,
+		MaxTokenAge:               maxTokenAge,
+		ClockSkew:                 clockSkew,
+		UserInfoUpdateMode:        userInfoUpdateMode,
 	}
 
 	issuer, err := h.engine.CreateIssuer(ctx, issuerToCreate)
-	if err != nil {
+	switch {
+	case err == nil:
+	case errors.Is(err, types.ErrIssuerSecretsDisabled):
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
 		return nil, err
 	}
 
@@ -159,6 +305,53 @@ func (h *apiHandler) UpdateIssuer(ctx context.Context, req UpdateIssuerRequestOb
 
 	updateOp := req.Body
 
+	update := types.IssuerUpdate{
+		Name:             updateOp.Name,
+		URI:              updateOp.URI,
+		JWKSURI:          updateOp.JWKSURI,
+		UserInfoURI:      updateOp.UserInfoURI,
+		Discovery:        updateOp.Discovery,
+		ClientID:         updateOp.ClientID,
+		IntrospectionURI: updateOp.IntrospectionURI,
+
+		IntrospectionClientID:     updateOp.IntrospectionClientID,
+		IntrospectionClientSecret: updateOp.IntrospectionClientSecret,
+	}
+
+	// Endpoints are discovered again when discovery is enabled or the URI of an issuer with
+	// discovery enabled changes.
+	enabled := updateOp.Discovery != nil && *updateOp.Discovery
+	uriChanged := updateOp.URI != nil && *updateOp.URI != iss.URI
+
+	if enabled || (iss.Discovery && updateOp.Discovery == nil && uriChanged) {
+		uri := iss.URI
+		if updateOp.URI != nil {
+			uri = *updateOp.URI
+		}
+
+		metadata, err := h.discover(ctx, uri)
+		if err != nil {
+			return nil, err
+		}
+
+		update.JWKSURI = &metadata.JWKSURI
+		update.UserInfoURI = &metadata.UserInfoEndpoint
+	}
+
+	jwksURI := iss.JWKSURI
+	if update.JWKSURI != nil {
+		jwksURI = *update.JWKSURI
+	}
+
+	introspectionURI := iss.IntrospectionURI
+	if updateOp.IntrospectionURI != nil {
+		introspectionURI = *updateOp.IntrospectionURI
+	}
+
+	if jwksURI == "" && introspectionURI == "" {
+		return nil, errorMissingTokenValidation
+	}
+
 	var claimsMapping types.ClaimsMapping
 
 	if updateOp.ClaimMappings != nil {
@@ -184,19 +377,83 @@ func (h *apiHandler) UpdateIssuer(ctx context.Context, req UpdateIssuerRequestOb
 		}
 	}
 
-	update := types.IssuerUpdate{
-		Name:            updateOp.Name,
-		URI:             updateOp.URI,
-		JWKSURI:         updateOp.JWKSURI,
-		ClaimMappings:   claimsMapping,
-		ClaimConditions: claimConditions,
+	var actorConditions *types.ClaimConditions
+
+	if updateOp.ActorConditions != nil {
+		actorConditions, err = types.NewClaimConditions(*updateOp.ActorConditions)
+		if err != nil {
+			err = echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("error parsing CEL expression: %w", err))
+
+			return nil, err
+		}
+	}
+
+	var scopeMapping *types.ScopeMapping
+
+	if updateOp.ScopeMapping != nil {
+		scopeMapping, err = types.NewScopeMapping(*updateOp.ScopeMapping)
+		if err != nil {
+			err = echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("error parsing CEL expression: %w", err))
+
+			return nil, err
+		}
+	}
+
+	var profileMapping *types.ProfileMapping
+
+	if updateOp.ProfileMapping != nil {
+		profileMapping, err = types.NewProfileMapping(*updateOp.ProfileMapping)
+		if err != nil {
+			err = echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("error parsing CEL expression: %w", err))
+
+			return nil, err
+		}
+	}
+
+	update.ClaimMappings = claimsMapping
+	update.ClaimConditions = claimConditions
+	update.ActorConditions = actorConditions
+	update.ScopeMapping = scopeMapping
+	update.ProfileMapping = profileMapping
+
+	if updateOp.AllowedAudiences != nil {
+		update.AllowedAudiences = *updateOp.AllowedAudiences
+	}
+
+	if updateOp.AllowedAlgorithms != nil {
+		if err := types.ValidateIssuerAlgorithms(*updateOp.AllowedAlgorithms); err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		update.AllowedAlgorithms = *updateOp.AllowedAlgorithms
+	}
+
+	if updateOp.RequiredAudiences != nil {
+		update.RequiredAudiences = *updateOp.RequiredAudiences
+	}
+
+	if updateOp.MaxTokenAge != nil {
+		maxTokenAge := time.Duration(*updateOp.MaxTokenAge) * time.Second
+		update.MaxTokenAge = &maxTokenAge
+	}
+
+	if updateOp.ClockSkew != nil {
+		clockSkew := time.Duration(*updateOp.ClockSkew) * time.Second
+		update.ClockSkew = &clockSkew
+	}
+
+	if updateOp.UserInfoUpdateMode != nil {
+		userInfoUpdateMode := types.UserInfoUpdateMode(*updateOp.UserInfoUpdateMode)
+		update.UserInfoUpdateMode = &userInfoUpdateMode
 	}
 
 	issuer, err := h.engine.UpdateIssuer(ctx, req.Id, update)
-	switch err {
-	case nil:
-	case types.ErrorIssuerNotFound:
+	switch {
+	case err == nil:
+	case errors.Is(err, types.ErrorIssuerNotFound):
 		return nil, errorNotFound
+	case errors.Is(err, types.ErrIssuerSecretsDisabled):
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	default:
 		return nil, err
 	}
@@ -237,3 +494,21 @@ func (h *apiHandler) DeleteIssuer(ctx context.Context, req DeleteIssuerRequestOb
 
 	return DeleteIssuer200JSONResponse(out), nil
 }
+
+// discover retrieves the OIDC provider metadata of the issuer with the given URI.
+func (h *apiHandler) discover(ctx context.Context, uri string) (discovery.Metadata, error) {
+	client := h.discoveryClient
+	if client == nil {
+		client = discovery.NewClient(nil)
+	}
+
+	metadata, err := client.Discover(ctx, uri)
+	if err != nil {
+		return discovery.Metadata{}, echo.NewHTTPError(
+			http.StatusBadRequest,
+			fmt.Sprintf("failed to discover issuer endpoints: %s", err.Error()),
+		)
+	}
+
+	return metadata, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/labstack/echo/v4"
 	"go.infratographer.com/permissions-api/pkg/permissions"
 
 	"go.infratographer.com/identity-api/internal/crypto"
@@ -14,10 +15,11 @@ import (
 const defaultTokenLength = 26
 
 const (
-	actionOAuthClientCreate = "iam_oauthclient_create"
-	actionOAuthClientDelete = "iam_oauthclient_delete"
-	actionOAuthClientGet    = "iam_oauthclient_get"
-	actionOAuthClientList   = "iam_oauthclient_list"
+	actionOAuthClientCreate       = "iam_oauthclient_create"
+	actionOAuthClientDelete       = "iam_oauthclient_delete"
+	actionOAuthClientGet          = "iam_oauthclient_get"
+	actionOAuthClientList         = "iam_oauthclient_list"
+	actionOAuthClientTokensRevoke = "iam_oauthclient_tokens_revoke"
 )
 
 // CreateOAuthClient creates a client for a owner with a set name.
@@ -38,6 +40,20 @@ func (h *apiHandler) CreateOAuthClient(ctx context.Context, request CreateOAuthC
 		newClient.Audience = *request.Body.Audience
 	}
 
+	newClient.Scopes = []string{}
+	if request.Body.Scopes != nil {
+		newClient.Scopes = *request.Body.Scopes
+	}
+
+	newClient.GrantTypes = types.DefaultOAuthClientGrantTypes()
+	if request.Body.GrantTypes != nil {
+		newClient.GrantTypes = *request.Body.GrantTypes
+	}
+
+	if err := types.ValidateOAuthClientGrantTypes(newClient.GrantTypes); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
 	secret, err := crypto.GenerateSecureToken(defaultTokenLength)
 	if err != nil {
 		return nil, err
@@ -139,3 +155,29 @@ func (h *apiHandler) DeleteOAuthClient(ctx context.Context, request DeleteOAuthC
 
 	return DeleteOAuthClient200JSONResponse{Success: true}, nil
 }
+
+// RevokeOAuthClientTokens revokes all tokens issued to the OAuth client.
+func (h *apiHandler) RevokeOAuthClientTokens(ctx context.Context, request RevokeOAuthClientTokensRequestObject) (RevokeOAuthClientTokensResponseObject, error) {
+	// We must fetch the oauth client to retrieve the owner so we may check for permission to revoke tokens.
+	client, err := h.engine.LookupOAuthClientByID(ctx, request.ClientID)
+	switch err {
+	case nil:
+	case types.ErrOAuthClientNotFound:
+		return nil, errorWithStatus{
+			status:  http.StatusNotFound,
+			message: err.Error(),
+		}
+	default:
+		return nil, err
+	}
+
+	if err := permissions.CheckAccess(ctx, client.OwnerID, actionOAuthClientTokensRevoke); err != nil {
+		return nil, permissionsError(err)
+	}
+
+	if err := h.engine.RevokeClientTokens(ctx, request.ClientID); err != nil {
+		return nil, err
+	}
+
+	return RevokeOAuthClientTokens200JSONResponse{Success: true}, nil
+}
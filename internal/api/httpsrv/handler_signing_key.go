@@ -0,0 +1,141 @@
+package httpsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/permissions-api/pkg/permissions"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+const (
+	actionSigningKeyList     = "iam_signingkey_list"
+	actionSigningKeyCreate   = "iam_signingkey_create"
+	actionSigningKeyActivate = "iam_signingkey_activate"
+	actionSigningKeyRetire   = "iam_signingkey_retire"
+)
+
+// checkSigningKeyAccess checks access to the signing keys, which are all owned by the configured owner.
+func (h *apiHandler) checkSigningKeyAccess(ctx context.Context, action string) error {
+	if h.signingKeyOwnerID == "" {
+		return echo.NewHTTPError(http.StatusNotFound, types.ErrSigningKeysDisabled.Error())
+	}
+
+	if err := permissions.CheckAccess(ctx, h.signingKeyOwnerID, action); err != nil {
+		return permissionsError(err)
+	}
+
+	return nil
+}
+
+// ListSigningKeys lists the signing keys stored in the database.
+func (h *apiHandler) ListSigningKeys(ctx context.Context, _ ListSigningKeysRequestObject) (ListSigningKeysResponseObject, error) {
+	if err := h.checkSigningKeyAccess(ctx, actionSigningKeyList); err != nil {
+		return nil, err
+	}
+
+	keys, err := h.engine.ListSigningKeys(ctx)
+	if err != nil {
+		return nil, signingKeyError(err)
+	}
+
+	collection := SigningKeyCollectionJSONResponse{
+		SigningKeys: keys.ToV1SigningKeys(),
+	}
+
+	return ListSigningKeys200JSONResponse{collection}, nil
+}
+
+// CreateSigningKey generates a signing key. The key is published once every replica has reloaded
+// its keys, and does not sign tokens until it is activated.
+func (h *apiHandler) CreateSigningKey(ctx context.Context, req CreateSigningKeyRequestObject) (CreateSigningKeyResponseObject, error) {
+	if err := h.checkSigningKeyAccess(ctx, actionSigningKeyCreate); err != nil {
+		return nil, err
+	}
+
+	alg := jose.SignatureAlgorithm(req.Body.Algorithm)
+
+	privateKey, err := fositex.GenerateKey(alg)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	id, err := gidx.NewID(types.IdentitySigningKeyIDPrefix)
+	if err != nil {
+		err = echo.NewHTTPError(
+			http.StatusInternalServerError,
+			fmt.Sprintf("failed to generate new id: %s", err.Error()),
+		)
+
+		return nil, err
+	}
+
+	key := types.SigningKey{
+		ID:         id,
+		Algorithm:  string(alg),
+		State:      types.SigningKeyStatePending,
+		PrivateKey: privateKey,
+	}
+
+	if req.Body.ActivateAt != nil {
+		key.ActivateAt = *req.Body.ActivateAt
+	}
+
+	created, err := h.engine.CreateSigningKey(ctx, key)
+	if err != nil {
+		return nil, signingKeyError(err)
+	}
+
+	return CreateSigningKey200JSONResponse(created.ToV1SigningKey()), nil
+}
+
+// ActivateSigningKey activates a signing key. The key starts signing tokens once every replica has
+// reloaded its keys, so tokens it signs can be validated by any replica.
+func (h *apiHandler) ActivateSigningKey(ctx context.Context, req ActivateSigningKeyRequestObject) (ActivateSigningKeyResponseObject, error) {
+	if err := h.checkSigningKeyAccess(ctx, actionSigningKeyActivate); err != nil {
+		return nil, err
+	}
+
+	key, err := h.engine.ActivateSigningKey(ctx, req.KeyID, time.Now().Add(h.signingKeyActivationDelay))
+	if err != nil {
+		return nil, signingKeyError(err)
+	}
+
+	return ActivateSigningKey200JSONResponse(key.ToV1SigningKey()), nil
+}
+
+// RetireSigningKey retires a signing key. Retired keys are published until the tokens they signed
+// have expired.
+func (h *apiHandler) RetireSigningKey(ctx context.Context, req RetireSigningKeyRequestObject) (RetireSigningKeyResponseObject, error) {
+	if err := h.checkSigningKeyAccess(ctx, actionSigningKeyRetire); err != nil {
+		return nil, err
+	}
+
+	key, err := h.engine.RetireSigningKey(ctx, req.KeyID)
+	if err != nil {
+		return nil, signingKeyError(err)
+	}
+
+	return RetireSigningKey200JSONResponse(key.ToV1SigningKey()), nil
+}
+
+func signingKeyError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrSigningKeyInUse):
+		return echo.NewHTTPError(http.StatusConflict, "signing key is in use, activate another signing key first")
+	case errors.Is(err, types.ErrSigningKeyRetired):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, types.ErrNotFound), errors.Is(err, types.ErrSigningKeysDisabled):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	default:
+		return err
+	}
+}
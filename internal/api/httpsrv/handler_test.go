@@ -2,18 +2,28 @@ package httpsrv
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/token/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.infratographer.com/permissions-api/pkg/permissions"
+	"go.infratographer.com/permissions-api/pkg/permissions/mockpermissions"
 	"go.infratographer.com/x/crdbx"
+	eventsx "go.infratographer.com/x/events"
 	"go.infratographer.com/x/gidx"
 
 	pagination "go.infratographer.com/identity-api/internal/crdbx"
+	"go.infratographer.com/identity-api/internal/discovery"
+	"go.infratographer.com/identity-api/internal/events"
 	"go.infratographer.com/identity-api/internal/storage"
 	"go.infratographer.com/identity-api/internal/testingx"
 	"go.infratographer.com/identity-api/internal/types"
@@ -106,13 +116,27 @@ func TestAPIHandler(t *testing.T) {
 	t.Run("CreateIssuer", func(t *testing.T) {
 		t.Parallel()
 
+		// idp stands in for an upstream identity provider publishing its OIDC provider metadata.
+		var idp *httptest.Server
+
+		idp = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_ = json.NewEncoder(w).Encode(discovery.Metadata{
+				Issuer:           idp.URL,
+				JWKSURI:          idp.URL + "/jwks.json",
+				UserInfoEndpoint: idp.URL + "/userinfo",
+			})
+		}))
+
+		t.Cleanup(idp.Close)
+
 		handler := apiHandler{
-			engine: store,
+			engine:          store,
+			discoveryClient: discovery.NewClient(idp.Client()),
 		}
 
 		createOp := &v1.CreateIssuer{
 			ClaimMappings: &mappingStrs,
-			JWKSURI:       "https://issuer.info/jwks.json",
+			JWKSURI:       ptr("https://issuer.info/jwks.json"),
 			Name:          "Good issuer",
 			URI:           "https://issuer.info/",
 		}
@@ -153,13 +177,20 @@ func TestAPIHandler(t *testing.T) {
 					obsIssuer := v1.Issuer(resp)
 
 					expIssuer := v1.Issuer{
-						ID:            obsIssuer.ID,
-						ClaimMappings: *createOp.ClaimMappings,
-						JWKSURI:       createOp.JWKSURI,
-						Name:          createOp.Name,
-						URI:           createOp.URI,
+						AllowedAudiences:   []string{},
+						AllowedAlgorithms:  []string{},
+						RequiredAudiences:  []string{},
+						UserInfoUpdateMode: v1.UserInfoUpdateModeOnChange,
+						ID:                 obsIssuer.ID,
+						ClaimMappings:      *createOp.ClaimMappings,
+						JWKSURI:            *createOp.JWKSURI,
+						Name:               createOp.Name,
+						URI:                createOp.URI,
+						CreatedAt:          obsIssuer.CreatedAt,
+						UpdatedAt:          obsIssuer.UpdatedAt,
 					}
 
+					assert.False(t, obsIssuer.CreatedAt.IsZero())
 					assert.Equal(t, expIssuer, obsIssuer)
 				},
 				CleanupFn: cleanupFn,
@@ -172,7 +203,7 @@ func TestAPIHandler(t *testing.T) {
 						ClaimMappings: &map[string]string{
 							"bad": "'123",
 						},
-						JWKSURI: "https://bad.info/jwks.json",
+						JWKSURI: ptr("https://bad.info/jwks.json"),
 						Name:    "Bad issuer",
 						URI:     "https://bad.info/",
 					},
@@ -188,12 +219,68 @@ func TestAPIHandler(t *testing.T) {
 				},
 				CleanupFn: cleanupFn,
 			},
+			{
+				Name: "Discovery",
+				Input: CreateIssuerRequestObject{
+					OwnerID: ownerID,
+					Body: &v1.CreateIssuer{
+						Name: "Discovered issuer",
+						URI:  idp.URL,
+					},
+				},
+				SetupFn: setupFn,
+				CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[CreateIssuerResponseObject]) {
+					require.NoError(t, result.Err)
+
+					resp, ok := result.Success.(CreateIssuer200JSONResponse)
+					require.True(t, ok)
+
+					assert.True(t, resp.Discovery)
+					assert.Equal(t, idp.URL+"/jwks.json", resp.JWKSURI)
+					assert.Equal(t, idp.URL+"/userinfo", resp.UserInfoURI)
+				},
+				CleanupFn: cleanupFn,
+			},
+			{
+				Name: "DiscoveryFailed",
+				Input: CreateIssuerRequestObject{
+					OwnerID: ownerID,
+					Body: &v1.CreateIssuer{
+						Name: "Bad issuer",
+						URI:  idp.URL + "/other",
+					},
+				},
+				SetupFn: setupFn,
+				CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[CreateIssuerResponseObject]) {
+					var httpErr *echo.HTTPError
+
+					require.ErrorAs(t, result.Err, &httpErr)
+					assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+				},
+				CleanupFn: cleanupFn,
+			},
+			{
+				Name: "MissingTokenValidation",
+				Input: CreateIssuerRequestObject{
+					OwnerID: ownerID,
+					Body: &v1.CreateIssuer{
+						Name:      "Bad issuer",
+						URI:       "https://bad.info/",
+						Discovery: ptr(false),
+					},
+				},
+				SetupFn: setupFn,
+				CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[CreateIssuerResponseObject]) {
+					assert.ErrorIs(t, result.Err, errorMissingTokenValidation)
+				},
+				CleanupFn: cleanupFn,
+			},
 			{
 				Name: "ConditionInvalidCEL",
 				Input: CreateIssuerRequestObject{
 					OwnerID: ownerID,
 					Body: &v1.CreateIssuer{
-						JWKSURI:         "https://bad.info/jwks.json",
+						JWKSURI:         ptr("https://bad.info/jwks.json"),
 						Name:            "Bad issuer",
 						URI:             "https://bad.info/",
 						ClaimConditions: ptr(`this CEL bad bad`),
@@ -220,7 +307,7 @@ func TestAPIHandler(t *testing.T) {
 				Input: CreateIssuerRequestObject{
 					OwnerID: ownerID,
 					Body: &v1.CreateIssuer{
-						JWKSURI:         "https://bad.info/jwks.json",
+						JWKSURI:         ptr("https://bad.info/jwks.json"),
 						Name:            "Bad issuer",
 						URI:             "https://bad.info/",
 						ClaimConditions: ptr(`"this-is-a-string"`),
@@ -247,7 +334,7 @@ func TestAPIHandler(t *testing.T) {
 				Input: CreateIssuerRequestObject{
 					OwnerID: ownerID,
 					Body: &v1.CreateIssuer{
-						JWKSURI:         "https://good.info/jwks.json",
+						JWKSURI:         ptr("https://good.info/jwks.json"),
 						Name:            "Good issuer",
 						URI:             "https://good.info/",
 						ClaimConditions: &conditionStr,
@@ -266,14 +353,21 @@ func TestAPIHandler(t *testing.T) {
 					obsIssuer := v1.Issuer(resp)
 
 					expIssuer := v1.Issuer{
-						ID:              obsIssuer.ID,
-						ClaimConditions: conditionStr,
-						ClaimMappings:   map[string]string{},
-						JWKSURI:         "https://good.info/jwks.json",
-						Name:            "Good issuer",
-						URI:             "https://good.info/",
+						AllowedAudiences:   []string{},
+						AllowedAlgorithms:  []string{},
+						RequiredAudiences:  []string{},
+						UserInfoUpdateMode: v1.UserInfoUpdateModeOnChange,
+						ID:                 obsIssuer.ID,
+						ClaimConditions:    conditionStr,
+						ClaimMappings:      map[string]string{},
+						JWKSURI:            "https://good.info/jwks.json",
+						Name:               "Good issuer",
+						URI:                "https://good.info/",
+						CreatedAt:          obsIssuer.CreatedAt,
+						UpdatedAt:          obsIssuer.UpdatedAt,
 					}
 
+					assert.False(t, obsIssuer.CreatedAt.IsZero())
 					assert.Equal(t, expIssuer, obsIssuer)
 				},
 			},
@@ -311,14 +405,6 @@ func TestAPIHandler(t *testing.T) {
 						return
 					}
 
-					expIssuer := v1.Issuer{
-						ID:            issuerID,
-						ClaimMappings: mappingStrs,
-						JWKSURI:       issuer.JWKSURI,
-						Name:          issuer.Name,
-						URI:           issuer.URI,
-					}
-
 					resp, ok := result.Success.(GetIssuerByID200JSONResponse)
 					if !ok {
 						assert.FailNow(t, "unexpected result type for get issuer response")
@@ -326,6 +412,21 @@ func TestAPIHandler(t *testing.T) {
 
 					obsIssuer := v1.Issuer(resp)
 
+					expIssuer := v1.Issuer{
+						AllowedAudiences:   []string{},
+						AllowedAlgorithms:  []string{},
+						RequiredAudiences:  []string{},
+						UserInfoUpdateMode: v1.UserInfoUpdateModeOnChange,
+						ID:                 issuerID,
+						ClaimMappings:      mappingStrs,
+						JWKSURI:            issuer.JWKSURI,
+						Name:               issuer.Name,
+						URI:                issuer.URI,
+						CreatedAt:          obsIssuer.CreatedAt,
+						UpdatedAt:          obsIssuer.UpdatedAt,
+					}
+
+					assert.False(t, obsIssuer.CreatedAt.IsZero())
 					assert.Equal(t, expIssuer, obsIssuer)
 				},
 			},
@@ -370,7 +471,6 @@ func TestAPIHandler(t *testing.T) {
 				URI:     "https://" + t.Name() + "-1.example.com/",
 				JWKSURI: "https://" + t.Name() + "-1.example.com/.well-known/jwks.json",
 			}
-			v1iss1 = must(iss1.ToV1Issuer())
 
 			iss2 = types.Issuer{
 				OwnerID: issOwnerID,
@@ -379,7 +479,6 @@ func TestAPIHandler(t *testing.T) {
 				URI:     "https://" + t.Name() + "-2.example.com/",
 				JWKSURI: "https://" + t.Name() + "-2.example.com/.well-known/jwks.json",
 			}
-			v1iss2 = must(iss2.ToV1Issuer())
 
 			iss3 = types.Issuer{
 				OwnerID: gidx.MustNewID("testten"),
@@ -392,6 +491,8 @@ func TestAPIHandler(t *testing.T) {
 
 		withStoredIssuers(t, store, &iss1, &iss2, &iss3)
 
+		v1iss1, v1iss2 := must(iss1.ToV1Issuer()), must(iss2.ToV1Issuer())
+
 		testCases := []testingx.TestCase[ListOwnerIssuersRequestObject, ListOwnerIssuersResponseObject]{
 			{
 				Name: "Success (Default Pagination)",
@@ -579,15 +680,6 @@ func TestAPIHandler(t *testing.T) {
 						return
 					}
 
-					expIssuer := v1.Issuer{
-						ID:              issuerID,
-						ClaimMappings:   mappingStrs,
-						ClaimConditions: conditionStr,
-						JWKSURI:         issuer.JWKSURI,
-						Name:            newName,
-						URI:             issuer.URI,
-					}
-
 					resp, ok := result.Success.(UpdateIssuer200JSONResponse)
 					if !ok {
 						assert.FailNow(t, "unexpected result type for update issuer response")
@@ -595,6 +687,22 @@ func TestAPIHandler(t *testing.T) {
 
 					obsIssuer := v1.Issuer(resp)
 
+					expIssuer := v1.Issuer{
+						AllowedAudiences:   []string{},
+						AllowedAlgorithms:  []string{},
+						RequiredAudiences:  []string{},
+						UserInfoUpdateMode: v1.UserInfoUpdateModeOnChange,
+						ID:                 issuerID,
+						ClaimMappings:      mappingStrs,
+						ClaimConditions:    conditionStr,
+						JWKSURI:            issuer.JWKSURI,
+						Name:               newName,
+						URI:                issuer.URI,
+						CreatedAt:          obsIssuer.CreatedAt,
+						UpdatedAt:          obsIssuer.UpdatedAt,
+					}
+
+					assert.False(t, obsIssuer.CreatedAt.IsZero())
 					assert.Equal(t, expIssuer, obsIssuer)
 				},
 				CleanupFn: cleanupFn,
@@ -794,6 +902,7 @@ func TestAPIHandler(t *testing.T) {
 					Body: &v1.CreateOAuthClientJSONRequestBody{
 						Name:     "test-client",
 						Audience: &[]string{"aud1", "aud2"},
+						Scopes:   &[]string{"read", "write"},
 					},
 				},
 				SetupFn: setupFn,
@@ -804,6 +913,27 @@ func TestAPIHandler(t *testing.T) {
 					assert.NotEmpty(t, resp.ID)
 					assert.NotEmpty(t, *resp.Secret)
 					assert.Equal(t, []string{"aud1", "aud2"}, resp.Audience)
+					assert.Equal(t, []string{"read", "write"}, resp.Scopes)
+					assert.Equal(t, types.DefaultOAuthClientGrantTypes(), resp.GrantTypes)
+				},
+				CleanupFn: cleanupFn,
+			},
+			{
+				Name: "InvalidGrantType",
+				Input: CreateOAuthClientRequestObject{
+					OwnerID: gidx.MustNewID("testten"),
+					Body: &v1.CreateOAuthClientJSONRequestBody{
+						Name:       "test-client",
+						GrantTypes: &[]string{"password"},
+					},
+				},
+				SetupFn: setupFn,
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[CreateOAuthClientResponseObject]) {
+					assert.Error(t, res.Err)
+
+					httpErr, ok := res.Err.(*echo.HTTPError)
+					assert.True(t, ok)
+					assert.Equal(t, http.StatusBadRequest, httpErr.Code)
 				},
 				CleanupFn: cleanupFn,
 			},
@@ -1239,6 +1369,314 @@ func TestAPIHandler(t *testing.T) {
 		testingx.RunTests(ctxPermsAllow(context.Background()), t, testCases, runFn)
 	})
 
+	t.Run("RevokeUserTokens", func(t *testing.T) {
+		t.Parallel()
+
+		handler := apiHandler{
+			engine: store,
+		}
+		issuer := types.Issuer{
+			OwnerID: ownerID,
+			Name:    "Example",
+			URI:     "https://example3.com/",
+			JWKSURI: "https://example3.com/.well-known/jwks.json",
+		}
+
+		withStoredIssuers(t, store, &issuer)
+
+		userInfo := types.UserInfo{
+			Name:    t.Name(),
+			Email:   t.Name() + "@example.com",
+			Issuer:  issuer.URI,
+			Subject: t.Name() + "Test",
+		}
+
+		withStoredUsers(t, store, &userInfo)
+
+		expiresAt := time.Now().Add(time.Hour)
+
+		request := fosite.NewRequest()
+		request.Session = &oauth2.JWTSession{
+			JWTClaims: &jwt.JWTClaims{
+				Subject: userInfo.ID.String(),
+			},
+			ExpiresAt: map[fosite.TokenType]time.Time{
+				fosite.AccessToken: expiresAt,
+			},
+		}
+
+		jti := t.Name() + "-jti"
+
+		tokenStore, ok := store.(oauth2.AccessTokenStorage)
+		require.True(t, ok)
+
+		require.NoError(t, tokenStore.CreateAccessTokenSession(context.Background(), jti, request))
+
+		setupFn := func(ctx context.Context) context.Context {
+			ctx, err := store.BeginContext(ctx)
+			if !assert.NoError(t, err) {
+				assert.FailNow(t, "setup failed")
+			}
+
+			return ctx
+		}
+
+		cleanupFn := func(ctx context.Context) {
+			err := store.RollbackContext(ctx)
+			assert.NoError(t, err)
+		}
+
+		testCases := []testingx.TestCase[RevokeUserTokensRequestObject, RevokeUserTokensResponseObject]{
+			{
+				Name: "NotFound",
+				Input: RevokeUserTokensRequestObject{
+					UserID: gidx.MustNewID(types.IdentityUserIDPrefix),
+				},
+				SetupFn:   setupFn,
+				CleanupFn: cleanupFn,
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[RevokeUserTokensResponseObject]) {
+					assert.IsType(t, errorWithStatus{}, res.Err)
+					assert.Equal(t, http.StatusNotFound, res.Err.(errorWithStatus).status)
+				},
+			},
+			{
+				Name: "Success",
+				Input: RevokeUserTokensRequestObject{
+					UserID: userInfo.ID,
+				},
+				SetupFn:   setupFn,
+				CleanupFn: cleanupFn,
+				CheckFn: func(ctx context.Context, t *testing.T, res testingx.TestResult[RevokeUserTokensResponseObject]) {
+					require.NoError(t, res.Err)
+					assert.Equal(t, RevokeUserTokens200JSONResponse{Success: true}, res.Success)
+
+					revoked, err := store.IsAccessTokenRevoked(ctx, jti)
+					require.NoError(t, err)
+					assert.True(t, revoked)
+				},
+			},
+		}
+
+		runFn := func(ctx context.Context, input RevokeUserTokensRequestObject) testingx.TestResult[RevokeUserTokensResponseObject] {
+			resp, err := handler.RevokeUserTokens(ctx, input)
+
+			return testingx.TestResult[RevokeUserTokensResponseObject]{
+				Success: resp,
+				Err:     err,
+			}
+		}
+
+		testingx.RunTests(ctxPermsAllow(context.Background()), t, testCases, runFn)
+	})
+
+	t.Run("UpdateUser", func(t *testing.T) {
+		t.Parallel()
+
+		handler := apiHandler{
+			engine: store,
+		}
+
+		issuer := types.Issuer{
+			OwnerID: ownerID,
+			Name:    "Example",
+			URI:     "https://example4.com/",
+			JWKSURI: "https://example4.com/.well-known/jwks.json",
+		}
+
+		withStoredIssuers(t, store, &issuer)
+
+		userInfo := types.UserInfo{
+			Name:    t.Name(),
+			Email:   t.Name() + "@example.com",
+			Issuer:  issuer.URI,
+			Subject: t.Name() + "Test",
+		}
+
+		withStoredUsers(t, store, &userInfo)
+
+		setupFn := func(ctx context.Context) context.Context {
+			ctx, err := store.BeginContext(ctx)
+			if !assert.NoError(t, err) {
+				assert.FailNow(t, "setup failed")
+			}
+
+			return ctx
+		}
+
+		cleanupFn := func(ctx context.Context) {
+			err := store.RollbackContext(ctx)
+			assert.NoError(t, err)
+		}
+
+		testCases := []testingx.TestCase[UpdateUserRequestObject, UpdateUserResponseObject]{
+			{
+				Name: "NotFound",
+				Input: UpdateUserRequestObject{
+					UserID: gidx.MustNewID(types.IdentityUserIDPrefix),
+					Body:   &v1.UpdateUserJSONRequestBody{Disabled: ptr(true)},
+				},
+				SetupFn:   setupFn,
+				CleanupFn: cleanupFn,
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[UpdateUserResponseObject]) {
+					assert.IsType(t, errorWithStatus{}, res.Err)
+					assert.Equal(t, http.StatusNotFound, res.Err.(errorWithStatus).status)
+				},
+			},
+			{
+				Name: "Disable",
+				Input: UpdateUserRequestObject{
+					UserID: userInfo.ID,
+					Body:   &v1.UpdateUserJSONRequestBody{Disabled: ptr(true)},
+				},
+				SetupFn:   setupFn,
+				CleanupFn: cleanupFn,
+				CheckFn: func(ctx context.Context, t *testing.T, res testingx.TestResult[UpdateUserResponseObject]) {
+					require.NoError(t, res.Err)
+
+					resp, ok := res.Success.(UpdateUser200JSONResponse)
+					require.True(t, ok)
+					assert.True(t, resp.Disabled)
+
+					stored, err := store.LookupUserInfoByID(ctx, userInfo.ID)
+					require.NoError(t, err)
+					assert.True(t, stored.Disabled)
+				},
+			},
+			{
+				Name: "NoChanges",
+				Input: UpdateUserRequestObject{
+					UserID: userInfo.ID,
+					Body:   &v1.UpdateUserJSONRequestBody{},
+				},
+				SetupFn:   setupFn,
+				CleanupFn: cleanupFn,
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[UpdateUserResponseObject]) {
+					require.NoError(t, res.Err)
+
+					exp := UpdateUser200JSONResponse(must(userInfo.ToV1User()))
+
+					assert.Equal(t, exp, res.Success)
+				},
+			},
+		}
+
+		runFn := func(ctx context.Context, input UpdateUserRequestObject) testingx.TestResult[UpdateUserResponseObject] {
+			resp, err := handler.UpdateUser(ctx, input)
+
+			return testingx.TestResult[UpdateUserResponseObject]{
+				Success: resp,
+				Err:     err,
+			}
+		}
+
+		testingx.RunTests(ctxPermsAllow(context.Background()), t, testCases, runFn)
+	})
+
+	t.Run("DeleteUser", func(t *testing.T) {
+		t.Parallel()
+
+		handler := apiHandler{
+			engine:       store,
+			eventService: events.NewEvents(),
+		}
+
+		m := &mockpermissions.MockPermissions{}
+		m.On("DeleteAuthRelationships").Return(nil)
+
+		issuer := types.Issuer{
+			OwnerID: ownerID,
+			Name:    "Example",
+			URI:     "https://example5.com/",
+			JWKSURI: "https://example5.com/.well-known/jwks.json",
+		}
+
+		withStoredIssuers(t, store, &issuer)
+
+		userInfo := types.UserInfo{
+			Name:    t.Name(),
+			Email:   t.Name() + "@example.com",
+			Issuer:  issuer.URI,
+			Subject: t.Name() + "Test",
+		}
+
+		withStoredUsers(t, store, &userInfo)
+
+		group := &types.Group{
+			ID:      gidx.MustNewID(types.IdentityGroupIDPrefix),
+			OwnerID: ownerID,
+			Name:    t.Name(),
+		}
+
+		withStoredGroupAndMembers(t, store, group, userInfo.ID)
+
+		setupFn := func(ctx context.Context) context.Context {
+			ctx, err := store.BeginContext(m.ContextWithHandler(ctx))
+			if !assert.NoError(t, err) {
+				assert.FailNow(t, "setup failed")
+			}
+
+			return ctx
+		}
+
+		cleanupFn := func(ctx context.Context) {
+			err := store.RollbackContext(ctx)
+			assert.NoError(t, err)
+		}
+
+		testCases := []testingx.TestCase[DeleteUserRequestObject, DeleteUserResponseObject]{
+			{
+				Name: "NotFound",
+				Input: DeleteUserRequestObject{
+					UserID: gidx.MustNewID(types.IdentityUserIDPrefix),
+				},
+				SetupFn:   setupFn,
+				CleanupFn: cleanupFn,
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[DeleteUserResponseObject]) {
+					assert.IsType(t, errorWithStatus{}, res.Err)
+					assert.Equal(t, http.StatusNotFound, res.Err.(errorWithStatus).status)
+				},
+			},
+			{
+				Name: "Success",
+				Input: DeleteUserRequestObject{
+					UserID: userInfo.ID,
+				},
+				SetupFn:   setupFn,
+				CleanupFn: cleanupFn,
+				CheckFn: func(ctx context.Context, t *testing.T, res testingx.TestResult[DeleteUserResponseObject]) {
+					require.NoError(t, res.Err)
+					assert.Equal(t, DeleteUser200JSONResponse{Success: true}, res.Success)
+
+					_, err := store.LookupUserInfoByID(ctx, userInfo.ID)
+					assert.ErrorIs(t, err, types.ErrUserInfoNotFound)
+
+					groupIDs, err := store.ListGroupIDsBySubject(ctx, userInfo.ID)
+					require.NoError(t, err)
+					assert.Empty(t, groupIDs)
+
+					m.AssertCalled(
+						t, "DeleteAuthRelationships", events.GroupTopic, group.ID,
+						eventsx.AuthRelationshipRelation{
+							Relation:  events.DirectMemberRelationship,
+							SubjectID: userInfo.ID,
+						},
+					)
+				},
+			},
+		}
+
+		runFn := func(ctx context.Context, input DeleteUserRequestObject) testingx.TestResult[DeleteUserResponseObject] {
+			resp, err := handler.DeleteUser(ctx, input)
+
+			return testingx.TestResult[DeleteUserResponseObject]{
+				Success: resp,
+				Err:     err,
+			}
+		}
+
+		testingx.RunTests(ctxPermsAllow(context.Background()), t, testCases, runFn)
+	})
+
 	t.Run("ListIssuerUsers", func(t *testing.T) {
 		t.Parallel()
 
@@ -24,6 +24,9 @@ type ServerInterface interface {
 	// Gets information about an OAuth 2.0 Client.
 	// (GET /api/v1/clients/{clientID})
 	GetOAuthClient(ctx echo.Context, clientID gidx.PrefixedID) error
+	// Revokes all tokens issued to an OAuth Client
+	// (DELETE /api/v1/clients/{clientID}/tokens)
+	RevokeOAuthClientTokens(ctx echo.Context, clientID gidx.PrefixedID) error
 	// Deletes a Group
 	// (DELETE /api/v1/groups/{groupID})
 	DeleteGroup(ctx echo.Context, groupID GroupID) error
@@ -75,12 +78,33 @@ type ServerInterface interface {
 	// Creates an issuer.
 	// (POST /api/v1/owners/{ownerID}/issuers)
 	CreateIssuer(ctx echo.Context, ownerID gidx.PrefixedID) error
+	// Lists the signing keys stored in the database.
+	// (GET /api/v1/signing-keys)
+	ListSigningKeys(ctx echo.Context) error
+	// Generates a signing key.
+	// (POST /api/v1/signing-keys)
+	CreateSigningKey(ctx echo.Context) error
+	// Activates a signing key.
+	// (POST /api/v1/signing-keys/{keyID}/activate)
+	ActivateSigningKey(ctx echo.Context, keyID KeyID) error
+	// Retires a signing key.
+	// (POST /api/v1/signing-keys/{keyID}/retire)
+	RetireSigningKey(ctx echo.Context, keyID KeyID) error
+	// Deletes a User
+	// (DELETE /api/v1/users/{userID})
+	DeleteUser(ctx echo.Context, userID gidx.PrefixedID) error
 	// Gets information about a User.
 	// (GET /api/v1/users/{userID})
 	GetUserByID(ctx echo.Context, userID gidx.PrefixedID) error
+	// Updates a User
+	// (PATCH /api/v1/users/{userID})
+	UpdateUser(ctx echo.Context, userID gidx.PrefixedID) error
 	// Lists groups by user id
 	// (GET /api/v1/users/{userID}/groups)
 	ListUserGroups(ctx echo.Context, userID gidx.PrefixedID, params ListUserGroupsParams) error
+	// Revokes all tokens issued to a User
+	// (DELETE /api/v1/users/{userID}/tokens)
+	RevokeUserTokens(ctx echo.Context, userID gidx.PrefixedID) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -120,6 +144,22 @@ func (w *ServerInterfaceWrapper) GetOAuthClient(ctx echo.Context) error {
 	return err
 }
 
+// RevokeOAuthClientTokens converts echo context to params.
+func (w *ServerInterfaceWrapper) RevokeOAuthClientTokens(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "clientID" -------------
+	var clientID gidx.PrefixedID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "clientID", ctx.Param("clientID"), &clientID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter clientID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RevokeOAuthClientTokens(ctx, clientID)
+	return err
+}
+
 // DeleteGroup converts echo context to params.
 func (w *ServerInterfaceWrapper) DeleteGroup(ctx echo.Context) error {
 	var err error
@@ -331,6 +371,13 @@ func (w *ServerInterfaceWrapper) GetIssuerUsers(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
 	}
 
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.GetIssuerUsers(ctx, issuerID, params)
 	return err
@@ -363,6 +410,13 @@ func (w *ServerInterfaceWrapper) GetOwnerOAuthClients(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
 	}
 
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.GetOwnerOAuthClients(ctx, ownerID, params)
 	return err
@@ -411,6 +465,13 @@ func (w *ServerInterfaceWrapper) ListGroups(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
 	}
 
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.ListGroups(ctx, ownerID, params)
 	return err
@@ -459,6 +520,13 @@ func (w *ServerInterfaceWrapper) ListOwnerIssuers(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
 	}
 
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.ListOwnerIssuers(ctx, ownerID, params)
 	return err
@@ -480,6 +548,72 @@ func (w *ServerInterfaceWrapper) CreateIssuer(ctx echo.Context) error {
 	return err
 }
 
+// ListSigningKeys converts echo context to params.
+func (w *ServerInterfaceWrapper) ListSigningKeys(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListSigningKeys(ctx)
+	return err
+}
+
+// CreateSigningKey converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateSigningKey(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateSigningKey(ctx)
+	return err
+}
+
+// ActivateSigningKey converts echo context to params.
+func (w *ServerInterfaceWrapper) ActivateSigningKey(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "keyID" -------------
+	var keyID KeyID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "keyID", ctx.Param("keyID"), &keyID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter keyID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ActivateSigningKey(ctx, keyID)
+	return err
+}
+
+// RetireSigningKey converts echo context to params.
+func (w *ServerInterfaceWrapper) RetireSigningKey(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "keyID" -------------
+	var keyID KeyID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "keyID", ctx.Param("keyID"), &keyID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter keyID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RetireSigningKey(ctx, keyID)
+	return err
+}
+
+// DeleteUser converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteUser(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "userID" -------------
+	var userID gidx.PrefixedID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", ctx.Param("userID"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteUser(ctx, userID)
+	return err
+}
+
 // GetUserByID converts echo context to params.
 func (w *ServerInterfaceWrapper) GetUserByID(ctx echo.Context) error {
 	var err error
@@ -496,6 +630,22 @@ func (w *ServerInterfaceWrapper) GetUserByID(ctx echo.Context) error {
 	return err
 }
 
+// UpdateUser converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateUser(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "userID" -------------
+	var userID gidx.PrefixedID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", ctx.Param("userID"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UpdateUser(ctx, userID)
+	return err
+}
+
 // ListUserGroups converts echo context to params.
 func (w *ServerInterfaceWrapper) ListUserGroups(ctx echo.Context) error {
 	var err error
@@ -528,6 +678,22 @@ func (w *ServerInterfaceWrapper) ListUserGroups(ctx echo.Context) error {
 	return err
 }
 
+// RevokeUserTokens converts echo context to params.
+func (w *ServerInterfaceWrapper) RevokeUserTokens(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "userID" -------------
+	var userID gidx.PrefixedID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", ctx.Param("userID"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RevokeUserTokens(ctx, userID)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -558,6 +724,7 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 
 	router.DELETE(baseURL+"/api/v1/clients/:clientID", wrapper.DeleteOAuthClient)
 	router.GET(baseURL+"/api/v1/clients/:clientID", wrapper.GetOAuthClient)
+	router.DELETE(baseURL+"/api/v1/clients/:clientID/tokens", wrapper.RevokeOAuthClientTokens)
 	router.DELETE(baseURL+"/api/v1/groups/:groupID", wrapper.DeleteGroup)
 	router.GET(baseURL+"/api/v1/groups/:groupID", wrapper.GetGroupByID)
 	router.PATCH(baseURL+"/api/v1/groups/:groupID", wrapper.UpdateGroup)
@@ -575,8 +742,15 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.POST(baseURL+"/api/v1/owners/:ownerID/groups", wrapper.CreateGroup)
 	router.GET(baseURL+"/api/v1/owners/:ownerID/issuers", wrapper.ListOwnerIssuers)
 	router.POST(baseURL+"/api/v1/owners/:ownerID/issuers", wrapper.CreateIssuer)
+	router.GET(baseURL+"/api/v1/signing-keys", wrapper.ListSigningKeys)
+	router.POST(baseURL+"/api/v1/signing-keys", wrapper.CreateSigningKey)
+	router.POST(baseURL+"/api/v1/signing-keys/:keyID/activate", wrapper.ActivateSigningKey)
+	router.POST(baseURL+"/api/v1/signing-keys/:keyID/retire", wrapper.RetireSigningKey)
+	router.DELETE(baseURL+"/api/v1/users/:userID", wrapper.DeleteUser)
 	router.GET(baseURL+"/api/v1/users/:userID", wrapper.GetUserByID)
+	router.PATCH(baseURL+"/api/v1/users/:userID", wrapper.UpdateUser)
 	router.GET(baseURL+"/api/v1/users/:userID/groups", wrapper.ListUserGroups)
+	router.DELETE(baseURL+"/api/v1/users/:userID/tokens", wrapper.RevokeUserTokens)
 
 }
 
@@ -616,6 +790,10 @@ type OAuthClientCollectionJSONResponse struct {
 	Pagination Pagination `json:"pagination"`
 }
 
+type SigningKeyCollectionJSONResponse struct {
+	SigningKeys []SigningKey `json:"signing_keys"`
+}
+
 type UserCollectionJSONResponse struct {
 	// Pagination collection response pagination
 	Pagination Pagination `json:"pagination"`
@@ -656,6 +834,23 @@ func (response GetOAuthClient200JSONResponse) VisitGetOAuthClientResponse(w http
 	return json.NewEncoder(w).Encode(response)
 }
 
+type RevokeOAuthClientTokensRequestObject struct {
+	ClientID gidx.PrefixedID `json:"clientID"`
+}
+
+type RevokeOAuthClientTokensResponseObject interface {
+	VisitRevokeOAuthClientTokensResponse(w http.ResponseWriter) error
+}
+
+type RevokeOAuthClientTokens200JSONResponse DeleteResponse
+
+func (response RevokeOAuthClientTokens200JSONResponse) VisitRevokeOAuthClientTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteGroupRequestObject struct {
 	GroupID GroupID `json:"groupID"`
 }
@@ -962,6 +1157,92 @@ func (response CreateIssuer200JSONResponse) VisitCreateIssuerResponse(w http.Res
 	return json.NewEncoder(w).Encode(response)
 }
 
+type ListSigningKeysRequestObject struct {
+}
+
+type ListSigningKeysResponseObject interface {
+	VisitListSigningKeysResponse(w http.ResponseWriter) error
+}
+
+type ListSigningKeys200JSONResponse struct {
+	SigningKeyCollectionJSONResponse
+}
+
+func (response ListSigningKeys200JSONResponse) VisitListSigningKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSigningKeyRequestObject struct {
+	Body *CreateSigningKeyJSONRequestBody
+}
+
+type CreateSigningKeyResponseObject interface {
+	VisitCreateSigningKeyResponse(w http.ResponseWriter) error
+}
+
+type CreateSigningKey200JSONResponse SigningKey
+
+func (response CreateSigningKey200JSONResponse) VisitCreateSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ActivateSigningKeyRequestObject struct {
+	KeyID KeyID `json:"keyID"`
+}
+
+type ActivateSigningKeyResponseObject interface {
+	VisitActivateSigningKeyResponse(w http.ResponseWriter) error
+}
+
+type ActivateSigningKey200JSONResponse SigningKey
+
+func (response ActivateSigningKey200JSONResponse) VisitActivateSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetireSigningKeyRequestObject struct {
+	KeyID KeyID `json:"keyID"`
+}
+
+type RetireSigningKeyResponseObject interface {
+	VisitRetireSigningKeyResponse(w http.ResponseWriter) error
+}
+
+type RetireSigningKey200JSONResponse SigningKey
+
+func (response RetireSigningKey200JSONResponse) VisitRetireSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUserRequestObject struct {
+	UserID gidx.PrefixedID `json:"userID"`
+}
+
+type DeleteUserResponseObject interface {
+	VisitDeleteUserResponse(w http.ResponseWriter) error
+}
+
+type DeleteUser200JSONResponse DeleteResponse
+
+func (response DeleteUser200JSONResponse) VisitDeleteUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetUserByIDRequestObject struct {
 	UserID gidx.PrefixedID `json:"userID"`
 }
@@ -979,6 +1260,24 @@ func (response GetUserByID200JSONResponse) VisitGetUserByIDResponse(w http.Respo
 	return json.NewEncoder(w).Encode(response)
 }
 
+type UpdateUserRequestObject struct {
+	UserID gidx.PrefixedID `json:"userID"`
+	Body   *UpdateUserJSONRequestBody
+}
+
+type UpdateUserResponseObject interface {
+	VisitUpdateUserResponse(w http.ResponseWriter) error
+}
+
+type UpdateUser200JSONResponse User
+
+func (response UpdateUser200JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type ListUserGroupsRequestObject struct {
 	UserID gidx.PrefixedID `json:"userID"`
 	Params ListUserGroupsParams
@@ -997,6 +1296,23 @@ func (response ListUserGroups200JSONResponse) VisitListUserGroupsResponse(w http
 	return json.NewEncoder(w).Encode(response)
 }
 
+type RevokeUserTokensRequestObject struct {
+	UserID gidx.PrefixedID `json:"userID"`
+}
+
+type RevokeUserTokensResponseObject interface {
+	VisitRevokeUserTokensResponse(w http.ResponseWriter) error
+}
+
+type RevokeUserTokens200JSONResponse DeleteResponse
+
+func (response RevokeUserTokens200JSONResponse) VisitRevokeUserTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 	// Deletes an OAuth Client
@@ -1005,6 +1321,9 @@ type StrictServerInterface interface {
 	// Gets information about an OAuth 2.0 Client.
 	// (GET /api/v1/clients/{clientID})
 	GetOAuthClient(ctx context.Context, request GetOAuthClientRequestObject) (GetOAuthClientResponseObject, error)
+	// Revokes all tokens issued to an OAuth Client
+	// (DELETE /api/v1/clients/{clientID}/tokens)
+	RevokeOAuthClientTokens(ctx context.Context, request RevokeOAuthClientTokensRequestObject) (RevokeOAuthClientTokensResponseObject, error)
 	// Deletes a Group
 	// (DELETE /api/v1/groups/{groupID})
 	DeleteGroup(ctx context.Context, request DeleteGroupRequestObject) (DeleteGroupResponseObject, error)
@@ -1056,12 +1375,33 @@ type StrictServerInterface interface {
 	// Creates an issuer.
 	// (POST /api/v1/owners/{ownerID}/issuers)
 	CreateIssuer(ctx context.Context, request CreateIssuerRequestObject) (CreateIssuerResponseObject, error)
+	// Lists the signing keys stored in the database.
+	// (GET /api/v1/signing-keys)
+	ListSigningKeys(ctx context.Context, request ListSigningKeysRequestObject) (ListSigningKeysResponseObject, error)
+	// Generates a signing key.
+	// (POST /api/v1/signing-keys)
+	CreateSigningKey(ctx context.Context, request CreateSigningKeyRequestObject) (CreateSigningKeyResponseObject, error)
+	// Activates a signing key.
+	// (POST /api/v1/signing-keys/{keyID}/activate)
+	ActivateSigningKey(ctx context.Context, request ActivateSigningKeyRequestObject) (ActivateSigningKeyResponseObject, error)
+	// Retires a signing key.
+	// (POST /api/v1/signing-keys/{keyID}/retire)
+	RetireSigningKey(ctx context.Context, request RetireSigningKeyRequestObject) (RetireSigningKeyResponseObject, error)
+	// Deletes a User
+	// (DELETE /api/v1/users/{userID})
+	DeleteUser(ctx context.Context, request DeleteUserRequestObject) (DeleteUserResponseObject, error)
 	// Gets information about a User.
 	// (GET /api/v1/users/{userID})
 	GetUserByID(ctx context.Context, request GetUserByIDRequestObject) (GetUserByIDResponseObject, error)
+	// Updates a User
+	// (PATCH /api/v1/users/{userID})
+	UpdateUser(ctx context.Context, request UpdateUserRequestObject) (UpdateUserResponseObject, error)
 	// Lists groups by user id
 	// (GET /api/v1/users/{userID}/groups)
 	ListUserGroups(ctx context.Context, request ListUserGroupsRequestObject) (ListUserGroupsResponseObject, error)
+	// Revokes all tokens issued to a User
+	// (DELETE /api/v1/users/{userID}/tokens)
+	RevokeUserTokens(ctx context.Context, request RevokeUserTokensRequestObject) (RevokeUserTokensResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -1126,6 +1466,31 @@ func (sh *strictHandler) GetOAuthClient(ctx echo.Context, clientID gidx.Prefixed
 	return nil
 }
 
+// RevokeOAuthClientTokens operation middleware
+func (sh *strictHandler) RevokeOAuthClientTokens(ctx echo.Context, clientID gidx.PrefixedID) error {
+	var request RevokeOAuthClientTokensRequestObject
+
+	request.ClientID = clientID
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeOAuthClientTokens(ctx.Request().Context(), request.(RevokeOAuthClientTokensRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeOAuthClientTokens")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RevokeOAuthClientTokensResponseObject); ok {
+		return validResponse.VisitRevokeOAuthClientTokensResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // DeleteGroup operation middleware
 func (sh *strictHandler) DeleteGroup(ctx echo.Context, groupID GroupID) error {
 	var request DeleteGroupRequestObject
@@ -1599,6 +1964,133 @@ func (sh *strictHandler) CreateIssuer(ctx echo.Context, ownerID gidx.PrefixedID)
 	return nil
 }
 
+// ListSigningKeys operation middleware
+func (sh *strictHandler) ListSigningKeys(ctx echo.Context) error {
+	var request ListSigningKeysRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListSigningKeys(ctx.Request().Context(), request.(ListSigningKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListSigningKeys")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListSigningKeysResponseObject); ok {
+		return validResponse.VisitListSigningKeysResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateSigningKey operation middleware
+func (sh *strictHandler) CreateSigningKey(ctx echo.Context) error {
+	var request CreateSigningKeyRequestObject
+
+	var body CreateSigningKeyJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateSigningKey(ctx.Request().Context(), request.(CreateSigningKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateSigningKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreateSigningKeyResponseObject); ok {
+		return validResponse.VisitCreateSigningKeyResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ActivateSigningKey operation middleware
+func (sh *strictHandler) ActivateSigningKey(ctx echo.Context, keyID KeyID) error {
+	var request ActivateSigningKeyRequestObject
+
+	request.KeyID = keyID
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ActivateSigningKey(ctx.Request().Context(), request.(ActivateSigningKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ActivateSigningKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ActivateSigningKeyResponseObject); ok {
+		return validResponse.VisitActivateSigningKeyResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// RetireSigningKey operation middleware
+func (sh *strictHandler) RetireSigningKey(ctx echo.Context, keyID KeyID) error {
+	var request RetireSigningKeyRequestObject
+
+	request.KeyID = keyID
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RetireSigningKey(ctx.Request().Context(), request.(RetireSigningKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RetireSigningKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RetireSigningKeyResponseObject); ok {
+		return validResponse.VisitRetireSigningKeyResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteUser operation middleware
+func (sh *strictHandler) DeleteUser(ctx echo.Context, userID gidx.PrefixedID) error {
+	var request DeleteUserRequestObject
+
+	request.UserID = userID
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteUser(ctx.Request().Context(), request.(DeleteUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteUser")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteUserResponseObject); ok {
+		return validResponse.VisitDeleteUserResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // GetUserByID operation middleware
 func (sh *strictHandler) GetUserByID(ctx echo.Context, userID gidx.PrefixedID) error {
 	var request GetUserByIDRequestObject
@@ -1624,6 +2116,37 @@ func (sh *strictHandler) GetUserByID(ctx echo.Context, userID gidx.PrefixedID) e
 	return nil
 }
 
+// UpdateUser operation middleware
+func (sh *strictHandler) UpdateUser(ctx echo.Context, userID gidx.PrefixedID) error {
+	var request UpdateUserRequestObject
+
+	request.UserID = userID
+
+	var body UpdateUserJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateUser(ctx.Request().Context(), request.(UpdateUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateUser")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(UpdateUserResponseObject); ok {
+		return validResponse.VisitUpdateUserResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // ListUserGroups operation middleware
 func (sh *strictHandler) ListUserGroups(ctx echo.Context, userID gidx.PrefixedID, params ListUserGroupsParams) error {
 	var request ListUserGroupsRequestObject
@@ -1649,3 +2172,28 @@ func (sh *strictHandler) ListUserGroups(ctx echo.Context, userID gidx.PrefixedID
 	}
 	return nil
 }
+
+// RevokeUserTokens operation middleware
+func (sh *strictHandler) RevokeUserTokens(ctx echo.Context, userID gidx.PrefixedID) error {
+	var request RevokeUserTokensRequestObject
+
+	request.UserID = userID
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeUserTokens(ctx.Request().Context(), request.(RevokeUserTokensRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeUserTokens")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RevokeUserTokensResponseObject); ok {
+		return validResponse.VisitRevokeUserTokensResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
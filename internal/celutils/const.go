@@ -6,4 +6,6 @@ const (
 
 	// CELVariableSubSHA256 is the name of the subSHA256 variable in CEL expressions.
 	CELVariableSubSHA256 = "subSHA256"
+	// CELVariableActor is the name of the actor claims variable in CEL expressions.
+	CELVariableActor = "actor"
 )
@@ -1,6 +1,10 @@
 package celutils
 
 import (
+	"runtime"
+	"sync"
+	"weak"
+
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/common/types/ref"
 )
@@ -13,6 +17,7 @@ func init() {
 	env, err := cel.NewEnv(
 		cel.Variable(CELVariableClaims, cel.MapType(cel.StringType, cel.DynType)),
 		cel.Variable(CELVariableSubSHA256, cel.StringType),
+		cel.Variable(CELVariableActor, cel.MapType(cel.StringType, cel.DynType)),
 	)
 	if err != nil {
 		panic(err)
@@ -35,9 +40,36 @@ func ParseCEL(input string) (*cel.Ast, error) {
 	return ast, nil
 }
 
-// Eval evaluates the given AST against the provided input environment.
-func Eval(ast *cel.Ast, inputEnv map[string]any) (ref.Val, error) {
+// programs caches the programs compiled from each AST. ASTs are referenced weakly, so a program is
+// dropped once its AST is no longer used, such as when an issuer is reloaded.
+var programs sync.Map
+
+// program returns the program compiled from the given AST, compiling it on first use.
+func program(ast *cel.Ast) (cel.Program, error) {
+	key := weak.Make(ast)
+
+	if prog, ok := programs.Load(key); ok {
+		return prog.(cel.Program), nil
+	}
+
 	prog, err := celEnv.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, loaded := programs.LoadOrStore(key, prog); !loaded {
+		runtime.AddCleanup(ast, func(key weak.Pointer[cel.Ast]) {
+			programs.Delete(key)
+		}, key)
+	}
+
+	return prog, nil
+}
+
+// Eval evaluates the given AST against the provided input environment. The program compiled from
+// the AST is cached for as long as the AST is in use.
+func Eval(ast *cel.Ast, inputEnv map[string]any) (ref.Val, error) {
+	prog, err := program(ast)
 	if err != nil {
 		wrapped := ErrorCELParse{
 			inner: err,
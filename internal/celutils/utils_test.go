@@ -0,0 +1,52 @@
+package celutils
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cachedPrograms() int {
+	count := 0
+
+	programs.Range(func(_, _ any) bool {
+		count++
+
+		return true
+	})
+
+	return count
+}
+
+// TestEvalProgramCache checks that programs are compiled once per AST, and dropped once the AST is
+// no longer used.
+func TestEvalProgramCache(t *testing.T) {
+	ast, err := ParseCEL(`claims.sub + "-" + subSHA256`)
+	require.NoError(t, err)
+
+	before := cachedPrograms()
+
+	for range 3 {
+		val, err := Eval(ast, map[string]any{
+			CELVariableClaims:    map[string]any{"sub": "user"},
+			CELVariableSubSHA256: "hash",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "user-hash", val.Value())
+	}
+
+	assert.Equal(t, before+1, cachedPrograms())
+
+	runtime.KeepAlive(ast)
+
+	ast = nil //nolint:ineffassign,wastedassign // Drops the last reference to the AST.
+
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+
+		return cachedPrograms() == before
+	}, 5*time.Second, 10*time.Millisecond)
+}
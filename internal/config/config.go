@@ -2,6 +2,10 @@
 package config
 
 import (
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/viper"
 	"go.infratographer.com/permissions-api/pkg/permissions"
 	"go.infratographer.com/x/crdbx"
 	"go.infratographer.com/x/echox"
@@ -24,3 +28,11 @@ var Config struct {
 	Permissions permissions.Config
 	Events      eventsx.Config
 }
+
+// DecodeHook extends viper's default decode hooks to decode RFC 3339 times, such as signing key
+// activation times.
+var DecodeHook = viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+	mapstructure.StringToTimeDurationHookFunc(),
+	mapstructure.StringToSliceHookFunc(","),
+	mapstructure.StringToTimeHookFunc(time.RFC3339),
+))
@@ -57,7 +57,8 @@ func Limit(limit int) int {
 	return limit
 }
 
-// Paginate handles setting the pagination for the request.
+// Paginate handles setting the pagination for the request. A cursor which cannot be used with the
+// permitted fields is ignored, returning the first page with the requested limit and ordering.
 func Paginate(p Paginator, asOfSystemTime any) FormatValues {
 	var values FormatValues
 
@@ -76,28 +77,50 @@ func Paginate(p Paginator, asOfSystemTime any) FormatValues {
 	values.orderFields = onlyFields
 
 	if cursor := p.GetCursor(); cursor != nil {
-		cValues, err := cursor.Values()
-		if err != nil {
-			return FormatValues{}
+		values.fields, values.values = cursorFields(cursor, onlyFields)
+	}
+
+	return values
+}
+
+// cursorFields returns the fields and values of the cursor in the order of the given fields, or nil if
+// the cursor is invalid or was not created for those fields.
+func cursorFields(cursor *Cursor, onlyFields []string) ([]string, []any) {
+	cValues, err := cursor.Values()
+	if err != nil || len(cValues) == 0 {
+		return nil, nil
+	}
+
+	// Ensure only permitted fields are defined.
+	for key := range cValues {
+		if !slices.Contains(onlyFields, key) {
+			return nil, nil
 		}
+	}
+
+	var (
+		fields []string
+		values []any
+	)
 
-		// Ensure only permitted fields are defined.
-		for key := range cValues {
-			if !slices.Contains(onlyFields, key) {
-				return FormatValues{}
-			}
+	// Add clauses in original field order. Fields with an empty value were NULL, while
+	// cursors missing a field were created for a different ordering.
+	for _, field := range onlyFields {
+		if !cValues.Has(field) {
+			return nil, nil
 		}
 
-		// Add clauses in original field order.
-		for _, field := range onlyFields {
-			if value := cValues.Get(field); value != "" {
-				values.fields = append(values.fields, field)
-				values.values = append(values.values, value)
-			}
+		var value any
+
+		if v := cValues.Get(field); v != "" {
+			value = v
 		}
+
+		fields = append(fields, field)
+		values = append(values, value)
 	}
 
-	return values
+	return fields, values
 }
 
 type asOfSystemTimeCtx struct{}
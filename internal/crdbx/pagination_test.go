@@ -0,0 +1,214 @@
+package crdbx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+)
+
+// TestPaginate checks the conditions and bind values generated for pagination cursors.
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+
+	const (
+		createdAt = "2024-01-02T03:04:05.123456Z"
+		id        = "idntusr-abc"
+	)
+
+	sortedFields := []string{"created_at", "id"}
+
+	type input struct {
+		pagination Pagination
+		next       int
+		values     []any
+	}
+
+	type result struct {
+		where  string
+		values []any
+		order  string
+		limit  string
+	}
+
+	runFn := func(_ context.Context, in input) testingx.TestResult[result] {
+		paginate := Paginate(in.pagination, nil)
+
+		return testingx.TestResult[result]{
+			Success: result{
+				where:  paginate.Where(in.next),
+				values: paginate.Values(in.values...),
+				order:  paginate.Order(),
+				limit:  paginate.LimitClause(),
+			},
+		}
+	}
+
+	testCases := []testingx.TestCase[input, result]{
+		{
+			Name: "NoCursor",
+			Input: input{
+				pagination: Pagination{
+					OnlyFields: sortedFields,
+				},
+				next: 1,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Empty(t, res.Success.where)
+				assert.Empty(t, res.Success.values)
+				assert.Equal(t, `"created_at","id"`, res.Success.order)
+			},
+		},
+		{
+			Name: "IDOnly",
+			Input: input{
+				pagination: Pagination{
+					Cursor: MustNewCursor("id", id),
+				},
+				next: 1,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, `("id">$1)`, res.Success.where)
+				assert.Equal(t, []any{id}, res.Success.values)
+				assert.Equal(t, `"id"`, res.Success.order)
+			},
+		},
+		{
+			Name: "TwoFields",
+			Input: input{
+				pagination: Pagination{
+					Cursor:     MustNewCursor("created_at", createdAt, "id", id),
+					OnlyFields: sortedFields,
+				},
+				next: 1,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, `("created_at">$1 OR "created_at"=$1 AND "id">$2)`, res.Success.where)
+				assert.Equal(t, []any{createdAt, id}, res.Success.values)
+			},
+		},
+		{
+			Name: "NullFirstField",
+			Input: input{
+				pagination: Pagination{
+					Cursor:     mustNewCursorFromValues(map[string][]string{"created_at": {""}, "id": {id}}),
+					OnlyFields: sortedFields,
+				},
+				next: 1,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, `("created_at" IS NOT NULL OR "created_at" IS NULL AND "id">$1)`, res.Success.where)
+				assert.Equal(t, []any{id}, res.Success.values)
+			},
+		},
+		{
+			Name: "AdditionalValues",
+			Input: input{
+				pagination: Pagination{
+					Cursor:     MustNewCursor("created_at", createdAt, "id", id),
+					OnlyFields: sortedFields,
+				},
+				next:   3,
+				values: []any{"owner", "issuer"},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, `("created_at">$3 OR "created_at"=$3 AND "id">$4)`, res.Success.where)
+				assert.Equal(t, []any{"owner", "issuer", createdAt, id}, res.Success.values)
+			},
+		},
+		{
+			Name: "AdditionalValuesNullFirstField",
+			Input: input{
+				pagination: Pagination{
+					Cursor:     mustNewCursorFromValues(map[string][]string{"created_at": {""}, "id": {id}}),
+					OnlyFields: sortedFields,
+				},
+				next:   2,
+				values: []any{"owner"},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, `("created_at" IS NOT NULL OR "created_at" IS NULL AND "id">$2)`, res.Success.where)
+				assert.Equal(t, []any{"owner", id}, res.Success.values)
+			},
+		},
+		{
+			Name: "MissingSortField",
+			Input: input{
+				pagination: Pagination{
+					Cursor:     MustNewCursor("id", id),
+					Limit:      25,
+					OnlyFields: sortedFields,
+				},
+				next: 1,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Empty(t, res.Success.where)
+				assert.Empty(t, res.Success.values)
+				assert.Equal(t, `"created_at","id"`, res.Success.order)
+				assert.Equal(t, "LIMIT 25", res.Success.limit)
+			},
+		},
+		{
+			Name: "UnknownField",
+			Input: input{
+				pagination: Pagination{
+					Cursor: MustNewCursor("created_at", createdAt, "id", id),
+					Limit:  25,
+				},
+				next: 1,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Empty(t, res.Success.where)
+				assert.Empty(t, res.Success.values)
+				assert.Equal(t, `"id"`, res.Success.order)
+				assert.Equal(t, "LIMIT 25", res.Success.limit)
+			},
+		},
+		{
+			Name: "InvalidCursor",
+			Input: input{
+				pagination: Pagination{
+					Cursor:     cursorPtr("not a cursor"),
+					Limit:      25,
+					OnlyFields: sortedFields,
+				},
+				next: 1,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Empty(t, res.Success.where)
+				assert.Empty(t, res.Success.values)
+				assert.Equal(t, `"created_at","id"`, res.Success.order)
+				assert.Equal(t, "LIMIT 25", res.Success.limit)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
+
+func mustNewCursorFromValues(values map[string][]string) *Cursor {
+	cursor, err := NewCursorFromValues(values)
+	if err != nil {
+		panic(err)
+	}
+
+	return cursor
+}
+
+func cursorPtr(value string) *Cursor {
+	cursor := Cursor(value)
+
+	return &cursor
+}
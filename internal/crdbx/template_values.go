@@ -52,7 +52,9 @@ func (v FormatValues) AsOfSystemTime() string {
 	return "AS OF SYSTEM TIME " + quoteValue(value)
 }
 
-// Where returns the conditions to be added to a where clause.
+// Where returns the conditions to be added to a where clause, selecting the rows ordered after the
+// cursor. If multiple fields are set, rows are compared by each field in order, as in the `ORDER BY`
+// clause. Fields the cursor holds no value for are compared as NULL, which sorts first.
 // Next provides the offset for defining bind values.
 // If your query includes additional values, this value should be set to the next unused number.
 // For example if you had the query `SELECT * FROM users WHERE country=$1`. The value you should use here is `2`.
@@ -61,12 +63,28 @@ func (v FormatValues) Where(next int) string {
 		return ""
 	}
 
+	params := make([]string, len(v.fields))
+
+	for i, value := range v.values {
+		if value != nil {
+			params[i] = `$` + strconv.Itoa(next)
+			next++
+		}
+	}
+
 	where := make([]string, len(v.fields))
+
 	for i, field := range v.fields {
-		where[i] = quoteField(v.qualifier, field) + `>$` + strconv.Itoa(i+next)
+		conds := make([]string, 0, i+1)
+
+		for j, prev := range v.fields[:i] {
+			conds = append(conds, compareField(quoteField(v.qualifier, prev), "=", params[j]))
+		}
+
+		where[i] = strings.Join(append(conds, compareField(quoteField(v.qualifier, field), ">", params[i])), " AND ")
 	}
 
-	return "(" + strings.Join(where, " AND ") + ")"
+	return "(" + strings.Join(where, " OR ") + ")"
 }
 
 // WhereClause returns a full WHERE clause including the conditions if defined.
@@ -128,11 +146,29 @@ func (v FormatValues) Order(fields ...string) string {
 // Values returns the values to be used in a query.
 // Additional values may be included and will be first in the combined values slice.
 func (v FormatValues) Values(values ...any) []any {
-	values = append(values, v.values...)
+	for _, value := range v.values {
+		if value != nil {
+			values = append(values, value)
+		}
+	}
 
 	return values
 }
 
+// compareField compares a field with a bind parameter. If there is no parameter, the field is
+// compared with NULL, which sorts before any value.
+func compareField(field, op, param string) string {
+	if param != "" {
+		return field + op + param
+	}
+
+	if op == "=" {
+		return field + " IS NULL"
+	}
+
+	return field + " IS NOT NULL"
+}
+
 // https://github.com/jackc/pgx/blob/9907b874c223887dba628215feae29be69306e73/pgtype/timestamp.go#L219
 func discardTimeZone(t time.Time) time.Time {
 	if t.Location() != time.UTC {
@@ -152,11 +188,13 @@ func quoteField(q, v string) string {
 	return prefix + `"` + strings.ReplaceAll(v, `"`, `""`) + `"`
 }
 func quoteFields(qualifier string, fields ...string) []string {
+	quoted := make([]string, len(fields))
+
 	for i, field := range fields {
-		fields[i] = quoteField(qualifier, field)
+		quoted[i] = quoteField(qualifier, field)
 	}
 
-	return fields
+	return quoted
 }
 
 // https://www.postgresql.org/docs/current/sql-syntax-lexical.html#SQL-SYNTAX-STRINGS
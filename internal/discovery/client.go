@@ -0,0 +1,86 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTimeout is the default timeout for discovery requests.
+	DefaultTimeout = 10 * time.Second
+
+	// WellKnownPath is the path of the OIDC provider metadata, relative to the issuer URI.
+	WellKnownPath = "/.well-known/openid-configuration"
+
+	maxResponseBytes = 1 << 20
+)
+
+// Metadata represents the OIDC provider metadata used by identity-api.
+type Metadata struct {
+	Issuer           string `json:"issuer"`
+	JWKSURI          string `json:"jwks_uri"`
+	UserInfoEndpoint string `json:"userinfo_endpoint"`
+}
+
+// Client retrieves OIDC provider metadata.
+type Client struct {
+	httpClient *http.Client
+}
+
+// NewClient creates a new discovery client. If httpClient is nil, a client with DefaultTimeout is used.
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: DefaultTimeout,
+		}
+	}
+
+	return &Client{
+		httpClient: httpClient,
+	}
+}
+
+// Discover retrieves the provider metadata of the given issuer. Per OpenID Connect Discovery section
+// 4.3, the issuer in the metadata must match the issuer URI exactly.
+func (c *Client) Discover(ctx context.Context, issuerURI string) (Metadata, error) {
+	endpoint := strings.TrimSuffix(issuerURI, "/") + WellKnownPath
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("%w: %w", ErrDiscoveryFailed, err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("%w: %w", ErrDiscoveryFailed, err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return Metadata{}, fmt.Errorf("%w: unexpected status code %d", ErrDiscoveryFailed, resp.StatusCode)
+	}
+
+	var metadata Metadata
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&metadata); err != nil {
+		return Metadata{}, fmt.Errorf("%w: %w", ErrDiscoveryFailed, err)
+	}
+
+	if metadata.Issuer != issuerURI {
+		return Metadata{}, fmt.Errorf("%w: issuer %q does not match %q", ErrInvalidMetadata, metadata.Issuer, issuerURI)
+	}
+
+	if metadata.JWKSURI == "" {
+		return Metadata{}, fmt.Errorf("%w: missing jwks_uri", ErrInvalidMetadata)
+	}
+
+	return metadata, nil
+}
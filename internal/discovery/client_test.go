@@ -0,0 +1,156 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+)
+
+// stubProvider serves OIDC provider metadata for issuers under its URL, standing in for an upstream
+// identity provider.
+type stubProvider struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	metadata map[string]any
+}
+
+func newStubProvider(t *testing.T) *stubProvider {
+	t.Helper()
+
+	p := &stubProvider{
+		metadata: make(map[string]any),
+	}
+
+	p.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, ok := strings.CutSuffix(r.URL.Path, WellKnownPath)
+		if !ok || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		p.mu.Lock()
+		metadata, ok := p.metadata[path]
+		p.mu.Unlock()
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(metadata)
+	}))
+
+	t.Cleanup(p.Close)
+
+	return p
+}
+
+// set sets the metadata served for the issuer at the given path.
+func (p *stubProvider) set(path string, metadata any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.metadata[path] = metadata
+}
+
+// TestDiscover checks that provider metadata is retrieved and validated.
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	provider := newStubProvider(t)
+
+	provider.set("/good", Metadata{
+		Issuer:           provider.URL + "/good",
+		JWKSURI:          provider.URL + "/good/jwks.json",
+		UserInfoEndpoint: provider.URL + "/good/userinfo",
+	})
+
+	provider.set("/mismatch", Metadata{
+		Issuer:  provider.URL + "/other",
+		JWKSURI: provider.URL + "/mismatch/jwks.json",
+	})
+
+	provider.set("/nojwks", Metadata{
+		Issuer: provider.URL + "/nojwks",
+	})
+
+	provider.set("/invalid", "not metadata")
+
+	client := NewClient(provider.Client())
+
+	runFn := func(ctx context.Context, issuerURI string) testingx.TestResult[Metadata] {
+		out, err := client.Discover(ctx, issuerURI)
+
+		return testingx.TestResult[Metadata]{
+			Success: out,
+			Err:     err,
+		}
+	}
+
+	testCases := []testingx.TestCase[string, Metadata]{
+		{
+			Name:  "Success",
+			Input: provider.URL + "/good",
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[Metadata]) {
+				assert.NoError(t, result.Err)
+
+				expected := Metadata{
+					Issuer:           provider.URL + "/good",
+					JWKSURI:          provider.URL + "/good/jwks.json",
+					UserInfoEndpoint: provider.URL + "/good/userinfo",
+				}
+
+				assert.Equal(t, expected, result.Success)
+			},
+		},
+		{
+			Name:  "TrailingSlash",
+			Input: provider.URL + "/good/",
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[Metadata]) {
+				assert.ErrorIs(t, result.Err, ErrInvalidMetadata)
+			},
+		},
+		{
+			Name:  "IssuerMismatch",
+			Input: provider.URL + "/mismatch",
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[Metadata]) {
+				assert.ErrorIs(t, result.Err, ErrInvalidMetadata)
+			},
+		},
+		{
+			Name:  "MissingJWKSURI",
+			Input: provider.URL + "/nojwks",
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[Metadata]) {
+				assert.ErrorIs(t, result.Err, ErrInvalidMetadata)
+			},
+		},
+		{
+			Name:  "InvalidResponse",
+			Input: provider.URL + "/invalid",
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[Metadata]) {
+				assert.ErrorIs(t, result.Err, ErrDiscoveryFailed)
+			},
+		},
+		{
+			Name:  "NotFound",
+			Input: provider.URL + "/missing",
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[Metadata]) {
+				assert.ErrorIs(t, result.Err, ErrDiscoveryFailed)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
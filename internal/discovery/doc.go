@@ -0,0 +1,3 @@
+// Package discovery discovers the endpoints of upstream issuers from their OIDC provider metadata,
+// and periodically re-checks them so changes made by the issuer are picked up.
+package discovery
@@ -0,0 +1,13 @@
+package discovery
+
+import (
+	"errors"
+)
+
+var (
+	// ErrDiscoveryFailed represents an error where the provider metadata could not be retrieved.
+	ErrDiscoveryFailed = errors.New("OIDC discovery request failed")
+
+	// ErrInvalidMetadata represents an error where the provider metadata is not valid for the issuer.
+	ErrInvalidMetadata = errors.New("invalid OIDC provider metadata")
+)
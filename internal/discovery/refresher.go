@@ -0,0 +1,135 @@
+package discovery
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/storage"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// DefaultInterval is how often issuer endpoints are re-checked if no interval is configured.
+const DefaultInterval = time.Hour
+
+// Store represents the storage used to update discovered issuer endpoints.
+type Store interface {
+	types.IssuerService
+	storage.TransactionManager
+}
+
+// Refresher re-checks the endpoints of issuers with discovery enabled, updating them when the
+// issuer's provider metadata changes.
+type Refresher struct {
+	store    Store
+	client   *Client
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// Opt represents an option for configuring a Refresher.
+type Opt func(*Refresher)
+
+// WithLogger sets the logger for the Refresher.
+func WithLogger(logger *zap.Logger) Opt {
+	return func(r *Refresher) {
+		r.logger = logger
+	}
+}
+
+// NewRefresher creates a Refresher given a store, a discovery client and issuer discovery configuration.
+func NewRefresher(store Store, client *Client, config fositex.IssuerDiscoveryConfig, opts ...Opt) *Refresher {
+	interval := config.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	r := &Refresher{
+		store:    store,
+		client:   client,
+		interval: interval,
+		logger:   zap.NewNop(),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Run re-checks issuer endpoints every interval until the given context is canceled.
+func (r *Refresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+
+	defer ticker.Stop()
+
+	for {
+		r.Refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh re-checks the endpoints of every issuer with discovery enabled. Issuers whose metadata
+// cannot be retrieved keep their current endpoints.
+func (r *Refresher) Refresh(ctx context.Context) {
+	issuers, err := r.store.ListDiscoveryIssuers(ctx)
+	if err != nil {
+		r.logger.Error("failed to list issuers", zap.Error(err))
+
+		return
+	}
+
+	for _, iss := range issuers {
+		logger := r.logger.With(zap.String("issuer_id", iss.ID.String()), zap.String("issuer_uri", iss.URI))
+
+		metadata, err := r.client.Discover(ctx, iss.URI)
+		if err != nil {
+			logger.Warn("failed to discover issuer endpoints", zap.Error(err))
+
+			continue
+		}
+
+		if metadata.JWKSURI == iss.JWKSURI && metadata.UserInfoEndpoint == iss.UserInfoURI {
+			continue
+		}
+
+		if err := r.update(ctx, iss, metadata); err != nil {
+			logger.Error("failed to update issuer endpoints", zap.Error(err))
+
+			continue
+		}
+
+		logger.Info("updated issuer endpoints",
+			zap.String("jwks_uri", metadata.JWKSURI),
+			zap.String("userinfo_uri", metadata.UserInfoEndpoint),
+		)
+	}
+}
+
+func (r *Refresher) update(ctx context.Context, iss *types.Issuer, metadata Metadata) error {
+	ctx, err := r.store.BeginContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	update := types.IssuerUpdate{
+		JWKSURI:     &metadata.JWKSURI,
+		UserInfoURI: &metadata.UserInfoEndpoint,
+	}
+
+	if _, err := r.store.UpdateIssuer(ctx, iss.ID, update); err != nil {
+		_ = r.store.RollbackContext(ctx)
+
+		return err
+	}
+
+	return r.store.CommitContext(ctx)
+}
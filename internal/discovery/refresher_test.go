@@ -0,0 +1,137 @@
+package discovery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+type mockStore struct {
+	types.IssuerService
+
+	issuers   types.Issuers
+	updates   map[gidx.PrefixedID]types.IssuerUpdate
+	committed bool
+}
+
+func (s *mockStore) ListDiscoveryIssuers(_ context.Context) (types.Issuers, error) {
+	return s.issuers, nil
+}
+
+func (s *mockStore) UpdateIssuer(_ context.Context, id gidx.PrefixedID, update types.IssuerUpdate) (*types.Issuer, error) {
+	s.updates[id] = update
+
+	return &types.Issuer{ID: id}, nil
+}
+
+func (s *mockStore) BeginContext(ctx context.Context) (context.Context, error) {
+	return ctx, nil
+}
+
+func (s *mockStore) CommitContext(_ context.Context) error {
+	s.committed = true
+
+	return nil
+}
+
+func (s *mockStore) RollbackContext(_ context.Context) error {
+	return nil
+}
+
+// TestRefresh checks that issuer endpoints are updated when the provider metadata changes.
+func TestRefresh(t *testing.T) {
+	t.Parallel()
+
+	provider := newStubProvider(t)
+
+	provider.set("/idp", Metadata{
+		Issuer:           provider.URL + "/idp",
+		JWKSURI:          provider.URL + "/idp/keys",
+		UserInfoEndpoint: provider.URL + "/idp/userinfo",
+	})
+
+	client := NewClient(provider.Client())
+
+	type result struct {
+		update    *types.IssuerUpdate
+		committed bool
+	}
+
+	runFn := func(ctx context.Context, iss *types.Issuer) testingx.TestResult[result] {
+		store := &mockStore{
+			issuers: types.Issuers{iss},
+			updates: make(map[gidx.PrefixedID]types.IssuerUpdate),
+		}
+
+		NewRefresher(store, client, fositex.IssuerDiscoveryConfig{}).Refresh(ctx)
+
+		out := result{
+			committed: store.committed,
+		}
+
+		if update, ok := store.updates[iss.ID]; ok {
+			out.update = &update
+		}
+
+		return testingx.TestResult[result]{
+			Success: out,
+		}
+	}
+
+	testCases := []testingx.TestCase[*types.Issuer, result]{
+		{
+			Name: "Changed",
+			Input: &types.Issuer{
+				ID:        gidx.MustNewID(types.IdentityIssuerIDPrefix),
+				URI:       provider.URL + "/idp",
+				JWKSURI:   provider.URL + "/idp/jwks.json",
+				Discovery: true,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NotNil(t, res.Success.update)
+				assert.True(t, res.Success.committed)
+
+				require.NotNil(t, res.Success.update.JWKSURI)
+				assert.Equal(t, provider.URL+"/idp/keys", *res.Success.update.JWKSURI)
+
+				require.NotNil(t, res.Success.update.UserInfoURI)
+				assert.Equal(t, provider.URL+"/idp/userinfo", *res.Success.update.UserInfoURI)
+			},
+		},
+		{
+			Name: "Unchanged",
+			Input: &types.Issuer{
+				ID:          gidx.MustNewID(types.IdentityIssuerIDPrefix),
+				URI:         provider.URL + "/idp",
+				JWKSURI:     provider.URL + "/idp/keys",
+				UserInfoURI: provider.URL + "/idp/userinfo",
+				Discovery:   true,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				assert.Nil(t, res.Success.update)
+				assert.False(t, res.Success.committed)
+			},
+		},
+		{
+			Name: "Unavailable",
+			Input: &types.Issuer{
+				ID:        gidx.MustNewID(types.IdentityIssuerIDPrefix),
+				URI:       provider.URL + "/down",
+				JWKSURI:   provider.URL + "/down/jwks.json",
+				Discovery: true,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				assert.Nil(t, res.Success.update)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
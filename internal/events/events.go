@@ -1,12 +1,14 @@
 package events
 
 import (
+	eventsx "go.infratographer.com/x/events"
 	"go.uber.org/zap"
 )
 
 // Events represents a collection of relationships.
 type Events struct {
-	logger *zap.Logger
+	logger    *zap.Logger
+	publisher eventsx.Publisher
 }
 
 // Events implements the Service interface.
@@ -32,3 +34,11 @@ func WithLogger(logger *zap.Logger) Opt {
 		e.logger = logger
 	}
 }
+
+// WithPublisher is an option to set the publisher change events are published with. If unset,
+// change events are not published.
+func WithPublisher(publisher eventsx.Publisher) Opt {
+	return func(e *Events) {
+		e.publisher = publisher
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 
 	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/types"
 )
 
 // Service is the interface for the events service.
 type Service interface {
 	GroupService
+	UserService
 }
 
 // GroupService provides group-related event publishing and handling.
@@ -22,3 +25,9 @@ type GroupService interface {
 	// DeleteGroup deletes a group.
 	DeleteGroup(ctx context.Context, parentID, gid gidx.PrefixedID) error
 }
+
+// UserService provides user-related event publishing.
+type UserService interface {
+	// UpdateUser publishes a change event for the changes between the previous and current profile of a user.
+	UpdateUser(ctx context.Context, previous, user types.UserInfo) error
+}
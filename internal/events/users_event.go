@@ -0,0 +1,101 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	eventsx "go.infratographer.com/x/events"
+
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+const (
+	// UserTopic is the user topic.
+	UserTopic = "user"
+
+	userFieldName       = "name"
+	userFieldEmail      = "email"
+	userFieldAttributes = "attributes"
+)
+
+// UpdateUser publishes an update change event for a user whose profile changed. The user is
+// recorded as the actor, as profiles are updated when the user exchanges a token.
+func (e *Events) UpdateUser(ctx context.Context, previous, user types.UserInfo) error {
+	if e.publisher == nil {
+		return nil
+	}
+
+	changes, err := userFieldChanges(previous, user)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	_, err = e.publisher.PublishChange(ctx, UserTopic, eventsx.ChangeMessage{
+		SubjectID:    user.ID,
+		EventType:    string(eventsx.UpdateChangeType),
+		ActorID:      user.ID,
+		Timestamp:    time.Now().UTC(),
+		FieldChanges: changes,
+	})
+
+	return err
+}
+
+// userFieldChanges returns the changed profile fields. Attributes are recorded as JSON objects.
+func userFieldChanges(previous, user types.UserInfo) ([]eventsx.FieldChange, error) {
+	var changes []eventsx.FieldChange
+
+	if previous.Name != user.Name {
+		changes = append(changes, eventsx.FieldChange{
+			Field:         userFieldName,
+			PreviousValue: previous.Name,
+			CurrentValue:  user.Name,
+		})
+	}
+
+	if previous.Email != user.Email {
+		changes = append(changes, eventsx.FieldChange{
+			Field:         userFieldEmail,
+			PreviousValue: previous.Email,
+			CurrentValue:  user.Email,
+		})
+	}
+
+	if !types.EqualAttributes(previous.Attributes, user.Attributes) {
+		previousValue, err := attributesJSON(previous.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		currentValue, err := attributesJSON(user.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, eventsx.FieldChange{
+			Field:         userFieldAttributes,
+			PreviousValue: previousValue,
+			CurrentValue:  currentValue,
+		})
+	}
+
+	return changes, nil
+}
+
+func attributesJSON(attributes map[string]any) (string, error) {
+	if len(attributes) == 0 {
+		return "{}", nil
+	}
+
+	out, err := json.Marshal(attributes)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
@@ -0,0 +1,111 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	eventsx "go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+type mockPublisher struct {
+	eventsx.Publisher
+
+	topics   []string
+	messages []eventsx.ChangeMessage
+}
+
+func (p *mockPublisher) PublishChange(_ context.Context, topic string, message eventsx.ChangeMessage) (eventsx.Message[eventsx.ChangeMessage], error) {
+	p.topics = append(p.topics, topic)
+	p.messages = append(p.messages, message)
+
+	return nil, nil
+}
+
+// TestUpdateUser checks that user updated events are only published for changed profiles.
+func TestUpdateUser(t *testing.T) {
+	t.Parallel()
+
+	previous := types.UserInfo{
+		ID:    gidx.MustNewID(types.IdentityUserIDPrefix),
+		Name:  "Foo",
+		Email: "foo@example.com",
+	}
+
+	runFn := func(ctx context.Context, user types.UserInfo) testingx.TestResult[*mockPublisher] {
+		publisher := &mockPublisher{}
+
+		err := NewEvents(WithPublisher(publisher)).UpdateUser(ctx, previous, user)
+
+		return testingx.TestResult[*mockPublisher]{
+			Success: publisher,
+			Err:     err,
+		}
+	}
+
+	testCases := []testingx.TestCase[types.UserInfo, *mockPublisher]{
+		{
+			Name:  "Unchanged",
+			Input: previous,
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[*mockPublisher]) {
+				require.NoError(t, res.Err)
+				assert.Empty(t, res.Success.messages)
+			},
+		},
+		{
+			Name: "EmailChanged",
+			Input: types.UserInfo{
+				ID:    previous.ID,
+				Name:  previous.Name,
+				Email: "bar@example.com",
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[*mockPublisher]) {
+				require.NoError(t, res.Err)
+				require.Len(t, res.Success.messages, 1)
+
+				msg := res.Success.messages[0]
+
+				assert.Equal(t, []string{UserTopic}, res.Success.topics)
+				assert.Equal(t, previous.ID, msg.SubjectID)
+				assert.Equal(t, string(eventsx.UpdateChangeType), msg.EventType)
+				assert.Equal(t, []eventsx.FieldChange{
+					{
+						Field:         userFieldEmail,
+						PreviousValue: "foo@example.com",
+						CurrentValue:  "bar@example.com",
+					},
+				}, msg.FieldChanges)
+			},
+		},
+		{
+			Name: "AttributesChanged",
+			Input: types.UserInfo{
+				ID:    previous.ID,
+				Name:  previous.Name,
+				Email: previous.Email,
+				Attributes: map[string]any{
+					"department": "engineering",
+				},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[*mockPublisher]) {
+				require.NoError(t, res.Err)
+				require.Len(t, res.Success.messages, 1)
+
+				assert.Equal(t, []eventsx.FieldChange{
+					{
+						Field:         userFieldAttributes,
+						PreviousValue: "{}",
+						CurrentValue:  `{"department":"engineering"}`,
+					},
+				}, res.Success.messages[0].FieldChanges)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
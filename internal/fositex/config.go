@@ -2,12 +2,14 @@ package fositex
 
 import (
 	"context"
+	"time"
 
 	jose "github.com/go-jose/go-jose/v3"
 	"github.com/ory/fosite"
 	"github.com/ory/fosite/token/jwt"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"go.infratographer.com/x/gidx"
 	"go.infratographer.com/x/viperx"
 
 	"go.infratographer.com/identity-api/internal/types"
@@ -18,6 +20,13 @@ const (
 	PrivateKeyTypePublic PrivateKeyType = "public"
 	// PrivateKeyTypeSymmetric represents a symmetric key type.
 	PrivateKeyTypeSymmetric PrivateKeyType = "symmetric"
+
+	// ClaimGroups is the claim listing the groups the subject of an issued token is a member of.
+	ClaimGroups = "groups"
+	// ClaimOwnerID is the claim identifying the owner an issued token is scoped to.
+	ClaimOwnerID = "owner_id"
+	// ParamOwnerID is the token request parameter used to request a token scoped to an owner.
+	ParamOwnerID = "owner_id"
 )
 
 // PrivateKeyType represents a key type (public or symmetric)
@@ -28,6 +37,12 @@ type PrivateKey struct {
 	KeyID     string
 	Algorithm jose.SignatureAlgorithm
 	Path      string
+	// State is the lifecycle state of the key. If no key has a state, the first key is
+	// active and the others are retired.
+	State KeyState
+	// ActivateAt is the time from which the key signs tokens. A pending key is activated at
+	// this time.
+	ActivateAt time.Time
 }
 
 // Config represents an application config section for Fosite.
@@ -35,9 +50,99 @@ type Config struct {
 	Issuer              string
 	AccessTokenLifespan int
 	Secret              string
-	// When configuring an OAuth provider, the first private key will be used to sign
-	// JWTs.
+	// When configuring an OAuth provider, the most recently activated private key will be
+	// used to sign JWTs.
 	PrivateKeys []PrivateKey
+	// GroupsClaim configures the groups claim in issued access tokens.
+	GroupsClaim GroupsClaimConfig
+	// TokenGC configures the removal of expired tokens from storage.
+	TokenGC TokenGCConfig
+	// SigningKeys configures signing keys stored in the database.
+	SigningKeys SigningKeysConfig
+	// IssuerCache configures the caching of issuers looked up during token exchange.
+	IssuerCache IssuerCacheConfig
+	// IssuerSecrets configures the encryption of issuer introspection client secrets.
+	IssuerSecrets IssuerSecretsConfig
+	// IssuerDiscovery configures the discovery of issuer endpoints from OIDC provider metadata.
+	IssuerDiscovery IssuerDiscoveryConfig
+	// UserInfo configures fetching user info from the userinfo endpoints of issuers.
+	UserInfo UserInfoConfig
+	// LastSeen configures recording when users and clients were last issued tokens.
+	LastSeen LastSeenConfig
+}
+
+// GroupsClaimConfig represents the configuration of the groups claim in issued access tokens.
+type GroupsClaimConfig struct {
+	// Enabled determines whether issued access tokens include a groups claim.
+	Enabled bool
+	// MaxGroups is the maximum number of groups included in the claim. Groups beyond this
+	// limit are omitted, ordered by group ID.
+	MaxGroups int
+	// RestrictToOwner limits the claim to groups owned by the owner of the issuer or client
+	// the token was issued through.
+	RestrictToOwner bool
+}
+
+// TokenGCConfig represents the configuration of the removal of expired tokens from storage.
+type TokenGCConfig struct {
+	// Interval is how often expired tokens are removed.
+	Interval time.Duration
+	// Retention is how long tokens are kept after they expire.
+	Retention time.Duration
+}
+
+// IssuerCacheConfig represents the configuration of the issuer cache.
+type IssuerCacheConfig struct {
+	// TTL is how long issuers are cached. Changes made through other replicas are picked up once
+	// it has passed. A negative TTL disables the cache.
+	TTL time.Duration
+}
+
+// IssuerSecretsConfig represents the configuration of the encryption of issuer introspection client secrets.
+type IssuerSecretsConfig struct {
+	// EncryptionKey is the secret introspection client secrets are encrypted with in the database.
+	// Issuers cannot be given an introspection client secret when it is not set.
+	EncryptionKey string
+}
+
+// IssuerDiscoveryConfig represents the configuration of issuer endpoint discovery.
+type IssuerDiscoveryConfig struct {
+	// Interval is how often the endpoints of issuers with discovery enabled are re-checked.
+	Interval time.Duration
+}
+
+// UserInfoConfig represents the configuration of fetching user info from the userinfo endpoints of issuers.
+type UserInfoConfig struct {
+	// RefreshInterval is how long user info fetched from an issuer's userinfo endpoint is used
+	// before it is fetched again.
+	RefreshInterval time.Duration
+}
+
+// LastSeenConfig represents the configuration of recording when users and clients were last issued tokens.
+type LastSeenConfig struct {
+	// FlushInterval is how often the recorded times are written to the database. Times recorded
+	// meanwhile are kept in memory, and only the latest time of each user and client is written.
+	FlushInterval time.Duration
+}
+
+// SigningKeysConfig represents the configuration of signing keys stored in the database.
+type SigningKeysConfig struct {
+	// EncryptionKey is the secret private keys are encrypted with in the database. Signing keys
+	// are only loaded from the database, and managed through the API, when it is set.
+	EncryptionKey string
+	// OwnerID is the resource access to the signing keys API is checked against.
+	OwnerID gidx.PrefixedID
+	// RefreshInterval is how often signing keys are reloaded from the database. Keys activated
+	// through the API start signing tokens after this interval, once every replica has loaded them.
+	RefreshInterval time.Duration
+	// BootstrapAlgorithm is the algorithm of the key generated at startup when neither the config
+	// file nor the database holds a signing key.
+	BootstrapAlgorithm jose.SignatureAlgorithm
+}
+
+// Enabled reports whether signing keys are stored in the database.
+func (c SigningKeysConfig) Enabled() bool {
+	return c.EncryptionKey != ""
 }
 
 // IssuerJWKSURIProvider represents a provider for the JWKS URI for a given issuer.
@@ -45,9 +150,9 @@ type IssuerJWKSURIProvider interface {
 	GetIssuerJWKSURI(ctx context.Context, iss string) (string, error)
 }
 
-// SigningKeyProvider represents a provider of a signing key.
-type SigningKeyProvider interface {
-	GetSigningKey(ctx context.Context) *jose.JSONWebKey
+// SignerProvider represents a provider of the signer used to sign tokens.
+type SignerProvider interface {
+	GetSigner(ctx context.Context) Signer
 }
 
 // SigningJWKSProvider represents a provider of a valid signing JWKS.
@@ -55,6 +160,11 @@ type SigningJWKSProvider interface {
 	GetSigningJWKS(ctx context.Context) *jose.JSONWebKeySet
 }
 
+// ActiveSigningJWKSProvider represents a provider of the keys which may currently sign tokens.
+type ActiveSigningJWKSProvider interface {
+	GetActiveSigningJWKS(ctx context.Context) *jose.JSONWebKeySet
+}
+
 // ClaimMappingStrategy represents a strategy for mapping token claims to other claims.
 type ClaimMappingStrategy interface {
 	MapClaims(ctx context.Context, claims *jwt.JWTClaims) (jwt.JWTClaimsContainer, error)
@@ -65,6 +175,16 @@ type ClaimMappingStrategyProvider interface {
 	GetClaimMappingStrategy(ctx context.Context) ClaimMappingStrategy
 }
 
+// ScopeMappingStrategy represents a strategy for determining the scopes that may be granted for token claims.
+type ScopeMappingStrategy interface {
+	MapScopes(ctx context.Context, claims *jwt.JWTClaims) ([]string, error)
+}
+
+// ScopeMappingStrategyProvider represents a provider of a scope mapping strategy.
+type ScopeMappingStrategyProvider interface {
+	GetScopeMappingStrategy(ctx context.Context) ScopeMappingStrategy
+}
+
 // ClaimConditionStrategyProvider represents a provider of a claims condition eval strategy.
 type ClaimConditionStrategyProvider interface {
 	GetClaimConditionStrategy(ctx context.Context) ClaimConditionStrategy
@@ -75,6 +195,69 @@ type ClaimConditionStrategy interface {
 	Eval(ctx context.Context, claims *jwt.JWTClaims) (bool, error)
 }
 
+// ActorConditionStrategyProvider represents a provider of an actor condition eval strategy.
+type ActorConditionStrategyProvider interface {
+	GetActorConditionStrategy(ctx context.Context) ActorConditionStrategy
+}
+
+// ActorConditionStrategy represents a strategy for deciding whether an actor may act on behalf of a subject.
+type ActorConditionStrategy interface {
+	Eval(ctx context.Context, subjectClaims, actorClaims *jwt.JWTClaims) (bool, error)
+}
+
+// IntrospectionStrategy represents a strategy for validating opaque tokens using RFC 7662 token introspection.
+type IntrospectionStrategy interface {
+	IntrospectToken(ctx context.Context, issuerURI, token string) (*jwt.JWTClaims, error)
+}
+
+// IntrospectionStrategyProvider represents a provider of an introspection strategy.
+type IntrospectionStrategyProvider interface {
+	GetIntrospectionStrategy(ctx context.Context) IntrospectionStrategy
+}
+
+// UserInfoFetchStrategy represents a strategy for fetching user info from an issuer's userinfo endpoint.
+type UserInfoFetchStrategy interface {
+	FetchUserInfo(ctx context.Context, endpoint, token string) (map[string]any, error)
+}
+
+// UserInfoFetchStrategyProvider represents a provider of a user info fetch strategy.
+type UserInfoFetchStrategyProvider interface {
+	GetUserInfoFetchStrategy(ctx context.Context) UserInfoFetchStrategy
+	// GetUserInfoRefreshInterval returns how long fetched user info is used before it is fetched again.
+	GetUserInfoRefreshInterval(ctx context.Context) time.Duration
+}
+
+// GroupsClaimStrategy represents a strategy for building the groups claim of an issued token.
+type GroupsClaimStrategy interface {
+	GetGroupsClaim(ctx context.Context, subject, ownerID gidx.PrefixedID) ([]string, error)
+	GetOwnerGroupsClaim(ctx context.Context, subject, ownerID gidx.PrefixedID) ([]string, error)
+}
+
+// GroupsClaimStrategyProvider represents a provider of a groups claim strategy.
+type GroupsClaimStrategyProvider interface {
+	GetGroupsClaimStrategy(ctx context.Context) GroupsClaimStrategy
+}
+
+// OwnerAccessStrategy represents a strategy for deciding whether a subject may obtain tokens scoped to an owner.
+type OwnerAccessStrategy interface {
+	CheckOwnerAccess(ctx context.Context, subject, homeOwnerID, ownerID gidx.PrefixedID) (bool, error)
+}
+
+// OwnerAccessStrategyProvider represents a provider of an owner access strategy.
+type OwnerAccessStrategyProvider interface {
+	GetOwnerAccessStrategy(ctx context.Context) OwnerAccessStrategy
+}
+
+// IssuerStrategy looks up issuers in the storage backend.
+type IssuerStrategy interface {
+	types.IssuerService
+}
+
+// IssuerStrategyProvider represents the provider of the IssuerStrategy.
+type IssuerStrategyProvider interface {
+	GetIssuerStrategy(ctx context.Context) IssuerStrategy
+}
+
 // UserInfoStrategy persists user information in the storage backend.
 type UserInfoStrategy interface {
 	types.UserInfoService
@@ -91,29 +274,76 @@ type UserInfoStrategyProvider interface {
 	GetUserInfoStrategy(ctx context.Context) UserInfoStrategy
 }
 
+// UserEventStrategy represents a strategy for publishing events about users updated during token exchange.
+type UserEventStrategy interface {
+	UpdateUser(ctx context.Context, previous, user types.UserInfo) error
+}
+
+// UserEventStrategyProvider represents a provider of a user event strategy.
+type UserEventStrategyProvider interface {
+	GetUserEventStrategy(ctx context.Context) UserEventStrategy
+}
+
+// LastSeenStrategy represents a strategy for recording when users and OAuth clients were last issued tokens.
+// Recording must not block, as it happens while tokens are issued.
+type LastSeenStrategy interface {
+	RecordUserExchange(userID gidx.PrefixedID, at time.Time)
+	RecordClientToken(clientID gidx.PrefixedID, at time.Time)
+}
+
+// LastSeenStrategyProvider represents a provider of a last seen strategy.
+type LastSeenStrategyProvider interface {
+	GetLastSeenStrategy(ctx context.Context) LastSeenStrategy
+}
+
+// GrantTypeHandler is implemented by token endpoint handlers to advertise the grant type they handle
+// in the authorization server metadata.
+type GrantTypeHandler interface {
+	GrantType() string
+}
+
 // OAuth2Configurator represents an OAuth2 configuration.
 type OAuth2Configurator interface {
 	fosite.Configurator
-	SigningKeyProvider
+	SignerProvider
 	SigningJWKSProvider
+	ActiveSigningJWKSProvider
 	ClaimMappingStrategyProvider
+	ScopeMappingStrategyProvider
 	ClaimConditionStrategyProvider
+	ActorConditionStrategyProvider
+	IssuerStrategyProvider
+	IntrospectionStrategyProvider
+	UserInfoFetchStrategyProvider
+	GroupsClaimStrategyProvider
+	OwnerAccessStrategyProvider
 	UserInfoStrategyProvider
+	UserEventStrategyProvider
+	LastSeenStrategyProvider
 	GetIssuerJWKSURIProvider(ctx context.Context) IssuerJWKSURIProvider
 }
 
 // OAuth2Config represents a Fosite OAuth 2.0 provider configuration.
 type OAuth2Config struct {
 	*fosite.Config
-	SigningKey  *jose.JSONWebKey
-	SigningJWKS *jose.JSONWebKeySet
+	KeyRing *KeyRing
 
 	ClaimMappingStrategy   ClaimMappingStrategy
+	ScopeMappingStrategy   ScopeMappingStrategy
 	ClaimConditionStrategy ClaimConditionStrategy
+	ActorConditionStrategy ActorConditionStrategy
+	IssuerStrategy         IssuerStrategy
+	IntrospectionStrategy  IntrospectionStrategy
+	UserInfoFetchStrategy  UserInfoFetchStrategy
+	GroupsClaimStrategy    GroupsClaimStrategy
+	OwnerAccessStrategy    OwnerAccessStrategy
 	UserInfoStrategy       UserInfoStrategy
+	UserEventStrategy      UserEventStrategy
+	LastSeenStrategy       LastSeenStrategy
 
-	IssuerJWKSURIProvider IssuerJWKSURIProvider
-	userInfoAudience      string
+	IssuerJWKSURIProvider   IssuerJWKSURIProvider
+	userInfoAudience        string
+	userInfoRefreshInterval time.Duration
 }
 
 // GetIssuerJWKSURIProvider returns the config's IssuerJWKSURIProvider.
@@ -121,14 +351,21 @@ func (c *OAuth2Config) GetIssuerJWKSURIProvider(_ context.Context) IssuerJWKSURI
 	return c.IssuerJWKSURIProvider
 }
 
-// GetSigningKey returns the config's signing key.
-func (c *OAuth2Config) GetSigningKey(_ context.Context) *jose.JSONWebKey {
-	return c.SigningKey
+// GetSigner returns the config's current signer.
+func (c *OAuth2Config) GetSigner(_ context.Context) Signer {
+	return c.KeyRing.Signer()
 }
 
-// GetSigningJWKS returns the config's signing JWKS. This includes private keys.
+// GetSigningJWKS returns the config's signing JWKS, used to verify issued tokens. This includes
+// symmetric keys.
 func (c *OAuth2Config) GetSigningJWKS(_ context.Context) *jose.JSONWebKeySet {
-	return c.SigningJWKS
+	return c.KeyRing.JWKS()
+}
+
+// GetActiveSigningJWKS returns the keys of the config's key ring which may currently sign tokens. This
+// includes symmetric keys.
+func (c *OAuth2Config) GetActiveSigningJWKS(_ context.Context) *jose.JSONWebKeySet {
+	return c.KeyRing.ActiveJWKS()
 }
 
 // GetClaimMappingStrategy returns the config's claims mapping strategy.
@@ -136,16 +373,68 @@ func (c *OAuth2Config) GetClaimMappingStrategy(_ context.Context) ClaimMappingSt
 	return c.ClaimMappingStrategy
 }
 
+// GetScopeMappingStrategy returns the config's scope mapping strategy.
+func (c *OAuth2Config) GetScopeMappingStrategy(_ context.Context) ScopeMappingStrategy {
+	return c.ScopeMappingStrategy
+}
+
 // GetClaimConditionStrategy returns the config's claim condition strategy.
 func (c *OAuth2Config) GetClaimConditionStrategy(_ context.Context) ClaimConditionStrategy {
 	return c.ClaimConditionStrategy
 }
 
+// GetActorConditionStrategy returns the config's actor condition strategy.
+func (c *OAuth2Config) GetActorConditionStrategy(_ context.Context) ActorConditionStrategy {
+	return c.ActorConditionStrategy
+}
+
+// GetIssuerStrategy returns the config's issuer lookup strategy.
+func (c *OAuth2Config) GetIssuerStrategy(_ context.Context) IssuerStrategy {
+	return c.IssuerStrategy
+}
+
+// GetIntrospectionStrategy returns the config's token introspection strategy.
+func (c *OAuth2Config) GetIntrospectionStrategy(_ context.Context) IntrospectionStrategy {
+	return c.IntrospectionStrategy
+}
+
+// GetUserInfoFetchStrategy returns the config's user info fetch strategy. If nil, user info is
+// only read from token claims.
+func (c *OAuth2Config) GetUserInfoFetchStrategy(_ context.Context) UserInfoFetchStrategy {
+	return c.UserInfoFetchStrategy
+}
+
+// GetUserInfoRefreshInterval returns how long fetched user info is used before it is fetched again.
+func (c *OAuth2Config) GetUserInfoRefreshInterval(_ context.Context) time.Duration {
+	return c.userInfoRefreshInterval
+}
+
+// GetGroupsClaimStrategy returns the config's groups claim strategy. If nil, no groups claim is issued.
+func (c *OAuth2Config) GetGroupsClaimStrategy(_ context.Context) GroupsClaimStrategy {
+	return c.GroupsClaimStrategy
+}
+
+// GetOwnerAccessStrategy returns the config's owner access strategy.
+func (c *OAuth2Config) GetOwnerAccessStrategy(_ context.Context) OwnerAccessStrategy {
+	return c.OwnerAccessStrategy
+}
+
 // GetUserInfoStrategy returns the config's user info store strategy.
 func (c *OAuth2Config) GetUserInfoStrategy(_ context.Context) UserInfoStrategy {
 	return c.UserInfoStrategy
 }
 
+// GetUserEventStrategy returns the config's user event strategy. If nil, no user events are published.
+func (c *OAuth2Config) GetUserEventStrategy(_ context.Context) UserEventStrategy {
+	return c.UserEventStrategy
+}
+
+// GetLastSeenStrategy returns the config's last seen strategy. If nil, the times users and clients
+// were last issued tokens are not recorded.
+func (c *OAuth2Config) GetLastSeenStrategy(_ context.Context) LastSeenStrategy {
+	return c.LastSeenStrategy
+}
+
 // GetUserInfoAudience returns this services userinfo audience.
 func (c *OAuth2Config) GetUserInfoAudience() string {
 	return c.userInfoAudience
@@ -5,6 +5,10 @@ package fositex
 import (
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -17,11 +21,20 @@ import (
 
 	jose "github.com/go-jose/go-jose/v3"
 	"github.com/ory/fosite"
+	"github.com/ory/fosite/token/jwt"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 )
 
-const instrumentationName = "go.infratographer.com/identity-api/internal/fositex"
+const (
+	instrumentationName = "go.infratographer.com/identity-api/internal/fositex"
+
+	// rsaKeyBits is the size of generated RSA keys.
+	rsaKeyBits = 3072
+
+	// DefaultUserInfoRefreshInterval is how long fetched user info is used if no refresh interval is configured.
+	DefaultUserInfoRefreshInterval = 24 * time.Hour
+)
 
 var (
 	// ErrInvalidKey is returned when the key is not valid.
@@ -43,32 +56,36 @@ func readSymmetricKey(path string) ([]byte, error) {
 	return bytes, nil
 }
 
-func readAsymmetricKey[T crypto.Signer](path string) (T, error) {
-	var empty T
-
+func readPEMKey(path string) ([]byte, error) {
 	f, err := os.Open(path)
 	if err != nil {
-		return empty, err
+		return nil, err
 	}
 
 	defer f.Close() //nolint:errcheck
 
 	bytes, err := io.ReadAll(f)
 	if err != nil {
-		return empty, err
+		return nil, err
 	}
 
 	block, rest := pem.Decode(bytes)
 
 	switch {
 	case block == nil, block.Type != "PRIVATE KEY":
-		return empty, fmt.Errorf("%w: invalid private key", ErrInvalidKey)
+		return nil, fmt.Errorf("%w: invalid private key", ErrInvalidKey)
 	case len(rest) > 0:
-		return empty, fmt.Errorf("%w: extra data in private key", ErrInvalidKey)
+		return nil, fmt.Errorf("%w: extra data in private key", ErrInvalidKey)
 	default:
 	}
 
-	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	return block.Bytes, nil
+}
+
+func parseAsymmetricKey[T crypto.Signer](der []byte) (T, error) {
+	var empty T
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
 	if err != nil {
 		return empty, err
 	}
@@ -81,20 +98,43 @@ func readAsymmetricKey[T crypto.Signer](path string) (T, error) {
 	return signer, nil
 }
 
-func readPrivateKey(key PrivateKey) (jose.JSONWebKey, error) {
+// ecdsaCurves maps ECDSA signing algorithms to the curve their keys must use.
+var ecdsaCurves = map[jose.SignatureAlgorithm]elliptic.Curve{
+	jose.ES256: elliptic.P256(),
+	jose.ES384: elliptic.P384(),
+}
+
+func parseECDSAKey(der []byte, alg jose.SignatureAlgorithm) (*ecdsa.PrivateKey, error) {
+	key, err := parseAsymmetricKey[*ecdsa.PrivateKey](der)
+	if err != nil {
+		return nil, err
+	}
+
+	if curve := ecdsaCurves[alg]; key.Curve != curve {
+		return nil, fmt.Errorf("%w: key curve %s does not match algorithm %s",
+			ErrInvalidKey, key.Curve.Params().Name, alg)
+	}
+
+	return key, nil
+}
+
+// ParsePKCS8Key parses a PKCS #8 encoded private key for the given asymmetric signing algorithm.
+func ParsePKCS8Key(keyID string, alg jose.SignatureAlgorithm, der []byte) (jose.JSONWebKey, error) {
 	var (
 		rawKey interface{}
 		err    error
 	)
 
-	switch key.Algorithm {
+	switch alg {
 	case jose.RS256, jose.RS384, jose.RS512:
-		rawKey, err = readAsymmetricKey[*rsa.PrivateKey](key.Path)
-	case jose.HS256, jose.HS384, jose.HS512:
-		rawKey, err = readSymmetricKey(key.Path)
+		rawKey, err = parseAsymmetricKey[*rsa.PrivateKey](der)
+	case jose.ES256, jose.ES384:
+		rawKey, err = parseECDSAKey(der, alg)
+	case jose.EdDSA:
+		rawKey, err = parseAsymmetricKey[ed25519.PrivateKey](der)
 	default:
 		return jose.JSONWebKey{}, fmt.Errorf("%w: unsupported private key type %s",
-			ErrInvalidKey, key.Algorithm)
+			ErrInvalidKey, alg)
 	}
 
 	if err != nil {
@@ -103,46 +143,85 @@ func readPrivateKey(key PrivateKey) (jose.JSONWebKey, error) {
 
 	out := jose.JSONWebKey{
 		Key:       rawKey,
-		KeyID:     key.KeyID,
-		Algorithm: string(key.Algorithm),
+		KeyID:     keyID,
+		Algorithm: string(alg),
 	}
 
 	return out, nil
 }
 
-func parsePrivateKeys(keys []PrivateKey) (*jose.JSONWebKey, *jose.JSONWebKeySet, error) {
-	if len(keys) == 0 {
-		return nil, nil, fmt.Errorf("%w: no private keys provided", ErrInvalidKey)
-	}
+// GenerateKey generates a new private key for the given asymmetric signing algorithm, and returns it
+// PKCS #8 encoded.
+func GenerateKey(alg jose.SignatureAlgorithm) ([]byte, error) {
+	var (
+		key any
+		err error
+	)
 
-	first := keys[0]
+	switch alg {
+	case jose.RS256, jose.RS384, jose.RS512:
+		key, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case jose.ES256, jose.ES384:
+		key, err = ecdsa.GenerateKey(ecdsaCurves[alg], rand.Reader)
+	case jose.EdDSA:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("%w: unsupported private key type %s", ErrInvalidKey, alg)
+	}
 
-	signingKey, err := readPrivateKey(first)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	var jwks jose.JSONWebKeySet
+	return x509.MarshalPKCS8PrivateKey(key)
+}
 
-	jwks.Keys = append(jwks.Keys, signingKey)
+func readPrivateKey(key PrivateKey) (jose.JSONWebKey, error) {
+	switch key.Algorithm {
+	case jose.HS256, jose.HS384, jose.HS512:
+		rawKey, err := readSymmetricKey(key.Path)
+		if err != nil {
+			return jose.JSONWebKey{}, err
+		}
+
+		out := jose.JSONWebKey{
+			Key:       rawKey,
+			KeyID:     key.KeyID,
+			Algorithm: string(key.Algorithm),
+		}
 
-	for _, key := range keys[1:] {
-		jwk, err := readPrivateKey(key)
+		return out, nil
+	case jose.RS256, jose.RS384, jose.RS512, jose.ES256, jose.ES384, jose.EdDSA:
+		der, err := readPEMKey(key.Path)
 		if err != nil {
-			return nil, nil, err
+			return jose.JSONWebKey{}, err
 		}
 
-		jwks.Keys = append(jwks.Keys, jwk)
+		return ParsePKCS8Key(key.KeyID, key.Algorithm, der)
+	default:
+		return jose.JSONWebKey{}, fmt.Errorf("%w: unsupported private key type %s",
+			ErrInvalidKey, key.Algorithm)
 	}
-
-	return &signingKey, &jwks, nil
 }
 
 // NewOAuth2Config builds a new OAuth2Config from the given Config.
 func NewOAuth2Config(config Config) (*OAuth2Config, error) {
-	signingKey, jwks, err := parsePrivateKeys(config.PrivateKeys)
-	if err != nil {
-		return nil, err
+	var (
+		keyRing *KeyRing
+		err     error
+	)
+
+	if len(config.PrivateKeys) == 0 && config.SigningKeys.Enabled() {
+		// Signing keys are loaded from the database once storage is available.
+		keyRing = &KeyRing{
+			jwks: &jose.JSONWebKeySet{},
+			now:  time.Now,
+		}
+	} else {
+		keyRing, err = LoadKeyRing(config.PrivateKeys)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	tokenLifespan := time.Second * time.Duration(config.AccessTokenLifespan)
@@ -150,6 +229,10 @@ func NewOAuth2Config(config Config) (*OAuth2Config, error) {
 		AccessTokenIssuer:   config.Issuer,
 		AccessTokenLifespan: tokenLifespan,
 		GlobalSecret:        []byte(config.Secret),
+		// Issue both the RFC 9068 "scope" claim and the "scp" claim existing consumers may rely on.
+		JWTScopeClaimKey: jwt.JWTScopeFieldBoth,
+		// Refresh tokens are only issued to clients allowed the refresh_token grant, not gated on a scope.
+		RefreshTokenScopes: []string{},
 	}
 
 	userInfoAudience, err := url.JoinPath(config.Issuer, "userinfo")
@@ -157,11 +240,16 @@ func NewOAuth2Config(config Config) (*OAuth2Config, error) {
 		return nil, err
 	}
 
+	userInfoRefreshInterval := config.UserInfo.RefreshInterval
+	if userInfoRefreshInterval <= 0 {
+		userInfoRefreshInterval = DefaultUserInfoRefreshInterval
+	}
+
 	out := &OAuth2Config{
-		Config:           fositeConfig,
-		SigningKey:       signingKey,
-		SigningJWKS:      jwks,
-		userInfoAudience: userInfoAudience,
+		Config:                  fositeConfig,
+		KeyRing:                 keyRing,
+		userInfoAudience:        userInfoAudience,
+		userInfoRefreshInterval: userInfoRefreshInterval,
 	}
 
 	return out, nil
@@ -189,6 +277,22 @@ func (p *instrumentedProvider) NewAccessResponse(ctx context.Context, req fosite
 	return p.OAuth2Provider.NewAccessResponse(ctx, req)
 }
 
+func (p *instrumentedProvider) NewIntrospectionRequest(ctx context.Context, req *http.Request, session fosite.Session) (fosite.IntrospectionResponder, error) {
+	ctx, span := p.tracer.Start(ctx, "fositex.NewIntrospectionRequest")
+
+	defer span.End()
+
+	return p.OAuth2Provider.NewIntrospectionRequest(ctx, req, session)
+}
+
+func (p *instrumentedProvider) NewRevocationRequest(ctx context.Context, req *http.Request) error {
+	ctx, span := p.tracer.Start(ctx, "fositex.NewRevocationRequest")
+
+	defer span.End()
+
+	return p.OAuth2Provider.NewRevocationRequest(ctx, req)
+}
+
 // NewOAuth2Provider creates a new fosite.OAuth2Provider.
 // The configurator, store, and strategy are all passed to the factories
 // and the resulting endpoint handlers are registered to the fosite.Config.
@@ -0,0 +1,200 @@
+package fositex
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/compose"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+)
+
+func writePKCS8Key(t *testing.T, key crypto.Signer) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	return path
+}
+
+// TestReadPrivateKey checks that asymmetric keys are loaded and advertised with the right algorithm and curve,
+// and that tokens signed with them can be validated.
+func TestReadPrivateKey(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	type publicJWK struct {
+		Alg string `json:"alg"`
+		Kty string `json:"kty"`
+		Crv string `json:"crv"`
+	}
+
+	runFn := func(ctx context.Context, input PrivateKey) testingx.TestResult[publicJWK] {
+		key, err := readPrivateKey(input)
+		if err != nil {
+			return testingx.TestResult[publicJWK]{
+				Err: err,
+			}
+		}
+
+		keyRing, err := NewKeyRing([]SigningKey{{Signer: NewKeySigner(key), State: KeyStateActive}})
+		if err != nil {
+			return testingx.TestResult[publicJWK]{
+				Err: err,
+			}
+		}
+
+		config := &OAuth2Config{
+			Config: &fosite.Config{
+				AccessTokenIssuer: "https://example.com/",
+				GlobalSecret:      []byte("abcd1234abcd1234abcd1234abcd1234"),
+			},
+			KeyRing: keyRing,
+		}
+
+		strategy := NewOAuth2JWTStrategy(config, compose.NewOAuth2HMACStrategy(config))
+
+		request := fosite.NewRequest()
+		request.Session = &oauth2.JWTSession{
+			JWTClaims: &jwt.JWTClaims{
+				Subject: "sub",
+			},
+			JWTHeader: &jwt.Headers{},
+			ExpiresAt: map[fosite.TokenType]time.Time{
+				fosite.AccessToken: time.Now().Add(time.Hour),
+			},
+		}
+
+		token, _, err := strategy.GenerateAccessToken(ctx, request)
+		if err != nil {
+			return testingx.TestResult[publicJWK]{
+				Err: err,
+			}
+		}
+
+		if err := strategy.ValidateAccessToken(ctx, request, token); err != nil {
+			return testingx.TestResult[publicJWK]{
+				Err: err,
+			}
+		}
+
+		keyJSON, err := json.Marshal(key.Public())
+		if err != nil {
+			return testingx.TestResult[publicJWK]{
+				Err: err,
+			}
+		}
+
+		var out publicJWK
+
+		err = json.Unmarshal(keyJSON, &out)
+
+		return testingx.TestResult[publicJWK]{
+			Success: out,
+			Err:     err,
+		}
+	}
+
+	testCases := []testingx.TestCase[PrivateKey, publicJWK]{
+		{
+			Name: "RS256",
+			Input: PrivateKey{
+				Algorithm: jose.RS256,
+				Path:      writePKCS8Key(t, rsaKey),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[publicJWK]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, publicJWK{Alg: "RS256", Kty: "RSA"}, res.Success)
+			},
+		},
+		{
+			Name: "ES256",
+			Input: PrivateKey{
+				Algorithm: jose.ES256,
+				Path:      writePKCS8Key(t, p256Key),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[publicJWK]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, publicJWK{Alg: "ES256", Kty: "EC", Crv: "P-256"}, res.Success)
+			},
+		},
+		{
+			Name: "ES384",
+			Input: PrivateKey{
+				Algorithm: jose.ES384,
+				Path:      writePKCS8Key(t, p384Key),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[publicJWK]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, publicJWK{Alg: "ES384", Kty: "EC", Crv: "P-384"}, res.Success)
+			},
+		},
+		{
+			Name: "EdDSA",
+			Input: PrivateKey{
+				Algorithm: jose.EdDSA,
+				Path:      writePKCS8Key(t, ed25519Key),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[publicJWK]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, publicJWK{Alg: "EdDSA", Kty: "OKP", Crv: "Ed25519"}, res.Success)
+			},
+		},
+		{
+			Name: "CurveMismatch",
+			Input: PrivateKey{
+				Algorithm: jose.ES384,
+				Path:      writePKCS8Key(t, p256Key),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[publicJWK]) {
+				assert.ErrorIs(t, res.Err, ErrInvalidKey)
+			},
+		},
+		{
+			Name: "KeyTypeMismatch",
+			Input: PrivateKey{
+				Algorithm: jose.EdDSA,
+				Path:      writePKCS8Key(t, p256Key),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[publicJWK]) {
+				assert.ErrorIs(t, res.Err, ErrInvalidKey)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
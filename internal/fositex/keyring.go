@@ -0,0 +1,273 @@
+package fositex
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v3"
+)
+
+const (
+	// KeyStatePending represents a key which is published but not used to sign tokens until it is
+	// activated, either at its activation time or by changing its state to active.
+	KeyStatePending KeyState = "pending"
+	// KeyStateActive represents a key used to sign tokens.
+	KeyStateActive KeyState = "active"
+	// KeyStateRetired represents a key which is no longer used to sign tokens, but is still published
+	// so tokens it signed can be validated until they expire.
+	KeyStateRetired KeyState = "retired"
+)
+
+// KeyState represents the lifecycle state of a signing key.
+type KeyState string
+
+// SigningKey represents a key in a KeyRing.
+type SigningKey struct {
+	Signer     Signer
+	State      KeyState
+	ActivateAt time.Time
+}
+
+// signsAt reports whether the key may sign tokens at the given time.
+func (k SigningKey) signsAt(now time.Time) bool {
+	switch k.State {
+	case KeyStateActive:
+		return !now.Before(k.ActivateAt)
+	case KeyStatePending:
+		return !k.ActivateAt.IsZero() && !now.Before(k.ActivateAt)
+	default:
+		return false
+	}
+}
+
+// KeySourceConfig is the source of the keys read from the config file.
+const KeySourceConfig = "config"
+
+// KeyRing holds the keys used to sign tokens and the keys published to validate them. Keys can be
+// replaced while the server is running, and the signing key switches over at its activation time
+// without any reload. Keys are grouped by source, such as the config file or the database, so each
+// source can be reloaded without affecting the others.
+type KeyRing struct {
+	mu      sync.RWMutex
+	sources map[string][]SigningKey
+	keys    []SigningKey
+	jwks    *jose.JSONWebKeySet
+
+	now func() time.Time
+}
+
+// NewKeyRing creates a new KeyRing holding the given keys.
+func NewKeyRing(keys []SigningKey) (*KeyRing, error) {
+	ring := &KeyRing{
+		now: time.Now,
+	}
+
+	if err := ring.Set(keys); err != nil {
+		return nil, err
+	}
+
+	return ring, nil
+}
+
+// LoadKeyRing creates a new KeyRing holding the given private keys.
+func LoadKeyRing(keys []PrivateKey) (*KeyRing, error) {
+	signingKeys, err := readSigningKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeyRing(signingKeys)
+}
+
+// Load replaces the keys read from the config file with the given private keys. If any key cannot be
+// loaded, the keys in the ring are left unchanged.
+func (r *KeyRing) Load(keys []PrivateKey) error {
+	signingKeys, err := readSigningKeys(keys)
+	if err != nil {
+		return err
+	}
+
+	return r.Set(signingKeys)
+}
+
+// Set replaces the keys read from the config file with the given keys. If the keys are not valid,
+// the keys in the ring are left unchanged.
+func (r *KeyRing) Set(keys []SigningKey) error {
+	return r.SetSource(KeySourceConfig, keys)
+}
+
+// SetSource replaces the keys from the given source, keeping the keys from other sources. If the
+// resulting keys are not valid, the keys in the ring are left unchanged.
+func (r *KeyRing) SetSource(source string, keys []SigningKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sources := make(map[string][]SigningKey, len(r.sources)+1)
+
+	for name, sourceKeys := range r.sources {
+		sources[name] = sourceKeys
+	}
+
+	sources[source] = keys
+
+	// Config keys come first, so the JWKS is ordered the same way on every replica.
+	names := make([]string, 0, len(sources))
+
+	for name := range sources {
+		if name != KeySourceConfig {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	var all []SigningKey
+
+	for _, name := range append([]string{KeySourceConfig}, names...) {
+		all = append(all, sources[name]...)
+	}
+
+	if len(all) == 0 {
+		return fmt.Errorf("%w: no private keys provided", ErrInvalidKey)
+	}
+
+	keyIDs := make(map[string]struct{}, len(all))
+
+	jwks := &jose.JSONWebKeySet{}
+
+	for _, key := range all {
+		if key.Signer == nil {
+			return fmt.Errorf("%w: key has no signer", ErrInvalidKey)
+		}
+
+		keyID := key.Signer.Public().KeyID
+
+		switch key.State {
+		case KeyStatePending, KeyStateActive, KeyStateRetired:
+		default:
+			return fmt.Errorf("%w: key %q has unknown state %q", ErrInvalidKey, keyID, key.State)
+		}
+
+		if keyID != "" {
+			if _, ok := keyIDs[keyID]; ok {
+				return fmt.Errorf("%w: duplicate key ID %q", ErrInvalidKey, keyID)
+			}
+
+			keyIDs[keyID] = struct{}{}
+		}
+
+		jwks.Keys = append(jwks.Keys, verificationKey(key.Signer))
+	}
+
+	if signer(all, r.now()) == nil {
+		return fmt.Errorf("%w: no active signing key", ErrInvalidKey)
+	}
+
+	r.sources = sources
+	r.keys = all
+	r.jwks = jwks
+
+	return nil
+}
+
+// Signer returns the signer currently used to sign tokens.
+func (r *KeyRing) Signer() Signer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return signer(r.keys, r.now())
+}
+
+// JWKS returns the keys used to verify tokens signed by any key in the ring, including pending and
+// retired keys. Asymmetric keys only include their public part, while symmetric keys are included as is.
+func (r *KeyRing) JWKS() *jose.JSONWebKeySet {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.jwks
+}
+
+// ActiveJWKS returns the verification keys of the keys which may sign tokens at the current time,
+// excluding pending and retired keys.
+func (r *KeyRing) ActiveJWKS() *jose.JSONWebKeySet {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := r.now()
+
+	out := &jose.JSONWebKeySet{}
+
+	for _, key := range r.keys {
+		if key.signsAt(now) {
+			out.Keys = append(out.Keys, verificationKey(key.Signer))
+		}
+	}
+
+	return out
+}
+
+// signer returns the signer which signs tokens at the given time. This is the key which was
+// activated most recently, or the first active key if no activation times are set.
+func signer(keys []SigningKey, now time.Time) Signer {
+	var out *SigningKey
+
+	for i, key := range keys {
+		if !key.signsAt(now) {
+			continue
+		}
+
+		if out == nil || key.ActivateAt.After(out.ActivateAt) {
+			out = &keys[i]
+		}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return out.Signer
+}
+
+// readSigningKeys reads the given private keys. If no key has a state, the first key is active and
+// the others are retired, so existing configurations keep signing with the first key.
+func readSigningKeys(keys []PrivateKey) ([]SigningKey, error) {
+	legacy := true
+
+	for _, key := range keys {
+		if key.State != "" || !key.ActivateAt.IsZero() {
+			legacy = false
+
+			break
+		}
+	}
+
+	out := make([]SigningKey, 0, len(keys))
+
+	for i, key := range keys {
+		jwk, err := readPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		state := key.State
+
+		switch {
+		case legacy && i == 0:
+			state = KeyStateActive
+		case legacy:
+			state = KeyStateRetired
+		case state == "":
+			return nil, fmt.Errorf("%w: key %q has no state", ErrInvalidKey, key.KeyID)
+		}
+
+		out = append(out, SigningKey{
+			Signer:     NewKeySigner(jwk),
+			State:      state,
+			ActivateAt: key.ActivateAt,
+		})
+	}
+
+	return out, nil
+}
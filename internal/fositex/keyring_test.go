@@ -0,0 +1,320 @@
+package fositex
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/compose"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+)
+
+// TestKeyRingSigningKey checks that the signing key is chosen by key state and activation time.
+func TestKeyRingSigningKey(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	key := func(kid string, state KeyState, activateAt time.Time) SigningKey {
+		return SigningKey{
+			Signer: NewKeySigner(jose.JSONWebKey{
+				KeyID:     kid,
+				Algorithm: string(jose.ES256),
+			}),
+			State:      state,
+			ActivateAt: activateAt,
+		}
+	}
+
+	type result struct {
+		signingKeyID string
+		keyIDs       []string
+	}
+
+	runFn := func(_ context.Context, input []SigningKey) testingx.TestResult[result] {
+		ring := &KeyRing{
+			now: func() time.Time {
+				return now
+			},
+		}
+
+		if err := ring.Set(input); err != nil {
+			return testingx.TestResult[result]{
+				Err: err,
+			}
+		}
+
+		out := result{
+			signingKeyID: ring.Signer().Public().KeyID,
+		}
+
+		for _, key := range ring.JWKS().Keys {
+			out.keyIDs = append(out.keyIDs, key.KeyID)
+		}
+
+		return testingx.TestResult[result]{
+			Success: out,
+		}
+	}
+
+	testCases := []testingx.TestCase[[]SigningKey, result]{
+		{
+			Name: "PendingKeyPublished",
+			Input: []SigningKey{
+				key("pending", KeyStatePending, time.Time{}),
+				key("active", KeyStateActive, time.Time{}),
+				key("retired", KeyStateRetired, time.Time{}),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, "active", res.Success.signingKeyID)
+				assert.Equal(t, []string{"pending", "active", "retired"}, res.Success.keyIDs)
+			},
+		},
+		{
+			Name: "PendingKeyScheduled",
+			Input: []SigningKey{
+				key("active", KeyStateActive, time.Time{}),
+				key("pending", KeyStatePending, now.Add(time.Hour)),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, "active", res.Success.signingKeyID)
+			},
+		},
+		{
+			Name: "PendingKeyActivated",
+			Input: []SigningKey{
+				key("active", KeyStateActive, time.Time{}),
+				key("pending", KeyStatePending, now.Add(-time.Minute)),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, "pending", res.Success.signingKeyID)
+			},
+		},
+		{
+			Name: "LatestActivationSigns",
+			Input: []SigningKey{
+				key("older", KeyStateActive, now.Add(-time.Hour)),
+				key("newer", KeyStateActive, now.Add(-time.Minute)),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, "newer", res.Success.signingKeyID)
+			},
+		},
+		{
+			Name: "NoSigningKey",
+			Input: []SigningKey{
+				key("pending", KeyStatePending, now.Add(time.Hour)),
+				key("retired", KeyStateRetired, time.Time{}),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				assert.ErrorIs(t, res.Err, ErrInvalidKey)
+			},
+		},
+		{
+			Name: "UnknownState",
+			Input: []SigningKey{
+				key("active", KeyStateActive, time.Time{}),
+				key("unknown", "revoked", time.Time{}),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				assert.ErrorIs(t, res.Err, ErrInvalidKey)
+			},
+		},
+		{
+			Name: "DuplicateKeyID",
+			Input: []SigningKey{
+				key("active", KeyStateActive, time.Time{}),
+				key("active", KeyStateRetired, time.Time{}),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				assert.ErrorIs(t, res.Err, ErrInvalidKey)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
+
+// TestKeyRingLoad checks that keys without a state keep the first key signing, and that keys which
+// fail to load leave the ring unchanged.
+func TestKeyRingLoad(t *testing.T) {
+	t.Parallel()
+
+	firstKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	secondKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	first := PrivateKey{
+		KeyID:     "first",
+		Algorithm: jose.ES256,
+		Path:      writePKCS8Key(t, firstKey),
+	}
+
+	second := PrivateKey{
+		KeyID:     "second",
+		Algorithm: jose.ES256,
+		Path:      writePKCS8Key(t, secondKey),
+	}
+
+	ring, err := LoadKeyRing([]PrivateKey{first, second})
+	require.NoError(t, err)
+
+	assert.Equal(t, "first", ring.Signer().Public().KeyID)
+	assert.Len(t, ring.JWKS().Keys, 2)
+
+	// A key with a state requires all keys to have one.
+	second.State = KeyStateActive
+
+	err = ring.Load([]PrivateKey{first, second})
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	assert.Equal(t, "first", ring.Signer().Public().KeyID)
+
+	first.State = KeyStateRetired
+
+	err = ring.Load([]PrivateKey{first, second})
+	require.NoError(t, err)
+	assert.Equal(t, "second", ring.Signer().Public().KeyID)
+	assert.Len(t, ring.JWKS().Keys, 2)
+}
+
+// TestKeyRingRotation checks that tokens signed before the signing key is rotated remain valid while
+// the previous key is published.
+func TestKeyRingRotation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	newKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	oldJWK := jose.JSONWebKey{Key: oldKey, KeyID: "old", Algorithm: string(jose.ES256)}
+	newJWK := jose.JSONWebKey{Key: newKey, KeyID: "new", Algorithm: string(jose.ES384)}
+
+	ring := &KeyRing{
+		now: func() time.Time {
+			return now
+		},
+	}
+
+	require.NoError(t, ring.Set([]SigningKey{
+		{Signer: NewKeySigner(oldJWK), State: KeyStateActive},
+		{Signer: NewKeySigner(newJWK), State: KeyStatePending, ActivateAt: now.Add(time.Minute)},
+	}))
+
+	config := &OAuth2Config{
+		Config: &fosite.Config{
+			AccessTokenIssuer: "https://example.com/",
+			GlobalSecret:      []byte("abcd1234abcd1234abcd1234abcd1234"),
+		},
+		KeyRing: ring,
+	}
+
+	strategy := NewOAuth2JWTStrategy(config, compose.NewOAuth2HMACStrategy(config))
+
+	newRequest := func() *fosite.Request {
+		request := fosite.NewRequest()
+		request.Session = &oauth2.JWTSession{
+			JWTClaims: &jwt.JWTClaims{
+				Subject: "sub",
+			},
+			JWTHeader: &jwt.Headers{},
+			ExpiresAt: map[fosite.TokenType]time.Time{
+				fosite.AccessToken: now.Add(time.Hour),
+			},
+		}
+
+		return request
+	}
+
+	ctx := context.Background()
+
+	oldRequest := newRequest()
+
+	oldToken, _, err := strategy.GenerateAccessToken(ctx, oldRequest)
+	require.NoError(t, err)
+
+	// Advance past the activation time of the pending key.
+	now = now.Add(2 * time.Minute)
+
+	rotatedRequest := newRequest()
+
+	newToken, _, err := strategy.GenerateAccessToken(ctx, rotatedRequest)
+	require.NoError(t, err)
+
+	oldDecoded, err := strategy.Decode(ctx, oldToken)
+	require.NoError(t, err)
+	assert.Equal(t, "old", oldDecoded.Header["kid"])
+
+	newDecoded, err := strategy.Decode(ctx, newToken)
+	require.NoError(t, err)
+	assert.Equal(t, "new", newDecoded.Header["kid"])
+
+	assert.NoError(t, strategy.ValidateAccessToken(ctx, oldRequest, oldToken))
+	assert.NoError(t, strategy.ValidateAccessToken(ctx, rotatedRequest, newToken))
+
+	// Once the previous key is removed, tokens it signed are no longer valid.
+	require.NoError(t, ring.Set([]SigningKey{
+		{Signer: NewKeySigner(newJWK), State: KeyStateActive},
+	}))
+
+	assert.Error(t, strategy.ValidateAccessToken(ctx, oldRequest, oldToken))
+	assert.NoError(t, strategy.ValidateAccessToken(ctx, rotatedRequest, newToken))
+}
+
+// TestKeyRingSources checks that keys from each source are replaced independently.
+func TestKeyRingSources(t *testing.T) {
+	t.Parallel()
+
+	key := func(kid string, state KeyState) SigningKey {
+		return SigningKey{
+			Signer: NewKeySigner(jose.JSONWebKey{
+				KeyID:     kid,
+				Algorithm: string(jose.ES256),
+			}),
+			State: state,
+		}
+	}
+
+	ring, err := NewKeyRing([]SigningKey{key("config", KeyStateActive)})
+	require.NoError(t, err)
+
+	require.NoError(t, ring.SetSource("database", []SigningKey{
+		{Signer: key("database", KeyStateActive).Signer, State: KeyStateActive, ActivateAt: time.Now()},
+	}))
+
+	assert.Equal(t, "database", ring.Signer().Public().KeyID)
+	assert.Len(t, ring.JWKS().Keys, 2)
+
+	// Reloading the config keys keeps the database keys.
+	require.NoError(t, ring.Set([]SigningKey{key("reloaded", KeyStateRetired)}))
+	assert.Equal(t, "database", ring.Signer().Public().KeyID)
+	assert.Equal(t, "reloaded", ring.JWKS().Keys[0].KeyID)
+
+	// Key IDs must be unique across sources.
+	err = ring.SetSource("database", []SigningKey{key("reloaded", KeyStateActive)})
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	// Removing every signing key leaves the ring unchanged.
+	err = ring.SetSource("database", nil)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	assert.Equal(t, "database", ring.Signer().Public().KeyID)
+}
@@ -0,0 +1,278 @@
+package fositex
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/token/jwt"
+)
+
+var signatureHashes = map[jose.SignatureAlgorithm]crypto.Hash{
+	jose.HS256: crypto.SHA256,
+	jose.HS384: crypto.SHA384,
+	jose.HS512: crypto.SHA512,
+	jose.RS256: crypto.SHA256,
+	jose.RS384: crypto.SHA384,
+	jose.RS512: crypto.SHA512,
+	jose.PS256: crypto.SHA256,
+	jose.PS384: crypto.SHA384,
+	jose.PS512: crypto.SHA512,
+	jose.ES256: crypto.SHA256,
+	jose.ES384: crypto.SHA384,
+	jose.ES512: crypto.SHA512,
+}
+
+// Signer signs tokens with a key. Implementations may keep the private key outside of the process,
+// such as in a PKCS #11 module or a cloud KMS, as only the public key is needed to publish it and
+// validate the tokens it signs.
+type Signer interface {
+	// Public returns the public key of the signer, along with its key ID and algorithm.
+	Public() *jose.JSONWebKey
+	// SignPayload signs a JWS signing input with the signer's key using the given algorithm, and
+	// returns the raw signature.
+	SignPayload(ctx context.Context, payload []byte, alg jose.SignatureAlgorithm) ([]byte, error)
+}
+
+// KeySigner is a Signer for a private key held in memory.
+type KeySigner struct {
+	key    jose.JSONWebKey
+	public jose.JSONWebKey
+}
+
+// NewKeySigner creates a new KeySigner signing with the given private key.
+func NewKeySigner(key jose.JSONWebKey) *KeySigner {
+	public := key.Public()
+	if !public.Valid() {
+		// Symmetric keys have no public part, so only their metadata is exposed.
+		public = jose.JSONWebKey{
+			KeyID:     key.KeyID,
+			Algorithm: key.Algorithm,
+			Use:       key.Use,
+		}
+	}
+
+	return &KeySigner{
+		key:    key,
+		public: public,
+	}
+}
+
+// Public returns the public key of the signer. For symmetric keys, no key material is returned.
+func (s *KeySigner) Public() *jose.JSONWebKey {
+	public := s.public
+
+	return &public
+}
+
+// SignPayload signs the payload with the signer's key.
+func (s *KeySigner) SignPayload(_ context.Context, payload []byte, alg jose.SignatureAlgorithm) ([]byte, error) {
+	if string(alg) != s.key.Algorithm {
+		return nil, fmt.Errorf("%w: key %q cannot sign with algorithm %s", ErrInvalidKey, s.key.KeyID, alg)
+	}
+
+	if key, ok := s.key.Key.(ed25519.PrivateKey); ok {
+		return ed25519.Sign(key, payload), nil
+	}
+
+	hash, ok := signatureHashes[alg]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported algorithm %s", ErrInvalidKey, alg)
+	}
+
+	if key, ok := s.key.Key.([]byte); ok {
+		mac := hmac.New(hash.New, key)
+		mac.Write(payload)
+
+		return mac.Sum(nil), nil
+	}
+
+	digest := hash.New()
+	digest.Write(payload)
+
+	hashed := digest.Sum(nil)
+
+	switch key := s.key.Key.(type) {
+	case *rsa.PrivateKey:
+		switch alg {
+		case jose.PS256, jose.PS384, jose.PS512:
+			return rsa.SignPSS(rand.Reader, key, hash, hashed, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		default:
+			return rsa.SignPKCS1v15(rand.Reader, key, hash, hashed)
+		}
+	case *ecdsa.PrivateKey:
+		r, sig, err := ecdsa.Sign(rand.Reader, key, hashed)
+		if err != nil {
+			return nil, err
+		}
+
+		// JWS ECDSA signatures are the fixed size concatenation of r and s.
+		size := (key.Curve.Params().BitSize + 7) / 8
+		out := make([]byte, 2*size)
+
+		r.FillBytes(out[:size])
+		sig.FillBytes(out[size:])
+
+		return out, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported key type %T", ErrInvalidKey, key)
+	}
+}
+
+// verificationKey returns the key used to verify tokens signed by the signer. Symmetric keys have
+// no public part, and verify tokens themselves.
+func verificationKey(signer Signer) jose.JSONWebKey {
+	if s, ok := signer.(*KeySigner); ok {
+		if _, symmetric := s.key.Key.([]byte); symmetric {
+			return s.key
+		}
+	}
+
+	return *signer.Public()
+}
+
+// opaqueSigner adapts a Signer to a jose.OpaqueSigner, which does not take a context.
+type opaqueSigner struct {
+	ctx    context.Context
+	signer Signer
+}
+
+func (s opaqueSigner) Public() *jose.JSONWebKey {
+	return s.signer.Public()
+}
+
+func (s opaqueSigner) Algs() []jose.SignatureAlgorithm {
+	return []jose.SignatureAlgorithm{jose.SignatureAlgorithm(s.signer.Public().Algorithm)}
+}
+
+func (s opaqueSigner) SignPayload(payload []byte, alg jose.SignatureAlgorithm) ([]byte, error) {
+	return s.signer.SignPayload(s.ctx, payload, alg)
+}
+
+// GetSignerFunc returns the signer tokens are signed with.
+type GetSignerFunc func(ctx context.Context) (Signer, error)
+
+// GetVerificationKeysFunc returns the keys tokens may have been signed with.
+type GetVerificationKeysFunc func(ctx context.Context) (*jose.JSONWebKeySet, error)
+
+// JWKSigner is a jwt.Signer which signs tokens with a Signer. Unlike jwt.DefaultSigner, which only
+// validates tokens signed with RSA and ECDSA keys, it validates tokens signed with any key supported
+// by go-jose, including Ed25519 keys. Tokens are validated with the key matching their key ID, so
+// tokens signed before the signing key was rotated remain valid.
+type JWKSigner struct {
+	*jwt.DefaultSigner
+	GetSigner           GetSignerFunc
+	GetVerificationKeys GetVerificationKeysFunc
+}
+
+// NewJWKSigner creates a new JWKSigner signing tokens with the signer returned by signerGetter and
+// validating them with the keys returned by keysGetter.
+func NewJWKSigner(signerGetter GetSignerFunc, keysGetter GetVerificationKeysFunc) *JWKSigner {
+	return &JWKSigner{
+		DefaultSigner:       &jwt.DefaultSigner{},
+		GetSigner:           signerGetter,
+		GetVerificationKeys: keysGetter,
+	}
+}
+
+// Generate generates a new token, setting its key ID header to the ID of the key it is signed with.
+func (s *JWKSigner) Generate(ctx context.Context, claims jwt.MapClaims, header jwt.Mapper) (string, string, error) {
+	signer, err := s.GetSigner(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	public := signer.Public()
+
+	// The signing key may change between calls, so the key ID and signature must come from the same key.
+	if public.KeyID != "" {
+		header.Add("kid", public.KeyID)
+	}
+
+	token := jwt.NewWithClaims(jose.SignatureAlgorithm(public.Algorithm), claims)
+
+	for key, value := range header.ToMap() {
+		token.Header[key] = value
+	}
+
+	rawToken, err := token.SignedString(opaqueSigner{ctx: ctx, signer: signer})
+	if err != nil {
+		return "", "", err
+	}
+
+	signature, err := s.GetSignature(ctx, rawToken)
+	if err != nil {
+		return "", "", err
+	}
+
+	return rawToken, signature, nil
+}
+
+// Validate validates a token and returns its signature or an error if the token is not valid.
+func (s *JWKSigner) Validate(ctx context.Context, token string) (string, error) {
+	if _, err := s.Decode(ctx, token); err != nil {
+		return "", err
+	}
+
+	return s.GetSignature(ctx, token)
+}
+
+// Decode decodes a token, verifying its signature with the key it was signed with. Tokens without
+// a key ID are verified with the current signing key.
+func (s *JWKSigner) Decode(ctx context.Context, token string) (*jwt.Token, error) {
+	signer, err := s.GetSigner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys *jose.JSONWebKeySet
+
+	if s.GetVerificationKeys != nil {
+		keys, err = s.GetVerificationKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return jwt.ParseWithClaims(token, jwt.MapClaims{}, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" || keys == nil {
+			key := verificationKey(signer)
+
+			return &key, nil
+		}
+
+		matches := keys.Key(kid)
+		if len(matches) == 0 {
+			return nil, fosite.ErrTokenSignatureMismatch.WithHintf("Token was signed with unknown key %q.", kid)
+		}
+
+		return &matches[0], nil
+	})
+}
+
+// NewOAuth2JWTStrategy creates a JWT access token strategy which signs tokens with the config's
+// signer, and validates them with the config's signing JWKS.
+func NewOAuth2JWTStrategy(config OAuth2Configurator, strategy oauth2.CoreStrategy) *oauth2.DefaultJWTStrategy {
+	signerGetter := func(ctx context.Context) (Signer, error) {
+		return config.GetSigner(ctx), nil
+	}
+
+	keysGetter := func(ctx context.Context) (*jose.JSONWebKeySet, error) {
+		return config.GetSigningJWKS(ctx), nil
+	}
+
+	return &oauth2.DefaultJWTStrategy{
+		Signer:          NewJWKSigner(signerGetter, keysGetter),
+		HMACSHAStrategy: strategy,
+		Config:          config,
+	}
+}
@@ -0,0 +1,288 @@
+package fositex
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/compose"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+)
+
+var _ Signer = &KeySigner{}
+
+type signRequest struct {
+	Payload   []byte                  `json:"payload"`
+	Algorithm jose.SignatureAlgorithm `json:"alg"`
+}
+
+type signResponse struct {
+	Signature []byte `json:"signature"`
+}
+
+// socketSigner is a Signer which signs payloads with a key held by another process listening on a
+// unix socket, standing in for an HSM or KMS.
+type socketSigner struct {
+	public jose.JSONWebKey
+	client *http.Client
+}
+
+func (s *socketSigner) Public() *jose.JSONWebKey {
+	public := s.public
+
+	return &public
+}
+
+func (s *socketSigner) SignPayload(ctx context.Context, payload []byte, alg jose.SignatureAlgorithm) ([]byte, error) {
+	body, err := json.Marshal(signRequest{Payload: payload, Algorithm: alg})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://signer/sign", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck // Not needed to check returned error.
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: signer returned %s", ErrInvalidKey, resp.Status)
+	}
+
+	var out signResponse
+
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+
+	return out.Signature, nil
+}
+
+// newSocketSigner starts a signing server holding the given key on a unix socket, and returns a
+// Signer which only holds the public key.
+func newSocketSigner(t *testing.T, key jose.JSONWebKey) *socketSigner {
+	t.Helper()
+
+	socket := filepath.Join(t.TempDir(), "signer.sock")
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	keySigner := NewKeySigner(key)
+
+	srv := &http.Server{
+		ReadHeaderTimeout: time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req signRequest
+
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			sig, err := keySigner.SignPayload(r.Context(), req.Payload, req.Algorithm)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+
+				return
+			}
+
+			_ = json.NewEncoder(w).Encode(signResponse{Signature: sig})
+		}),
+	}
+
+	go srv.Serve(listener) //nolint:errcheck // Serve returns when the server is closed.
+
+	t.Cleanup(func() {
+		_ = srv.Close()
+	})
+
+	return &socketSigner{
+		public: key.Public(),
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+
+					return d.DialContext(ctx, "unix", socket)
+				},
+			},
+		},
+	}
+}
+
+// TestJWKSigner checks that tokens signed by each kind of Signer can be validated, and that only
+// public keys are published for asymmetric keys.
+func TestJWKSigner(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	p521Key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	require.NoError(t, err)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwk := func(key any, alg jose.SignatureAlgorithm) jose.JSONWebKey {
+		return jose.JSONWebKey{
+			Key:       key,
+			KeyID:     string(alg),
+			Algorithm: string(alg),
+		}
+	}
+
+	type result struct {
+		kid       string
+		published jose.JSONWebKey
+	}
+
+	runFn := func(ctx context.Context, input Signer) testingx.TestResult[result] {
+		keyRing, err := NewKeyRing([]SigningKey{{Signer: input, State: KeyStateActive}})
+		if err != nil {
+			return testingx.TestResult[result]{
+				Err: err,
+			}
+		}
+
+		config := &OAuth2Config{
+			Config: &fosite.Config{
+				AccessTokenIssuer: "https://example.com/",
+				GlobalSecret:      []byte("abcd1234abcd1234abcd1234abcd1234"),
+			},
+			KeyRing: keyRing,
+		}
+
+		strategy := NewOAuth2JWTStrategy(config, compose.NewOAuth2HMACStrategy(config))
+
+		request := fosite.NewRequest()
+		request.Session = &oauth2.JWTSession{
+			JWTClaims: &jwt.JWTClaims{
+				Subject: "sub",
+			},
+			JWTHeader: &jwt.Headers{},
+			ExpiresAt: map[fosite.TokenType]time.Time{
+				fosite.AccessToken: time.Now().Add(time.Hour),
+			},
+		}
+
+		token, _, err := strategy.GenerateAccessToken(ctx, request)
+		if err != nil {
+			return testingx.TestResult[result]{
+				Err: err,
+			}
+		}
+
+		if err := strategy.ValidateAccessToken(ctx, request, token); err != nil {
+			return testingx.TestResult[result]{
+				Err: err,
+			}
+		}
+
+		decoded, err := strategy.Decode(ctx, token)
+		if err != nil {
+			return testingx.TestResult[result]{
+				Err: err,
+			}
+		}
+
+		kid, _ := decoded.Header["kid"].(string)
+
+		return testingx.TestResult[result]{
+			Success: result{
+				kid:       kid,
+				published: keyRing.JWKS().Keys[0],
+			},
+		}
+	}
+
+	checkPublic := func(kid string) func(context.Context, *testing.T, testingx.TestResult[result]) {
+		return func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+			require.NoError(t, res.Err)
+			assert.Equal(t, kid, res.Success.kid)
+			assert.True(t, res.Success.published.IsPublic())
+		}
+	}
+
+	testCases := []testingx.TestCase[Signer, result]{
+		{
+			Name:  "HS256",
+			Input: NewKeySigner(jwk([]byte("abcd1234abcd1234abcd1234abcd1234"), jose.HS256)),
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, "HS256", res.Success.kid)
+			},
+		},
+		{
+			Name:    "RS256",
+			Input:   NewKeySigner(jwk(rsaKey, jose.RS256)),
+			CheckFn: checkPublic("RS256"),
+		},
+		{
+			Name:    "PS256",
+			Input:   NewKeySigner(jwk(rsaKey, jose.PS256)),
+			CheckFn: checkPublic("PS256"),
+		},
+		{
+			Name:    "ES256",
+			Input:   NewKeySigner(jwk(p256Key, jose.ES256)),
+			CheckFn: checkPublic("ES256"),
+		},
+		{
+			Name:    "ES512",
+			Input:   NewKeySigner(jwk(p521Key, jose.ES512)),
+			CheckFn: checkPublic("ES512"),
+		},
+		{
+			Name:    "EdDSA",
+			Input:   NewKeySigner(jwk(ed25519Key, jose.EdDSA)),
+			CheckFn: checkPublic("EdDSA"),
+		},
+		{
+			Name:    "SocketRS256",
+			Input:   newSocketSigner(t, jwk(rsaKey, jose.RS256)),
+			CheckFn: checkPublic("RS256"),
+		},
+		{
+			Name:    "SocketES256",
+			Input:   newSocketSigner(t, jwk(p256Key, jose.ES256)),
+			CheckFn: checkPublic("ES256"),
+		},
+		{
+			Name:    "SocketEdDSA",
+			Input:   newSocketSigner(t, jwk(ed25519Key, jose.EdDSA)),
+			CheckFn: checkPublic("EdDSA"),
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
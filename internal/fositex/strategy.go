@@ -0,0 +1,72 @@
+package fositex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/google/uuid"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/token/jwt"
+)
+
+// ErrMissingJWTClaims is returned when an access token is requested for a session without JWT claims.
+var ErrMissingJWTClaims = fmt.Errorf("session has no jwt claims")
+
+// JTIStrategy wraps a JWT token strategy, using the jti claim of access tokens as their signature. This
+// allows issued access tokens to be stored and looked up by their jti.
+type JTIStrategy struct {
+	oauth2.CoreStrategy
+}
+
+// NewJTIStrategy creates a new JTIStrategy wrapping the given strategy.
+func NewJTIStrategy(strategy oauth2.CoreStrategy) *JTIStrategy {
+	return &JTIStrategy{
+		CoreStrategy: strategy,
+	}
+}
+
+// GenerateAccessToken generates an access token for the given request, returning its jti as the signature.
+// If the session claims have no jti, a random one is assigned.
+func (s *JTIStrategy) GenerateAccessToken(ctx context.Context, requester fosite.Requester) (string, string, error) {
+	session, ok := requester.GetSession().(oauth2.JWTSessionContainer)
+	if !ok {
+		return "", "", ErrMissingJWTClaims
+	}
+
+	claims, ok := session.GetJWTClaims().(*jwt.JWTClaims)
+	if !ok || claims == nil {
+		return "", "", ErrMissingJWTClaims
+	}
+
+	if claims.JTI == "" {
+		claims.JTI = uuid.NewString()
+	}
+
+	token, _, err := s.CoreStrategy.GenerateAccessToken(ctx, requester)
+	if err != nil {
+		return "", "", err
+	}
+
+	return token, claims.JTI, nil
+}
+
+// AccessTokenSignature returns the jti of the given access token. The token signature is not verified.
+func (s *JTIStrategy) AccessTokenSignature(_ context.Context, token string) string {
+	parsed, err := jose.ParseSigned(token)
+	if err != nil {
+		return ""
+	}
+
+	var claims struct {
+		JTI string `json:"jti"`
+	}
+
+	if err := json.Unmarshal(parsed.UnsafePayloadWithoutVerification(), &claims); err != nil {
+		return ""
+	}
+
+	return claims.JTI
+}
@@ -0,0 +1,94 @@
+package fositex
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/compose"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+)
+
+// TestJTIStrategy checks that the jti of generated access tokens is used as their signature.
+func TestJTIStrategy(t *testing.T) {
+	t.Parallel()
+
+	config := &fosite.Config{
+		AccessTokenIssuer: "https://example.com/",
+		GlobalSecret:      []byte("abcd1234abcd1234abcd1234abcd1234"),
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	keyGetter := func(_ context.Context) (any, error) {
+		return key, nil
+	}
+
+	strategy := NewJTIStrategy(compose.NewOAuth2JWTStrategy(keyGetter, compose.NewOAuth2HMACStrategy(config), config))
+
+	type result struct {
+		signature string
+		parsed    string
+	}
+
+	runFn := func(ctx context.Context, jti string) testingx.TestResult[result] {
+		request := fosite.NewRequest()
+		request.Session = &oauth2.JWTSession{
+			JWTClaims: &jwt.JWTClaims{
+				JTI:     jti,
+				Subject: "sub",
+			},
+			JWTHeader: &jwt.Headers{},
+			ExpiresAt: map[fosite.TokenType]time.Time{
+				fosite.AccessToken: time.Now().Add(time.Hour),
+			},
+		}
+
+		token, signature, err := strategy.GenerateAccessToken(ctx, request)
+		if err != nil {
+			return testingx.TestResult[result]{
+				Err: err,
+			}
+		}
+
+		return testingx.TestResult[result]{
+			Success: result{
+				signature: signature,
+				parsed:    strategy.AccessTokenSignature(ctx, token),
+			},
+		}
+	}
+
+	testCases := []testingx.TestCase[string, result]{
+		{
+			Name:  "ExplicitJTI",
+			Input: "my-jti",
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, "my-jti", res.Success.signature)
+				assert.Equal(t, "my-jti", res.Success.parsed)
+			},
+		},
+		{
+			Name:  "GeneratedJTI",
+			Input: "",
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.NotEmpty(t, res.Success.signature)
+				assert.Equal(t, res.Success.signature, res.Success.parsed)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
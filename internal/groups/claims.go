@@ -0,0 +1,68 @@
+package groups
+
+import (
+	"context"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// DefaultMaxGroups is the maximum number of groups included in the claim if none is configured.
+const DefaultMaxGroups = 50
+
+// ClaimStrategy builds the groups claim from the group memberships of the token subject.
+type ClaimStrategy struct {
+	groupSvc        types.GroupService
+	maxGroups       int
+	restrictToOwner bool
+}
+
+// ClaimStrategy implements fositex.GroupsClaimStrategy
+var _ fositex.GroupsClaimStrategy = (*ClaimStrategy)(nil)
+
+// NewClaimStrategy creates a ClaimStrategy given a group service and groups claim configuration.
+func NewClaimStrategy(groupSvc types.GroupService, config fositex.GroupsClaimConfig) ClaimStrategy {
+	maxGroups := config.MaxGroups
+	if maxGroups <= 0 {
+		maxGroups = DefaultMaxGroups
+	}
+
+	return ClaimStrategy{
+		groupSvc:        groupSvc,
+		maxGroups:       maxGroups,
+		restrictToOwner: config.RestrictToOwner,
+	}
+}
+
+// GetGroupsClaim returns the IDs of the groups the subject is a member of, ordered by ID and limited to
+// the configured maximum. If the strategy is restricted to owners, only groups owned by ownerID are included.
+func (s ClaimStrategy) GetGroupsClaim(ctx context.Context, subject, ownerID gidx.PrefixedID) ([]string, error) {
+	return s.listGroups(ctx, subject, ownerID, s.restrictToOwner)
+}
+
+// GetOwnerGroupsClaim returns the IDs of the groups owned by ownerID the subject is a member of, ordered
+// by ID and limited to the configured maximum.
+func (s ClaimStrategy) GetOwnerGroupsClaim(ctx context.Context, subject, ownerID gidx.PrefixedID) ([]string, error) {
+	return s.listGroups(ctx, subject, ownerID, true)
+}
+
+func (s ClaimStrategy) listGroups(ctx context.Context, subject, ownerID gidx.PrefixedID, restrictToOwner bool) ([]string, error) {
+	out := []string{}
+
+	err := forEachGroup(ctx, s.groupSvc, subject, func(group *types.Group) bool {
+		if restrictToOwner && group.OwnerID != ownerID {
+			return true
+		}
+
+		out = append(out, group.ID.String())
+
+		return len(out) < s.maxGroups
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
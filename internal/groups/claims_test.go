@@ -0,0 +1,125 @@
+package groups
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/crdbx"
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// fakeGroupService serves ListGroupsBySubject from a fixed list of groups ordered by ID.
+type fakeGroupService struct {
+	types.GroupService
+	groups types.Groups
+}
+
+func (f fakeGroupService) ListGroupsBySubject(_ context.Context, _ gidx.PrefixedID, pagination crdbx.Paginator) (types.Groups, error) {
+	var after string
+
+	values, err := pagination.GetCursor().Values()
+	if err != nil {
+		return nil, err
+	}
+
+	if values != nil {
+		after = values.Get("id")
+	}
+
+	out := types.Groups{}
+
+	for _, group := range f.groups {
+		if group.ID.String() <= after {
+			continue
+		}
+
+		out = append(out, group)
+
+		if len(out) == pagination.GetLimit() {
+			break
+		}
+	}
+
+	return out, nil
+}
+
+// TestGetGroupsClaim checks that the groups claim is filtered and truncated deterministically.
+func TestGetGroupsClaim(t *testing.T) {
+	t.Parallel()
+
+	ownerA := gidx.PrefixedID("testten-a")
+	ownerB := gidx.PrefixedID("testten-b")
+	subject := gidx.PrefixedID("idntusr-subject")
+
+	var groups types.Groups
+
+	for i := 0; i < 250; i++ {
+		owner := ownerA
+		if i%2 == 1 {
+			owner = ownerB
+		}
+
+		groups = append(groups, &types.Group{
+			ID:      gidx.PrefixedID(fmt.Sprintf("idntgrp-%04d", i)),
+			OwnerID: owner,
+		})
+	}
+
+	svc := fakeGroupService{
+		groups: groups,
+	}
+
+	runFn := func(ctx context.Context, config fositex.GroupsClaimConfig) testingx.TestResult[[]string] {
+		out, err := NewClaimStrategy(svc, config).GetGroupsClaim(ctx, subject, ownerA)
+
+		return testingx.TestResult[[]string]{
+			Success: out,
+			Err:     err,
+		}
+	}
+
+	testCases := []testingx.TestCase[fositex.GroupsClaimConfig, []string]{
+		{
+			Name:  "DefaultCap",
+			Input: fositex.GroupsClaimConfig{},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				assert.NoError(t, result.Err)
+				assert.Len(t, result.Success, DefaultMaxGroups)
+				assert.Equal(t, "idntgrp-0000", result.Success[0])
+				assert.Equal(t, "idntgrp-0049", result.Success[DefaultMaxGroups-1])
+			},
+		},
+		{
+			Name: "AllGroups",
+			Input: fositex.GroupsClaimConfig{
+				MaxGroups: 1000,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				assert.NoError(t, result.Err)
+				assert.Len(t, result.Success, 250)
+			},
+		},
+		{
+			Name: "RestrictToOwner",
+			Input: fositex.GroupsClaimConfig{
+				MaxGroups:       110,
+				RestrictToOwner: true,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				assert.NoError(t, result.Err)
+				assert.Len(t, result.Success, 110)
+				assert.Equal(t, "idntgrp-0000", result.Success[0])
+				assert.Equal(t, "idntgrp-0002", result.Success[1])
+				assert.Equal(t, "idntgrp-0218", result.Success[109])
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
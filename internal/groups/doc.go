@@ -0,0 +1,3 @@
+// Package groups provides a fositex.GroupsClaimStrategy and fositex.OwnerAccessStrategy backed by group
+// memberships in storage, and the owner scoping and groups claim shared by the token grant handlers.
+package groups
@@ -0,0 +1,47 @@
+package groups
+
+import (
+	"context"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/crdbx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+const pageSize = 100
+
+// forEachGroup calls fn for each group the subject is a member of, ordered by group ID, until fn
+// returns false or there are no more groups. Memberships decide what tokens may contain, so they are
+// read as of now rather than from a possibly stale follower read.
+func forEachGroup(ctx context.Context, groupSvc types.GroupService, subject gidx.PrefixedID, fn func(*types.Group) bool) error {
+	ctx = crdbx.AsOfSystemTime(ctx, "")
+
+	pagination := crdbx.Pagination{
+		Limit: pageSize,
+	}
+
+	for {
+		groups, err := groupSvc.ListGroupsBySubject(ctx, subject, pagination)
+		if err != nil {
+			return err
+		}
+
+		for _, group := range groups {
+			if !fn(group) {
+				return nil
+			}
+		}
+
+		if len(groups) < pageSize {
+			return nil
+		}
+
+		cursor, err := crdbx.NewCursor("id", groups[len(groups)-1].ID.String())
+		if err != nil {
+			return err
+		}
+
+		pagination.Cursor = cursor
+	}
+}
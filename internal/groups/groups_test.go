@@ -0,0 +1,149 @@
+package groups
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	xcrdbx "go.infratographer.com/x/crdbx"
+
+	"go.infratographer.com/identity-api/internal/crdbx"
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/storage"
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// asOfGroupService records the AS OF SYSTEM TIME value memberships are read with.
+type asOfGroupService struct {
+	fakeGroupService
+	asOf *any
+}
+
+func (f asOfGroupService) ListGroupsBySubject(ctx context.Context, subject gidx.PrefixedID, pagination crdbx.Paginator) (types.Groups, error) {
+	*f.asOf = crdbx.ContextAsOfSystemTime(ctx, "-1m")
+
+	return f.fakeGroupService.ListGroupsBySubject(ctx, subject, pagination)
+}
+
+// TestForEachGroupCurrent checks that memberships are not read from the past.
+func TestForEachGroupCurrent(t *testing.T) {
+	t.Parallel()
+
+	var asOf any
+
+	svc := asOfGroupService{
+		asOf: &asOf,
+	}
+
+	err := forEachGroup(context.Background(), svc, "idntusr-subject", func(*types.Group) bool { return true })
+	require.NoError(t, err)
+
+	assert.Equal(t, "", asOf)
+}
+
+// TestRemovedMembership checks that a removed membership no longer grants owner access or appears in the
+// groups claim, without waiting for follower reads to catch up.
+func TestRemovedMembership(t *testing.T) {
+	t.Parallel()
+
+	testServer, err := storage.InMemoryCRDB()
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "initialization failed")
+	}
+
+	err = testServer.Start()
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "initialization failed")
+	}
+
+	t.Cleanup(func() {
+		testServer.Stop()
+	})
+
+	config := xcrdbx.Config{
+		URI: testServer.PGURL().String(),
+	}
+
+	storageEngine, err := storage.NewEngine(config, storage.WithMigrations())
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "initialization failed")
+	}
+
+	homeOwner := gidx.MustNewID("testten")
+	groupOwner := gidx.MustNewID("testten")
+	subject := gidx.MustNewID(types.IdentityUserIDPrefix)
+
+	withTx := func(fn func(ctx context.Context) error) {
+		ctx, err := storageEngine.BeginContext(context.Background())
+		require.NoError(t, err)
+
+		require.NoError(t, fn(ctx))
+		require.NoError(t, storageEngine.CommitContext(ctx))
+	}
+
+	var group *types.Group
+
+	withTx(func(ctx context.Context) error {
+		group, err = storageEngine.CreateGroup(ctx, types.Group{
+			ID:      gidx.MustNewID(types.IdentityGroupIDPrefix),
+			OwnerID: groupOwner,
+			Name:    "members",
+		})
+		if err != nil {
+			return err
+		}
+
+		return storageEngine.AddGroupMembers(ctx, group.ID, subject)
+	})
+
+	ownerStrategy := NewOwnerAccessStrategy(storageEngine)
+	claimStrategy := NewClaimStrategy(storageEngine, fositex.GroupsClaimConfig{})
+
+	allowed, err := ownerStrategy.CheckOwnerAccess(context.Background(), subject, homeOwner, groupOwner)
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	withTx(func(ctx context.Context) error {
+		return storageEngine.RemoveGroupMember(ctx, group.ID, subject)
+	})
+
+	type result struct {
+		allowed bool
+		groups  []string
+	}
+
+	runFn := func(ctx context.Context, _ gidx.PrefixedID) testingx.TestResult[result] {
+		allowed, err := ownerStrategy.CheckOwnerAccess(ctx, subject, homeOwner, groupOwner)
+		if err != nil {
+			return testingx.TestResult[result]{Err: err}
+		}
+
+		groups, err := claimStrategy.GetOwnerGroupsClaim(ctx, subject, groupOwner)
+
+		return testingx.TestResult[result]{
+			Success: result{
+				allowed: allowed,
+				groups:  groups,
+			},
+			Err: err,
+		}
+	}
+
+	testCases := []testingx.TestCase[gidx.PrefixedID, result]{
+		{
+			Name:  "Removed",
+			Input: subject,
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.False(t, res.Success.allowed)
+				assert.Empty(t, res.Success.groups)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
@@ -0,0 +1,47 @@
+package groups
+
+import (
+	"context"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// OwnerAccessStrategy decides whether a subject may obtain tokens scoped to an owner based on
+// the subject's group memberships.
+type OwnerAccessStrategy struct {
+	groupSvc types.GroupService
+}
+
+// OwnerAccessStrategy implements fositex.OwnerAccessStrategy
+var _ fositex.OwnerAccessStrategy = (*OwnerAccessStrategy)(nil)
+
+// NewOwnerAccessStrategy creates an OwnerAccessStrategy given a group service.
+func NewOwnerAccessStrategy(groupSvc types.GroupService) OwnerAccessStrategy {
+	return OwnerAccessStrategy{
+		groupSvc: groupSvc,
+	}
+}
+
+// CheckOwnerAccess returns true if ownerID is the home owner of the subject, i.e. the owner of the
+// issuer or client the token is obtained through, or if the subject is a member of a group owned by ownerID.
+func (s OwnerAccessStrategy) CheckOwnerAccess(ctx context.Context, subject, homeOwnerID, ownerID gidx.PrefixedID) (bool, error) {
+	if ownerID == homeOwnerID {
+		return true, nil
+	}
+
+	var found bool
+
+	err := forEachGroup(ctx, s.groupSvc, subject, func(group *types.Group) bool {
+		found = group.OwnerID == ownerID
+
+		return !found
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
@@ -0,0 +1,69 @@
+package groups
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// TestCheckOwnerAccess checks that owner access is granted for the home owner and owners of the subject's groups.
+func TestCheckOwnerAccess(t *testing.T) {
+	t.Parallel()
+
+	homeOwner := gidx.PrefixedID("testten-home")
+	subject := gidx.PrefixedID("idntusr-subject")
+
+	svc := fakeGroupService{
+		groups: types.Groups{
+			{
+				ID:      "idntgrp-a",
+				OwnerID: "testten-member",
+			},
+		},
+	}
+
+	strategy := NewOwnerAccessStrategy(svc)
+
+	runFn := func(ctx context.Context, ownerID gidx.PrefixedID) testingx.TestResult[bool] {
+		out, err := strategy.CheckOwnerAccess(ctx, subject, homeOwner, ownerID)
+
+		return testingx.TestResult[bool]{
+			Success: out,
+			Err:     err,
+		}
+	}
+
+	testCases := []testingx.TestCase[gidx.PrefixedID, bool]{
+		{
+			Name:  "HomeOwner",
+			Input: homeOwner,
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[bool]) {
+				assert.NoError(t, result.Err)
+				assert.True(t, result.Success)
+			},
+		},
+		{
+			Name:  "GroupOwner",
+			Input: "testten-member",
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[bool]) {
+				assert.NoError(t, result.Err)
+				assert.True(t, result.Success)
+			},
+		},
+		{
+			Name:  "OtherOwner",
+			Input: "testten-other",
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[bool]) {
+				assert.NoError(t, result.Err)
+				assert.False(t, result.Success)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
@@ -0,0 +1,92 @@
+package groups
+
+import (
+	"context"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/ory/x/errorsx"
+	"go.infratographer.com/x/gidx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+const instrumentationName = "go.infratographer.com/identity-api/internal/groups"
+
+// RequestedOwner returns the owner a token was requested to be scoped to with the owner_id parameter,
+// if any, after checking that the subject may obtain tokens for that owner. Without an owner access
+// strategy, only the home owner of the subject may be requested.
+func RequestedOwner(ctx context.Context, strategy fositex.OwnerAccessStrategy, requested string, subject, homeOwnerID gidx.PrefixedID) (gidx.PrefixedID, error) {
+	if len(requested) == 0 {
+		return "", nil
+	}
+
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, "RequestedOwner")
+
+	defer span.End()
+
+	ownerID, err := gidx.Parse(requested)
+	if err != nil {
+		return "", errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Invalid parameter '%s': %s", fositex.ParamOwnerID, err))
+	}
+
+	span.SetAttributes(attribute.Stringer("owner_id", ownerID))
+
+	allowed := ownerID == homeOwnerID
+
+	if strategy != nil {
+		allowed, err = strategy.CheckOwnerAccess(ctx, subject, homeOwnerID, ownerID)
+		if err != nil {
+			return "", errorsx.WithStack(fosite.ErrServerError.WithHintf("unable to check owner access: %s", err))
+		}
+	}
+
+	if !allowed {
+		cause := types.ErrorInvalidTokenRequest{
+			Subject: map[string]string{
+				"subject":  subject.String(),
+				"owner_id": ownerID.String(),
+			},
+		}
+
+		return "", errorsx.WithStack(fosite.ErrAccessDenied.WithHint("The subject is not permitted to obtain tokens for the requested owner.").WithWrap(cause))
+	}
+
+	return ownerID, nil
+}
+
+// AddClaim adds the groups claim to the given claims, if a groups claim strategy is set. If the token
+// is scoped to an owner, only groups owned by that owner are included.
+func AddClaim(ctx context.Context, strategy fositex.GroupsClaimStrategy, claims *jwt.JWTClaims, subject, homeOwnerID, ownerID gidx.PrefixedID) error {
+	if strategy == nil {
+		return nil
+	}
+
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, "AddClaim")
+
+	defer span.End()
+
+	var (
+		groups []string
+		err    error
+	)
+
+	if ownerID != "" {
+		groups, err = strategy.GetOwnerGroupsClaim(ctx, subject, ownerID)
+	} else {
+		groups, err = strategy.GetGroupsClaim(ctx, subject, homeOwnerID)
+	}
+
+	if err != nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithHintf("unable to look up groups: %s", err))
+	}
+
+	claims.Add(fositex.ClaimGroups, groups)
+
+	span.SetAttributes(attribute.Int("jwt_claims.groups.count", len(groups)))
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package groups
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// TestRequestedOwner checks that tokens may only be scoped to owners the subject has access to.
+func TestRequestedOwner(t *testing.T) {
+	t.Parallel()
+
+	homeOwner := gidx.PrefixedID("testten-home")
+	subject := gidx.PrefixedID("idntusr-subject")
+
+	strategy := NewOwnerAccessStrategy(fakeGroupService{
+		groups: types.Groups{
+			{
+				ID:      "idntgrp-a",
+				OwnerID: "testten-member",
+			},
+		},
+	})
+
+	type input struct {
+		strategy  fositex.OwnerAccessStrategy
+		requested string
+	}
+
+	runFn := func(ctx context.Context, in input) testingx.TestResult[gidx.PrefixedID] {
+		out, err := RequestedOwner(ctx, in.strategy, in.requested, subject, homeOwner)
+
+		return testingx.TestResult[gidx.PrefixedID]{
+			Success: out,
+			Err:     err,
+		}
+	}
+
+	checkOwner := func(expected gidx.PrefixedID) func(context.Context, *testing.T, testingx.TestResult[gidx.PrefixedID]) {
+		return func(_ context.Context, t *testing.T, result testingx.TestResult[gidx.PrefixedID]) {
+			require.NoError(t, result.Err)
+			assert.Equal(t, expected, result.Success)
+		}
+	}
+
+	checkErr := func(expected error) func(context.Context, *testing.T, testingx.TestResult[gidx.PrefixedID]) {
+		return func(_ context.Context, t *testing.T, result testingx.TestResult[gidx.PrefixedID]) {
+			assert.ErrorIs(t, result.Err, expected)
+		}
+	}
+
+	testCases := []testingx.TestCase[input, gidx.PrefixedID]{
+		{
+			Name:    "NotRequested",
+			Input:   input{strategy: strategy},
+			CheckFn: checkOwner(""),
+		},
+		{
+			Name:    "GroupOwner",
+			Input:   input{strategy: strategy, requested: "testten-member"},
+			CheckFn: checkOwner("testten-member"),
+		},
+		{
+			Name:    "HomeOwnerWithoutStrategy",
+			Input:   input{requested: homeOwner.String()},
+			CheckFn: checkOwner(homeOwner),
+		},
+		{
+			Name:    "GroupOwnerWithoutStrategy",
+			Input:   input{requested: "testten-member"},
+			CheckFn: checkErr(fosite.ErrAccessDenied),
+		},
+		{
+			Name:    "OtherOwner",
+			Input:   input{strategy: strategy, requested: "testten-other"},
+			CheckFn: checkErr(fosite.ErrAccessDenied),
+		},
+		{
+			Name:    "Invalid",
+			Input:   input{strategy: strategy, requested: "not an id"},
+			CheckFn: checkErr(fosite.ErrInvalidRequest),
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
+
+// TestAddClaim checks that the groups claim is limited to the requested owner's groups.
+func TestAddClaim(t *testing.T) {
+	t.Parallel()
+
+	homeOwner := gidx.PrefixedID("testten-home")
+	subject := gidx.PrefixedID("idntusr-subject")
+
+	strategy := NewClaimStrategy(fakeGroupService{
+		groups: types.Groups{
+			{
+				ID:      "idntgrp-a",
+				OwnerID: homeOwner,
+			},
+			{
+				ID:      "idntgrp-b",
+				OwnerID: "testten-member",
+			},
+		},
+	}, fositex.GroupsClaimConfig{})
+
+	type input struct {
+		strategy fositex.GroupsClaimStrategy
+		ownerID  gidx.PrefixedID
+	}
+
+	runFn := func(ctx context.Context, in input) testingx.TestResult[map[string]any] {
+		claims := &jwt.JWTClaims{}
+
+		err := AddClaim(ctx, in.strategy, claims, subject, homeOwner, in.ownerID)
+
+		return testingx.TestResult[map[string]any]{
+			Success: claims.Extra,
+			Err:     err,
+		}
+	}
+
+	checkGroups := func(expected []string) func(context.Context, *testing.T, testingx.TestResult[map[string]any]) {
+		return func(_ context.Context, t *testing.T, result testingx.TestResult[map[string]any]) {
+			require.NoError(t, result.Err)
+			assert.Equal(t, expected, result.Success[fositex.ClaimGroups])
+		}
+	}
+
+	testCases := []testingx.TestCase[input, map[string]any]{
+		{
+			Name:  "Disabled",
+			Input: input{},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[map[string]any]) {
+				require.NoError(t, result.Err)
+				assert.NotContains(t, result.Success, fositex.ClaimGroups)
+			},
+		},
+		{
+			Name:    "HomeOwner",
+			Input:   input{strategy: strategy},
+			CheckFn: checkGroups([]string{"idntgrp-a", "idntgrp-b"}),
+		},
+		{
+			Name:    "RequestedOwner",
+			Input:   input{strategy: strategy, ownerID: "testten-member"},
+			CheckFn: checkGroups([]string{"idntgrp-b"}),
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
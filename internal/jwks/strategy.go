@@ -12,6 +12,9 @@ import (
 var (
 	// ErrUnknownIssuer is returned when the issuer is unknown.
 	ErrUnknownIssuer = fmt.Errorf("unknown JWT issuer")
+
+	// ErrNoJWKSURI is returned when the issuer has no JWKS URI configured.
+	ErrNoJWKSURI = fmt.Errorf("issuer has no JWKS URI configured")
 )
 
 type issuerJWKSURIProvider struct {
@@ -33,5 +36,9 @@ func (s issuerJWKSURIProvider) GetIssuerJWKSURI(ctx context.Context, iss string)
 		return "", err
 	}
 
+	if issuer.JWKSURI == "" {
+		return "", ErrNoJWKSURI
+	}
+
 	return issuer.JWKSURI, nil
 }
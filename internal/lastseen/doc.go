@@ -0,0 +1,3 @@
+// Package lastseen records when users and OAuth clients were last issued tokens, writing the
+// recorded times to storage in batches.
+package lastseen
@@ -0,0 +1,151 @@
+package lastseen
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// DefaultFlushInterval is how often recorded times are written if no interval is configured.
+const DefaultFlushInterval = time.Minute
+
+var _ fositex.LastSeenStrategy = &Recorder{}
+
+// Recorder records when users exchanged tokens and OAuth clients were issued tokens. Recorded times
+// are kept in memory and written periodically, so the token endpoint does not write to the database
+// for every token issued.
+type Recorder struct {
+	svc      types.LastSeenService
+	interval time.Duration
+	logger   *zap.Logger
+
+	mu      sync.Mutex
+	users   map[gidx.PrefixedID]time.Time
+	clients map[gidx.PrefixedID]time.Time
+}
+
+// Opt represents an option for configuring a Recorder.
+type Opt func(*Recorder)
+
+// WithLogger sets the logger for the Recorder.
+func WithLogger(logger *zap.Logger) Opt {
+	return func(r *Recorder) {
+		r.logger = logger
+	}
+}
+
+// NewRecorder creates a Recorder given a last seen service and last seen configuration.
+func NewRecorder(svc types.LastSeenService, config fositex.LastSeenConfig, opts ...Opt) *Recorder {
+	interval := config.FlushInterval
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+
+	r := &Recorder{
+		svc:      svc,
+		interval: interval,
+		logger:   zap.NewNop(),
+		users:    make(map[gidx.PrefixedID]time.Time),
+		clients:  make(map[gidx.PrefixedID]time.Time),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// RecordUserExchange records that the given user exchanged a token at the given time.
+func (r *Recorder) RecordUserExchange(userID gidx.PrefixedID, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record(r.users, userID, at)
+}
+
+// RecordClientToken records that a token was issued to the given OAuth client at the given time.
+func (r *Recorder) RecordClientToken(clientID gidx.PrefixedID, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record(r.clients, clientID, at)
+}
+
+// Run writes the recorded times every interval until the given context is canceled, then writes
+// them once more.
+func (r *Recorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// The context is canceled, so write the remaining times without it.
+			r.Flush(context.WithoutCancel(ctx))
+
+			return
+		case <-ticker.C:
+			r.Flush(ctx)
+		}
+	}
+}
+
+// Flush writes the times recorded since the last flush. Times which fail to be written are kept,
+// and written with the next flush.
+func (r *Recorder) Flush(ctx context.Context) {
+	r.mu.Lock()
+
+	users, clients := r.users, r.clients
+
+	r.users = make(map[gidx.PrefixedID]time.Time)
+	r.clients = make(map[gidx.PrefixedID]time.Time)
+
+	r.mu.Unlock()
+
+	var failedUsers, failedClients map[gidx.PrefixedID]time.Time
+
+	if err := r.svc.SetUsersLastExchangeAt(ctx, users); err != nil {
+		r.logger.Error("failed to record user last exchange times", zap.Error(err))
+
+		failedUsers = users
+	}
+
+	if err := r.svc.SetOAuthClientsLastTokenIssuedAt(ctx, clients); err != nil {
+		r.logger.Error("failed to record client last token issued times", zap.Error(err))
+
+		failedClients = clients
+	}
+
+	r.restore(failedUsers, failedClients)
+
+	r.logger.Debug("recorded last seen times", zap.Int("users", len(users)), zap.Int("clients", len(clients)))
+}
+
+// restore adds times which failed to be written back to the recorded times.
+func (r *Recorder) restore(users, clients map[gidx.PrefixedID]time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, at := range users {
+		record(r.users, id, at)
+	}
+
+	for id, at := range clients {
+		record(r.clients, id, at)
+	}
+}
+
+// record records the time for the given ID, unless a later time is recorded already.
+func record(times map[gidx.PrefixedID]time.Time, id gidx.PrefixedID, at time.Time) {
+	if prev, ok := times[id]; !ok || at.After(prev) {
+		times[id] = at
+	}
+}
@@ -0,0 +1,115 @@
+package lastseen
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+type mockLastSeenService struct {
+	users   map[gidx.PrefixedID]time.Time
+	clients map[gidx.PrefixedID]time.Time
+	err     error
+}
+
+func (s *mockLastSeenService) SetUsersLastExchangeAt(_ context.Context, times map[gidx.PrefixedID]time.Time) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.users = times
+
+	return nil
+}
+
+func (s *mockLastSeenService) SetOAuthClientsLastTokenIssuedAt(_ context.Context, times map[gidx.PrefixedID]time.Time) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.clients = times
+
+	return nil
+}
+
+// TestFlush checks that the latest recorded times are written, and that times which fail to be
+// written are kept for the next flush.
+func TestFlush(t *testing.T) {
+	t.Parallel()
+
+	userID := gidx.MustNewID(types.IdentityUserIDPrefix)
+	clientID := gidx.MustNewID(types.IdentityClientIDPrefix)
+
+	now := time.Now()
+	earlier := now.Add(-time.Minute)
+
+	type input struct {
+		errs []error
+	}
+
+	runFn := func(ctx context.Context, in input) testingx.TestResult[*mockLastSeenService] {
+		svc := &mockLastSeenService{}
+
+		recorder := NewRecorder(svc, fositex.LastSeenConfig{})
+
+		recorder.RecordUserExchange(userID, now)
+		recorder.RecordUserExchange(userID, earlier)
+		recorder.RecordClientToken(clientID, earlier)
+		recorder.RecordClientToken(clientID, now)
+
+		for _, err := range in.errs {
+			svc.err = err
+
+			recorder.Flush(ctx)
+		}
+
+		return testingx.TestResult[*mockLastSeenService]{
+			Success: svc,
+		}
+	}
+
+	checkFlushed := func(_ context.Context, t *testing.T, res testingx.TestResult[*mockLastSeenService]) {
+		require.NoError(t, res.Err)
+		assert.Equal(t, map[gidx.PrefixedID]time.Time{userID: now}, res.Success.users)
+		assert.Equal(t, map[gidx.PrefixedID]time.Time{clientID: now}, res.Success.clients)
+	}
+
+	testCases := []testingx.TestCase[input, *mockLastSeenService]{
+		{
+			Name: "Success",
+			Input: input{
+				errs: []error{nil},
+			},
+			CheckFn: checkFlushed,
+		},
+		{
+			Name: "RetriedAfterError",
+			Input: input{
+				errs: []error{errors.New("boom"), nil},
+			},
+			CheckFn: checkFlushed,
+		},
+		{
+			Name: "NotRewritten",
+			Input: input{
+				errs: []error{nil, nil},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[*mockLastSeenService]) {
+				require.NoError(t, res.Err)
+				assert.Empty(t, res.Success.users)
+				assert.Empty(t, res.Success.clients)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
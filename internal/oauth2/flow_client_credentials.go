@@ -11,7 +11,9 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/groups"
 	"go.infratographer.com/identity-api/internal/storage"
+	"go.infratographer.com/identity-api/internal/types"
 
 	"github.com/ory/fosite"
 	"github.com/ory/fosite/handler/oauth2"
@@ -21,7 +23,10 @@ import (
 
 const instrumentationName = "go.infratographer.com/identity-api/internal/oauth2"
 
-var _ fosite.TokenEndpointHandler = &ClientCredentialsGrantHandler{}
+var (
+	_ fosite.TokenEndpointHandler = &ClientCredentialsGrantHandler{}
+	_ fositex.GrantTypeHandler    = &ClientCredentialsGrantHandler{}
+)
 
 type clientCredentialsConfigurator interface {
 	fosite.ScopeStrategyProvider
@@ -29,7 +34,10 @@ type clientCredentialsConfigurator interface {
 	fosite.AccessTokenLifespanProvider
 	fosite.AccessTokenIssuerProvider
 	fositex.UserInfoAudienceProvider
-	fositex.SigningKeyProvider
+	fositex.SignerProvider
+	fositex.GroupsClaimStrategyProvider
+	fositex.OwnerAccessStrategyProvider
+	fositex.LastSeenStrategyProvider
 }
 
 // ClientCredentialsGrantHandler handles the RFC6749 client credentials grant type.
@@ -62,6 +70,18 @@ func (c *ClientCredentialsGrantHandler) HandleTokenEndpointRequest(ctx context.C
 		return errorsx.WithStack(fosite.ErrInvalidGrant.WithHint("The OAuth 2.0 Client is marked as public and is not allowed to use authorization grant 'client_credentials'."))
 	}
 
+	if !client.GetGrantTypes().Has(types.GrantTypeClientCredentials) {
+		return errorsx.WithStack(fosite.ErrUnauthorizedClient.WithHint("The OAuth 2.0 Client is not allowed to use authorization grant 'client_credentials'."))
+	}
+
+	for _, scope := range request.GetRequestedScopes() {
+		if !c.Config.GetScopeStrategy(ctx)(client.GetScopes(), scope) {
+			return errorsx.WithStack(fosite.ErrInvalidScope.WithHintf("The OAuth 2.0 Client is not allowed to request scope '%s'.", scope))
+		}
+
+		request.GrantScope(scope)
+	}
+
 	requestedResources := request.GetRequestForm()["resource"]
 
 	resources := make([]string, 0)
@@ -80,7 +100,7 @@ func (c *ClientCredentialsGrantHandler) HandleTokenEndpointRequest(ctx context.C
 	atLifespan := fosite.GetEffectiveLifespan(client, fosite.GrantTypeClientCredentials, fosite.AccessToken, c.Config.GetAccessTokenLifespan(ctx))
 	session := request.GetSession().(*oauth2.JWTSession)
 
-	kid := c.Config.GetSigningKey(ctx).KeyID
+	kid := c.Config.GetSigner(ctx).Public().KeyID
 
 	headers := jwt.Headers{}
 	headers.Add("kid", kid)
@@ -100,6 +120,24 @@ func (c *ClientCredentialsGrantHandler) HandleTokenEndpointRequest(ctx context.C
 
 	session.JWTClaims.Subject = clientID.String()
 
+	oauthClient, ok := client.(types.OAuthClient)
+	if !ok {
+		return errorsx.WithStack(fosite.ErrServerError.WithHint("unexpected client type"))
+	}
+
+	ownerID, err := groups.RequestedOwner(ctx, c.Config.GetOwnerAccessStrategy(ctx), request.GetRequestForm().Get(fositex.ParamOwnerID), clientID, oauthClient.OwnerID)
+	if err != nil {
+		return err
+	}
+
+	if ownerID != "" {
+		session.JWTClaims.Add(fositex.ClaimOwnerID, ownerID.String())
+	}
+
+	if err := groups.AddClaim(ctx, c.Config.GetGroupsClaimStrategy(ctx), session.JWTClaims, clientID, oauthClient.OwnerID, ownerID); err != nil {
+		return err
+	}
+
 	span.SetAttributes(
 		attribute.Stringer(
 			"jwt_headers.client_id",
@@ -135,9 +173,17 @@ func (c *ClientCredentialsGrantHandler) PopulateTokenEndpointResponse(ctx contex
 
 	atLifespan := fosite.GetEffectiveLifespan(request.GetClient(), fosite.GrantTypeClientCredentials, fosite.AccessToken, c.Config.GetAccessTokenLifespan(ctx))
 
-	_, err := c.IssueAccessToken(ctx, atLifespan, request, response)
+	if _, err := c.IssueAccessToken(ctx, atLifespan, request, response); err != nil {
+		return err
+	}
+
+	if strategy := c.Config.GetLastSeenStrategy(ctx); strategy != nil {
+		if clientID, err := gidx.Parse(request.GetClient().GetID()); err == nil {
+			strategy.RecordClientToken(clientID, time.Now())
+		}
+	}
 
-	return err
+	return nil
 }
 
 // CanSkipClientAuth determines if the client must be authenticated to use this handler.
@@ -149,7 +195,12 @@ func (c *ClientCredentialsGrantHandler) CanSkipClientAuth(_ context.Context, _ f
 func (c *ClientCredentialsGrantHandler) CanHandleTokenEndpointRequest(_ context.Context, requester fosite.AccessRequester) bool {
 	// grant_type REQUIRED.
 	// Value MUST be set to "client_credentials".
-	return requester.GetGrantTypes().ExactOne("client_credentials")
+	return requester.GetGrantTypes().ExactOne(c.GrantType())
+}
+
+// GrantType returns the grant type handled by this handler.
+func (c *ClientCredentialsGrantHandler) GrantType() string {
+	return types.GrantTypeClientCredentials
 }
 
 var _ fositex.Factory = NewClientCredentialsHandlerFactory
@@ -0,0 +1,175 @@
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/ory/x/errorsx"
+	"go.infratographer.com/x/gidx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+var (
+	_ fosite.TokenEndpointHandler = &RefreshTokenGrantHandler{}
+	_ fositex.GrantTypeHandler    = &RefreshTokenGrantHandler{}
+)
+
+type refreshTokenConfigurator interface {
+	fosite.AccessTokenLifespanProvider
+	fosite.RefreshTokenLifespanProvider
+	fosite.ScopeStrategyProvider
+	fosite.AudienceStrategyProvider
+	fosite.RefreshTokenScopesProvider
+	fositex.SignerProvider
+	fositex.UserInfoStrategyProvider
+}
+
+// refreshTokenConfig overrides the audience strategy used when refreshing tokens. Audiences were checked
+// when the refresh token was first issued, possibly against the subject issuer's allowed audiences rather
+// than the client's, so they are carried over to the refreshed token as-is.
+type refreshTokenConfig struct {
+	refreshTokenConfigurator
+}
+
+// GetAudienceStrategy returns an audience strategy which allows every audience.
+func (refreshTokenConfig) GetAudienceStrategy(_ context.Context) fosite.AudienceMatchingStrategy {
+	return func(_ []string, _ []string) error {
+		return nil
+	}
+}
+
+// RefreshTokenGrantHandler handles the RFC6749 refresh token grant type. Refresh tokens are rotated on
+// every use, and are only accepted while the user they were issued to still exists.
+type RefreshTokenGrantHandler struct {
+	*oauth2.RefreshTokenGrantHandler
+	Config refreshTokenConfigurator
+	tracer trace.Tracer
+}
+
+// HandleTokenEndpointRequest implements https://tools.ietf.org/html/rfc6749#section-6
+func (c *RefreshTokenGrantHandler) HandleTokenEndpointRequest(ctx context.Context, request fosite.AccessRequester) error {
+	ctx, span := c.tracer.Start(ctx, "HandleTokenEndpointRequest")
+
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String(
+			"oauth2.client_id",
+			request.GetClient().GetID(),
+		),
+	)
+
+	if err := c.RefreshTokenGrantHandler.HandleTokenEndpointRequest(ctx, request); err != nil {
+		return err
+	}
+
+	session, ok := request.GetSession().(*oauth2.JWTSession)
+	if !ok || session.JWTClaims == nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithHint("requester session is not a jwt session"))
+	}
+
+	if err := c.checkSubject(ctx, request, session.JWTClaims.Subject); err != nil {
+		return err
+	}
+
+	kid := c.Config.GetSigner(ctx).Public().KeyID
+
+	headers := jwt.Headers{}
+	headers.Add("kid", kid)
+
+	// The stored claims were issued with the original token, so they must be reset for the new one.
+	session.JWTHeader = &headers
+	session.JWTClaims.JTI = ""
+	session.JWTClaims.IssuedAt = time.Time{}
+
+	span.SetAttributes(
+		attribute.String(
+			"jwt_headers.kid",
+			kid,
+		),
+		attribute.String(
+			"jwt_claims.sub",
+			session.JWTClaims.Subject,
+		),
+	)
+
+	return nil
+}
+
+// checkSubject ensures the user the refresh token was issued to still exists and is not disabled. If
+// it does not or is disabled, every refresh token issued with the original request is revoked.
+func (c *RefreshTokenGrantHandler) checkSubject(ctx context.Context, request fosite.AccessRequester, subject string) error {
+	userID, err := gidx.Parse(subject)
+	if err != nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithHintf("invalid refresh token subject: %s", err))
+	}
+
+	userInfo, err := c.Config.GetUserInfoStrategy(ctx).LookupUserInfoByID(ctx, userID)
+
+	var hint string
+
+	switch {
+	case err == nil && !userInfo.Disabled:
+		return nil
+	case err == nil:
+		hint = "The user the refresh token was issued to is disabled."
+	case errors.Is(err, types.ErrUserInfoNotFound):
+		hint = "The user the refresh token was issued to no longer exists."
+	default:
+		return errorsx.WithStack(fosite.ErrServerError.WithHintf("unable to look up user: %s", err))
+	}
+
+	if err := c.TokenRevocationStorage.RevokeRefreshToken(ctx, request.GetID()); err != nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	cause := types.ErrorInvalidTokenRequest{
+		Subject: map[string]string{
+			"subject": subject,
+		},
+	}
+
+	return errorsx.WithStack(fosite.ErrInvalidGrant.WithHint(hint).WithWrap(cause))
+}
+
+// PopulateTokenEndpointResponse implements https://tools.ietf.org/html/rfc6749#section-6
+func (c *RefreshTokenGrantHandler) PopulateTokenEndpointResponse(ctx context.Context, request fosite.AccessRequester, response fosite.AccessResponder) error {
+	ctx, span := c.tracer.Start(ctx, "PopulateTokenEndpointResponse")
+
+	defer span.End()
+
+	return c.RefreshTokenGrantHandler.PopulateTokenEndpointResponse(ctx, request, response)
+}
+
+// GrantType returns the grant type handled by this handler.
+func (c *RefreshTokenGrantHandler) GrantType() string {
+	return types.GrantTypeRefreshToken
+}
+
+var _ fositex.Factory = NewRefreshTokenHandlerFactory
+
+// NewRefreshTokenHandlerFactory is a fositex.Factory that
+// produces a handler for the 'refresh_token' grant type.
+func NewRefreshTokenHandlerFactory(config fositex.OAuth2Configurator, store any, strategy any) any {
+	tracer := otel.Tracer(instrumentationName)
+
+	return &RefreshTokenGrantHandler{
+		RefreshTokenGrantHandler: &oauth2.RefreshTokenGrantHandler{
+			AccessTokenStrategy:    strategy.(oauth2.AccessTokenStrategy),
+			RefreshTokenStrategy:   strategy.(oauth2.RefreshTokenStrategy),
+			TokenRevocationStorage: store.(oauth2.TokenRevocationStorage),
+			Config:                 refreshTokenConfig{config},
+		},
+		Config: config,
+		tracer: tracer,
+	}
+}
@@ -0,0 +1,170 @@
+package oauth2
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/compose"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/storage"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/testingx"
+)
+
+// TestTokenIntrospector checks that only recorded, unexpired and unrevoked access tokens are introspected.
+func TestTokenIntrospector(t *testing.T) {
+	t.Parallel()
+
+	config := &fosite.Config{
+		AccessTokenIssuer: "https://example.com/",
+		GlobalSecret:      []byte("abcd1234abcd1234abcd1234abcd1234"),
+		ScopeStrategy:     fosite.ExactScopeStrategy,
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	keyGetter := func(_ context.Context) (any, error) {
+		return key, nil
+	}
+
+	strategy := fositex.NewJTIStrategy(compose.NewOAuth2JWTStrategy(keyGetter, compose.NewOAuth2HMACStrategy(config), config))
+	store := storage.NewMemoryStore()
+
+	introspector := &TokenIntrospector{
+		AccessTokenStrategy: strategy,
+		AccessTokenStorage:  store,
+		Config:              config,
+		tracer:              otel.Tracer(instrumentationName),
+	}
+
+	issueToken := func(expiresAt time.Time, record bool) (string, string) {
+		request := fosite.NewRequest()
+		request.GrantedScope = fosite.Arguments{"read"}
+		request.GrantedAudience = fosite.Arguments{"https://example.com/userinfo"}
+		request.Session = &oauth2.JWTSession{
+			JWTClaims: &jwt.JWTClaims{
+				Subject:   "idntusr-abc",
+				ExpiresAt: expiresAt,
+				Extra: map[string]any{
+					"email": "user@example.com",
+				},
+			},
+			JWTHeader: &jwt.Headers{},
+			ExpiresAt: map[fosite.TokenType]time.Time{
+				fosite.AccessToken: expiresAt,
+			},
+			Subject: "upstream-subject",
+		}
+
+		token, jti, err := strategy.GenerateAccessToken(context.Background(), request)
+		require.NoError(t, err)
+
+		if record {
+			require.NoError(t, store.CreateAccessTokenSession(context.Background(), jti, request))
+		}
+
+		return token, request.GetID()
+	}
+
+	activeToken, _ := issueToken(time.Now().Add(time.Hour), true)
+	expiredToken, _ := issueToken(time.Now().Add(-time.Hour), true)
+	unrecordedToken, _ := issueToken(time.Now().Add(time.Hour), false)
+	revokedToken, revokedRequestID := issueToken(time.Now().Add(time.Hour), true)
+
+	require.NoError(t, store.RevokeAccessToken(context.Background(), revokedRequestID))
+
+	type input struct {
+		token  string
+		scopes []string
+	}
+
+	runFn := func(ctx context.Context, in input) testingx.TestResult[fosite.AccessRequester] {
+		accessRequest := fosite.NewAccessRequest(&oauth2.JWTSession{})
+
+		_, err := introspector.IntrospectToken(ctx, in.token, fosite.AccessToken, accessRequest, in.scopes)
+
+		return testingx.TestResult[fosite.AccessRequester]{
+			Success: accessRequest,
+			Err:     err,
+		}
+	}
+
+	testCases := []testingx.TestCase[input, fosite.AccessRequester]{
+		{
+			Name: "Active",
+			Input: input{
+				token:  activeToken,
+				scopes: []string{"read"},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[fosite.AccessRequester]) {
+				require.NoError(t, res.Err)
+
+				session, ok := res.Success.GetSession().(*oauth2.JWTSession)
+				require.True(t, ok)
+
+				assert.Equal(t, "idntusr-abc", session.GetSubject())
+				assert.Equal(t, "user@example.com", session.GetExtraClaims()["email"])
+				assert.Equal(t, fosite.Arguments{"https://example.com/userinfo"}, res.Success.GetGrantedAudience())
+			},
+		},
+		{
+			Name: "ScopeNotGranted",
+			Input: input{
+				token:  activeToken,
+				scopes: []string{"write"},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[fosite.AccessRequester]) {
+				assert.ErrorIs(t, res.Err, fosite.ErrInvalidScope)
+			},
+		},
+		{
+			Name: "Expired",
+			Input: input{
+				token: expiredToken,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[fosite.AccessRequester]) {
+				assert.ErrorIs(t, res.Err, fosite.ErrTokenExpired)
+			},
+		},
+		{
+			Name: "NotRecorded",
+			Input: input{
+				token: unrecordedToken,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[fosite.AccessRequester]) {
+				assert.ErrorIs(t, res.Err, fosite.ErrRequestUnauthorized)
+			},
+		},
+		{
+			Name: "Revoked",
+			Input: input{
+				token: revokedToken,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[fosite.AccessRequester]) {
+				assert.ErrorIs(t, res.Err, fosite.ErrRequestUnauthorized)
+			},
+		},
+		{
+			Name: "NotAJWT",
+			Input: input{
+				token: "opaque",
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[fosite.AccessRequester]) {
+				assert.ErrorIs(t, res.Err, fosite.ErrUnknownRequest)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
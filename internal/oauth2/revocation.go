@@ -0,0 +1,20 @@
+package oauth2
+
+import (
+	"github.com/ory/fosite/handler/oauth2"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+)
+
+var _ fositex.Factory = NewTokenRevocationHandlerFactory
+
+// NewTokenRevocationHandlerFactory is a fositex.Factory that produces an RFC7009 token
+// revocation handler. Revoking either token issued for a request revokes both the refresh
+// tokens and access tokens issued for it, with access tokens added to the deny-list.
+func NewTokenRevocationHandlerFactory(_ fositex.OAuth2Configurator, store any, strategy any) any {
+	return &oauth2.TokenRevocationHandler{
+		TokenRevocationStorage: store.(oauth2.TokenRevocationStorage),
+		AccessTokenStrategy:    strategy.(oauth2.AccessTokenStrategy),
+		RefreshTokenStrategy:   strategy.(oauth2.RefreshTokenStrategy),
+	}
+}
@@ -0,0 +1,99 @@
+package rfc7662
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTimeout is the default timeout for introspection requests.
+	DefaultTimeout = 10 * time.Second
+
+	claimActive = "active"
+	claimExpiry = "exp"
+
+	tokenTypeHintAccessToken = "access_token"
+
+	maxResponseBytes = 1 << 20
+)
+
+// Credentials represents the client credentials used to authenticate to an introspection endpoint.
+type Credentials struct {
+	ClientID     string
+	ClientSecret string
+}
+
+// Client makes RFC 7662 token introspection requests.
+type Client struct {
+	httpClient *http.Client
+}
+
+// NewClient creates a new introspection client. If httpClient is nil, a client with DefaultTimeout is used.
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: DefaultTimeout,
+		}
+	}
+
+	return &Client{
+		httpClient: httpClient,
+	}
+}
+
+// Introspect requests introspection of the given token from the given endpoint, returning the claims
+// in the introspection response if the token is active.
+func (c *Client) Introspect(ctx context.Context, endpoint string, creds Credentials, token string) (map[string]any, error) {
+	form := url.Values{
+		"token":           {token},
+		"token_type_hint": {tokenTypeHintAccessToken},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntrospectionFailed, err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	if creds.ClientID != "" {
+		// Per RFC 6749 section 2.3.1, credentials are form-encoded before being used for basic auth.
+		req.SetBasicAuth(url.QueryEscape(creds.ClientID), url.QueryEscape(creds.ClientSecret))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntrospectionFailed, err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: unexpected status code %d", ErrIntrospectionFailed, resp.StatusCode)
+	}
+
+	var claims map[string]any
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntrospectionFailed, err)
+	}
+
+	if active, ok := claims[claimActive].(bool); !ok || !active {
+		return nil, ErrInactiveToken
+	}
+
+	if exp, ok := claims[claimExpiry].(float64); ok && time.Unix(int64(exp), 0).Before(time.Now()) {
+		return nil, ErrInactiveToken
+	}
+
+	delete(claims, claimActive)
+
+	return claims, nil
+}
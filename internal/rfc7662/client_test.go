@@ -0,0 +1,132 @@
+package rfc7662
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+)
+
+// TestIntrospect checks that introspection responses are interpreted correctly.
+func TestIntrospect(t *testing.T) {
+	t.Parallel()
+
+	responses := map[string]map[string]any{
+		"active": {
+			"active": true,
+			"sub":    "foo",
+			"scope":  "read write",
+		},
+		"inactive": {
+			"active": false,
+		},
+		"expired": {
+			"active": true,
+			"sub":    "foo",
+			"exp":    time.Now().Add(-time.Hour).Unix(),
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "client" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		resp, ok := responses[r.PostFormValue("token")]
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	client := NewClient(srv.Client())
+
+	validCreds := Credentials{
+		ClientID:     "client",
+		ClientSecret: "secret",
+	}
+
+	type introspectInput struct {
+		creds Credentials
+		token string
+	}
+
+	runFn := func(ctx context.Context, input introspectInput) testingx.TestResult[map[string]any] {
+		out, err := client.Introspect(ctx, srv.URL, input.creds, input.token)
+
+		return testingx.TestResult[map[string]any]{
+			Success: out,
+			Err:     err,
+		}
+	}
+
+	testCases := []testingx.TestCase[introspectInput, map[string]any]{
+		{
+			Name: "Active",
+			Input: introspectInput{
+				creds: validCreds,
+				token: "active",
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[map[string]any]) {
+				assert.NoError(t, result.Err)
+
+				expected := map[string]any{
+					"sub":   "foo",
+					"scope": "read write",
+				}
+
+				assert.Equal(t, expected, result.Success)
+			},
+		},
+		{
+			Name: "Inactive",
+			Input: introspectInput{
+				creds: validCreds,
+				token: "inactive",
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[map[string]any]) {
+				assert.ErrorIs(t, result.Err, ErrInactiveToken)
+			},
+		},
+		{
+			Name: "Expired",
+			Input: introspectInput{
+				creds: validCreds,
+				token: "expired",
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[map[string]any]) {
+				assert.ErrorIs(t, result.Err, ErrInactiveToken)
+			},
+		},
+		{
+			Name: "BadCredentials",
+			Input: introspectInput{
+				creds: Credentials{
+					ClientID:     "client",
+					ClientSecret: "wrong",
+				},
+				token: "active",
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[map[string]any]) {
+				assert.ErrorIs(t, result.Err, ErrIntrospectionFailed)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
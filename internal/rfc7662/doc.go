@@ -0,0 +1,3 @@
+// Package rfc7662 provides an RFC 7662 token introspection client and a fositex.IntrospectionStrategy
+// for validating opaque tokens issued by upstream issuers.
+package rfc7662
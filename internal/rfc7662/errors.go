@@ -0,0 +1,22 @@
+package rfc7662
+
+import (
+	"errors"
+)
+
+var (
+	// ErrIntrospectionFailed represents an error where the introspection request could not be completed.
+	ErrIntrospectionFailed = errors.New("token introspection request failed")
+
+	// ErrInactiveToken represents an error where the introspection endpoint reported the token as inactive.
+	ErrInactiveToken = errors.New("token is not active")
+
+	// ErrIntrospectionNotConfigured represents an error where the issuer has no introspection endpoint configured.
+	ErrIntrospectionNotConfigured = errors.New("issuer has no introspection endpoint configured")
+
+	// ErrIssuerMismatch represents an error where the introspected token was issued by a different issuer.
+	ErrIssuerMismatch = errors.New("introspected token issuer does not match requested issuer")
+
+	// ErrMissingSubject represents an error where the introspection response has no subject.
+	ErrMissingSubject = errors.New("introspection response is missing 'sub'")
+)
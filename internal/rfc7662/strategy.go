@@ -0,0 +1,67 @@
+package rfc7662
+
+import (
+	"context"
+
+	"github.com/ory/fosite/token/jwt"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// IntrospectionStrategy validates opaque tokens using the introspection endpoint configured on their issuer.
+type IntrospectionStrategy struct {
+	issuerSvc types.IssuerService
+	client    *Client
+}
+
+// IntrospectionStrategy implements fositex.IntrospectionStrategy
+var _ fositex.IntrospectionStrategy = (*IntrospectionStrategy)(nil)
+
+// NewIntrospectionStrategy creates an IntrospectionStrategy given an issuer service and introspection client.
+func NewIntrospectionStrategy(issuerSvc types.IssuerService, client *Client) IntrospectionStrategy {
+	return IntrospectionStrategy{
+		issuerSvc: issuerSvc,
+		client:    client,
+	}
+}
+
+// IntrospectToken introspects the given token using the introspection endpoint of the issuer with the
+// given URI. The returned claims always have "iss" set to the issuer URI, so they may be used with
+// the issuer's claim conditions and mappings.
+func (s IntrospectionStrategy) IntrospectToken(ctx context.Context, issuerURI, token string) (*jwt.JWTClaims, error) {
+	issuer, err := s.issuerSvc.GetIssuerByURI(ctx, issuerURI)
+	if err != nil {
+		return nil, err
+	}
+
+	if issuer.IntrospectionURI == "" {
+		return nil, ErrIntrospectionNotConfigured
+	}
+
+	creds := Credentials{
+		ClientID:     issuer.IntrospectionClientID,
+		ClientSecret: issuer.IntrospectionClientSecret,
+	}
+
+	claimsMap, err := s.client.Introspect(ctx, issuer.IntrospectionURI, creds, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if iss, ok := claimsMap["iss"].(string); ok && iss != "" && iss != issuer.URI {
+		return nil, ErrIssuerMismatch
+	}
+
+	claimsMap["iss"] = issuer.URI
+
+	if sub, ok := claimsMap["sub"].(string); !ok || sub == "" {
+		return nil, ErrMissingSubject
+	}
+
+	var claims jwt.JWTClaims
+
+	claims.FromMap(claimsMap)
+
+	return &claims, nil
+}
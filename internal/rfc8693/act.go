@@ -0,0 +1,55 @@
+package rfc8693
+
+import (
+	"github.com/ory/fosite/token/jwt"
+)
+
+const (
+	// ClaimActor is the claim describing the acting party per RFC 8693 section 4.1.
+	ClaimActor = "act"
+	// ClaimMayAct is the claim describing the party authorized to act on behalf of the subject per RFC 8693 section 4.4.
+	ClaimMayAct = "may_act"
+)
+
+// newActorClaim builds an RFC 8693 act claim identifying the party described by actorClaims.
+// If the subject token was itself the result of a delegation, the prior act claim is nested
+// within the new one so that the full delegation chain is preserved, with the current actor
+// outermost.
+func newActorClaim(subjectClaims, actorClaims *jwt.JWTClaims) map[string]any {
+	act := map[string]any{
+		"iss": actorClaims.Issuer,
+		"sub": actorClaims.Subject,
+	}
+
+	if subjectClaims == nil || subjectClaims.Extra == nil {
+		return act
+	}
+
+	if prior, ok := subjectClaims.Extra[ClaimActor].(map[string]any); ok && len(prior) > 0 {
+		act[ClaimActor] = prior
+	}
+
+	return act
+}
+
+// ActorFromClaims returns the issuer and subject of the current actor described by the act claim
+// in the given claims, if one is present.
+func ActorFromClaims(claims *jwt.JWTClaims) (string, string, bool) {
+	if claims == nil || claims.Extra == nil {
+		return "", "", false
+	}
+
+	act, ok := claims.Extra[ClaimActor].(map[string]any)
+	if !ok {
+		return "", "", false
+	}
+
+	iss, _ := act["iss"].(string)
+	sub, _ := act["sub"].(string)
+
+	if len(sub) == 0 {
+		return "", "", false
+	}
+
+	return iss, sub, true
+}
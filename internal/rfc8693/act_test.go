@@ -0,0 +1,151 @@
+package rfc8693
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+)
+
+// TestNewActorClaim checks that act claims are built and nested correctly.
+func TestNewActorClaim(t *testing.T) {
+	t.Parallel()
+
+	type actorInput struct {
+		subject *jwt.JWTClaims
+		actor   *jwt.JWTClaims
+	}
+
+	runFn := func(_ context.Context, input actorInput) testingx.TestResult[map[string]any] {
+		return testingx.TestResult[map[string]any]{
+			Success: newActorClaim(input.subject, input.actor),
+		}
+	}
+
+	actor := &jwt.JWTClaims{
+		Issuer:  "https://ci.example.com/",
+		Subject: "pipeline",
+	}
+
+	testCases := []testingx.TestCase[actorInput, map[string]any]{
+		{
+			Name: "Direct",
+			Input: actorInput{
+				subject: &jwt.JWTClaims{
+					Issuer:  "https://example.com/",
+					Subject: "foo",
+				},
+				actor: actor,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[map[string]any]) {
+				expected := map[string]any{
+					"iss": "https://ci.example.com/",
+					"sub": "pipeline",
+				}
+
+				assert.Equal(t, expected, result.Success)
+			},
+		},
+		{
+			Name: "Nested",
+			Input: actorInput{
+				subject: &jwt.JWTClaims{
+					Issuer:  "https://example.com/",
+					Subject: "foo",
+					Extra: map[string]any{
+						"act": map[string]any{
+							"iss": "https://support.example.com/",
+							"sub": "tool",
+						},
+					},
+				},
+				actor: actor,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[map[string]any]) {
+				expected := map[string]any{
+					"iss": "https://ci.example.com/",
+					"sub": "pipeline",
+					"act": map[string]any{
+						"iss": "https://support.example.com/",
+						"sub": "tool",
+					},
+				}
+
+				assert.Equal(t, expected, result.Success)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
+
+// TestActorFromClaims checks that the current actor is extracted from act claims.
+func TestActorFromClaims(t *testing.T) {
+	t.Parallel()
+
+	type actorResult struct {
+		issuer  string
+		subject string
+		ok      bool
+	}
+
+	runFn := func(_ context.Context, claims *jwt.JWTClaims) testingx.TestResult[actorResult] {
+		iss, sub, ok := ActorFromClaims(claims)
+
+		return testingx.TestResult[actorResult]{
+			Success: actorResult{
+				issuer:  iss,
+				subject: sub,
+				ok:      ok,
+			},
+		}
+	}
+
+	testCases := []testingx.TestCase[*jwt.JWTClaims, actorResult]{
+		{
+			Name:  "NilClaims",
+			Input: nil,
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[actorResult]) {
+				assert.False(t, result.Success.ok)
+			},
+		},
+		{
+			Name: "NoActor",
+			Input: &jwt.JWTClaims{
+				Subject: "foo",
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[actorResult]) {
+				assert.False(t, result.Success.ok)
+			},
+		},
+		{
+			Name: "Actor",
+			Input: &jwt.JWTClaims{
+				Subject: "foo",
+				Extra: map[string]any{
+					"act": map[string]any{
+						"iss": "https://ci.example.com/",
+						"sub": "pipeline",
+						"act": map[string]any{
+							"sub": "prior",
+						},
+					},
+				},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[actorResult]) {
+				expected := actorResult{
+					issuer:  "https://ci.example.com/",
+					subject: "pipeline",
+					ok:      true,
+				}
+
+				assert.Equal(t, expected, result.Success)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
@@ -0,0 +1,73 @@
+package rfc8693
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/ory/fosite"
+	"github.com/ory/x/errorsx"
+	"go.opentelemetry.io/otel/attribute"
+
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// ParamResource is the OAuth 2.0 request parameter for the resource per RFC 8693 and RFC 8707.
+const ParamResource = "resource"
+
+// requestedAudiences returns the audiences requested with the audience and resource parameters,
+// in the order given and without duplicates.
+func requestedAudiences(form url.Values) []string {
+	seen := map[string]struct{}{}
+	out := []string{}
+
+	for _, aud := range append(fosite.GetAudiences(form), fosite.RemoveEmpty(form[ParamResource])...) {
+		if _, ok := seen[aud]; ok {
+			continue
+		}
+
+		seen[aud] = struct{}{}
+
+		out = append(out, aud)
+	}
+
+	return out
+}
+
+// grantAudiences checks the requested audiences against the allowlist of the authenticated client,
+// or of the subject token issuer if no client authenticated, and grants them on the request.
+func (s *TokenExchangeHandler) grantAudiences(ctx context.Context, requester fosite.AccessRequester, issuer *types.Issuer, subject string) error {
+	audiences := requestedAudiences(requester.GetRequestForm())
+	if len(audiences) == 0 {
+		return nil
+	}
+
+	ctx, span := s.tracer.Start(ctx, "grantAudiences")
+
+	defer span.End()
+
+	span.SetAttributes(attribute.StringSlice("audiences", audiences))
+
+	allowed := issuer.AllowedAudiences
+
+	client := requester.GetClient()
+	if client != nil && len(client.GetID()) > 0 {
+		allowed = client.GetAudience()
+	}
+
+	if err := s.config.GetAudienceStrategy(ctx)(allowed, audiences); err != nil {
+		cause := types.ErrorInvalidTokenRequest{
+			Subject: map[string]string{
+				"issuer":  issuer.URI,
+				"subject": subject,
+			},
+		}
+
+		return errorsx.WithStack(fosite.ErrorToRFC6749Error(err).WithWrap(cause))
+	}
+
+	for _, aud := range audiences {
+		requester.GrantAudience(aud)
+	}
+
+	return nil
+}
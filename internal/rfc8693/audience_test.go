@@ -0,0 +1,59 @@
+package rfc8693
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+)
+
+// TestRequestedAudiences checks that audience and resource parameters are combined without duplicates.
+func TestRequestedAudiences(t *testing.T) {
+	t.Parallel()
+
+	runFn := func(_ context.Context, input url.Values) testingx.TestResult[[]string] {
+		return testingx.TestResult[[]string]{
+			Success: requestedAudiences(input),
+		}
+	}
+
+	testCases := []testingx.TestCase[url.Values, []string]{
+		{
+			Name:  "None",
+			Input: url.Values{},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				assert.Empty(t, result.Success)
+			},
+		},
+		{
+			Name: "SpaceDelimitedAudience",
+			Input: url.Values{
+				"audience": {"https://a.example.com https://b.example.com"},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, result.Success)
+			},
+		},
+		{
+			Name: "AudienceAndResource",
+			Input: url.Values{
+				"audience": {"https://a.example.com", "https://b.example.com"},
+				"resource": {"https://b.example.com", "https://c.example.com", ""},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				expected := []string{
+					"https://a.example.com",
+					"https://b.example.com",
+					"https://c.example.com",
+				}
+
+				assert.Equal(t, expected, result.Success)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
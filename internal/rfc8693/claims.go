@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"reflect"
 
 	"github.com/ory/fosite/token/jwt"
 
@@ -71,6 +72,58 @@ func (m ClaimMappingStrategy) MapClaims(ctx context.Context, claims *jwt.JWTClai
 	return &outputClaims, nil
 }
 
+// ScopeMappingStrategy represents a mapping from external identity claims to the scopes that may be granted.
+type ScopeMappingStrategy struct {
+	issuerSvc types.IssuerService
+}
+
+// NewScopeMappingStrategy creates a ScopeMappingStrategy given an issuer service.
+func NewScopeMappingStrategy(issuerSvc types.IssuerService) ScopeMappingStrategy {
+	return ScopeMappingStrategy{
+		issuerSvc: issuerSvc,
+	}
+}
+
+// ScopeMappingStrategy implements fositex.ScopeMappingStrategy
+var _ fositex.ScopeMappingStrategy = (*ScopeMappingStrategy)(nil)
+
+// MapScopes evaluates the scope mapping of the issuer of the given claims, returning the scopes that
+// may be granted. If the issuer has no scope mapping, no scopes may be granted.
+func (m ScopeMappingStrategy) MapScopes(ctx context.Context, claims *jwt.JWTClaims) ([]string, error) {
+	if claims.Issuer == "" {
+		return nil, ErrMissingIss
+	}
+
+	issuer, err := m.issuerSvc.GetIssuerByURI(ctx, claims.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	if issuer.ScopeMapping == nil || issuer.ScopeMapping.AST() == nil {
+		return []string{}, nil
+	}
+
+	subSHA256Bytes := sha256.Sum256([]byte(claims.Subject))
+	subSHA256 := hex.EncodeToString(subSHA256Bytes[0:])
+
+	inputEnv := map[string]any{
+		celutils.CELVariableClaims:    claims.ToMapClaims(),
+		celutils.CELVariableSubSHA256: subSHA256,
+	}
+
+	res, err := celutils.Eval(issuer.ScopeMapping.AST(), inputEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := res.ConvertToNative(reflect.TypeOf([]string{}))
+	if err != nil {
+		return nil, fmt.Errorf("%w: unexpected type for scope mapping result: %s", ErrInvalidScopeMapping, err)
+	}
+
+	return scopes.([]string), nil
+}
+
 // ClaimConditionStrategy represents a strategy for evaluating claims conditions.
 type ClaimConditionStrategy struct {
 	issuerSvc types.IssuerService
@@ -119,3 +172,86 @@ func (c ClaimConditionStrategy) Eval(ctx context.Context, claims *jwt.JWTClaims)
 
 	return result, nil
 }
+
+// ActorConditionStrategy represents a strategy for deciding whether an actor may act on behalf of a subject.
+// An actor is allowed if the subject token contains a may_act claim matching the actor token, or if the
+// subject issuer's actor conditions are satisfied. If neither is present, the actor is denied.
+type ActorConditionStrategy struct {
+	issuerSvc types.IssuerService
+}
+
+// NewActorConditionStrategy creates an ActorConditionStrategy given an issuer service.
+func NewActorConditionStrategy(issuerSvc types.IssuerService) ActorConditionStrategy {
+	return ActorConditionStrategy{
+		issuerSvc: issuerSvc,
+	}
+}
+
+// ActorConditionStrategy implements fositex.ActorConditionStrategy
+var _ fositex.ActorConditionStrategy = (*ActorConditionStrategy)(nil)
+
+// Eval evaluates whether the actor described by actorClaims may act on behalf of the subject described by subjectClaims.
+func (c ActorConditionStrategy) Eval(ctx context.Context, subjectClaims, actorClaims *jwt.JWTClaims) (bool, error) {
+	if subjectClaims.Issuer == "" {
+		return false, ErrMissingIss
+	}
+
+	actorMap := actorClaims.ToMapClaims()
+
+	if mayActMatches(subjectClaims, actorMap) {
+		return true, nil
+	}
+
+	issuer, err := c.issuerSvc.GetIssuerByURI(ctx, subjectClaims.Issuer)
+	if err != nil {
+		return false, err
+	}
+
+	if issuer.ActorConditions == nil || issuer.ActorConditions.AST() == nil {
+		return false, nil
+	}
+
+	inputEnv := map[string]any{
+		celutils.CELVariableClaims: subjectClaims.ToMapClaims(),
+		celutils.CELVariableActor:  actorMap,
+	}
+
+	res, err := celutils.Eval(issuer.ActorConditions.AST(), inputEnv)
+	if err != nil {
+		return false, err
+	}
+
+	result, ok := res.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("%w: unexpected type for actor condition result: %T", ErrInvalidClaimCondition, res.Value())
+	}
+
+	return result, nil
+}
+
+// mayActMatches returns true if the subject claims contain a may_act claim whose members all
+// match the corresponding actor claims. A may_act claim without an iss member only matches actors
+// from the issuer of the subject token, as the same subject may exist at other issuers.
+func mayActMatches(subjectClaims *jwt.JWTClaims, actorMap map[string]any) bool {
+	if subjectClaims.Extra == nil {
+		return false
+	}
+
+	mayAct, ok := subjectClaims.Extra[ClaimMayAct].(map[string]any)
+	if !ok || len(mayAct) == 0 {
+		return false
+	}
+
+	if _, ok := mayAct["iss"]; !ok && actorMap["iss"] != subjectClaims.Issuer {
+		return false
+	}
+
+	for k, v := range mayAct {
+		actorValue, ok := actorMap[k]
+		if !ok || !reflect.DeepEqual(v, actorValue) {
+			return false
+		}
+	}
+
+	return true
+}
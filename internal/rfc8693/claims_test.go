@@ -248,3 +248,318 @@ func TestClaimConditionsEval(t *testing.T) {
 
 	testingx.RunTests(context.Background(), t, testCases, runFn)
 }
+
+func TestActorConditionsEval(t *testing.T) {
+	t.Parallel()
+
+	testServer, err := storage.InMemoryCRDB()
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "initialization failed")
+	}
+
+	err = testServer.Start()
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "initialization failed")
+	}
+
+	t.Cleanup(func() {
+		testServer.Stop()
+	})
+
+	config := crdbx.Config{
+		URI: testServer.PGURL().String(),
+	}
+
+	seedData := storage.SeedData{
+		Issuers: []storage.SeedIssuer{
+			{
+				OwnerID: gidx.MustNewID("testten"),
+				ID:      gidx.MustNewID("testiss"),
+				Name:    "no-conditions",
+				URI:     "https://no-conditions.com/",
+				JWKSURI: "https://no-conditions.com/.well-known/jwks.json",
+			},
+			{
+				OwnerID:         gidx.MustNewID("testten"),
+				ID:              gidx.MustNewID("testiss"),
+				Name:            "yes-conditions",
+				URI:             "https://yes-conditions.com/",
+				JWKSURI:         "https://yes-conditions.com/.well-known/jwks.json",
+				ActorConditions: `actor.iss == "https://ci.example.com/" && claims.sub == "foo"`,
+			},
+		},
+	}
+
+	storageEngine, err := storage.NewEngine(config, storage.WithMigrations(), storage.WithSeedData(seedData))
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "initialization failed")
+	}
+
+	actorStrategy := NewActorConditionStrategy(storageEngine)
+
+	type actorInput struct {
+		subject *jwt.JWTClaims
+		actor   *jwt.JWTClaims
+	}
+
+	runFn := func(ctx context.Context, input actorInput) testingx.TestResult[bool] {
+		out, err := actorStrategy.Eval(ctx, input.subject, input.actor)
+
+		return testingx.TestResult[bool]{
+			Success: out,
+			Err:     err,
+		}
+	}
+
+	actor := &jwt.JWTClaims{
+		Subject: "pipeline",
+		Issuer:  "https://ci.example.com/",
+	}
+
+	testCases := []testingx.TestCase[actorInput, bool]{
+		{
+			Name: "DeniedWithNoConditions",
+			Input: actorInput{
+				subject: &jwt.JWTClaims{
+					Subject: "foo",
+					Issuer:  "https://no-conditions.com/",
+				},
+				actor: actor,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[bool]) {
+				assert.Nil(t, result.Err)
+				assert.False(t, result.Success)
+			},
+		},
+		{
+			Name: "SuccessWithMayAct",
+			Input: actorInput{
+				subject: &jwt.JWTClaims{
+					Subject: "foo",
+					Issuer:  "https://no-conditions.com/",
+					Extra: map[string]any{
+						"may_act": map[string]any{
+							"iss": "https://ci.example.com/",
+							"sub": "pipeline",
+						},
+					},
+				},
+				actor: actor,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[bool]) {
+				assert.Nil(t, result.Err)
+				assert.True(t, result.Success)
+			},
+		},
+		{
+			Name: "MayActMismatch",
+			Input: actorInput{
+				subject: &jwt.JWTClaims{
+					Subject: "foo",
+					Issuer:  "https://no-conditions.com/",
+					Extra: map[string]any{
+						"may_act": map[string]any{
+							"sub": "someone-else",
+						},
+					},
+				},
+				actor: actor,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[bool]) {
+				assert.Nil(t, result.Err)
+				assert.False(t, result.Success)
+			},
+		},
+		{
+			Name: "SuccessWithMayActSameIssuer",
+			Input: actorInput{
+				subject: &jwt.JWTClaims{
+					Subject: "foo",
+					Issuer:  "https://no-conditions.com/",
+					Extra: map[string]any{
+						"may_act": map[string]any{
+							"sub": "pipeline",
+						},
+					},
+				},
+				actor: &jwt.JWTClaims{
+					Subject: "pipeline",
+					Issuer:  "https://no-conditions.com/",
+				},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[bool]) {
+				assert.Nil(t, result.Err)
+				assert.True(t, result.Success)
+			},
+		},
+		{
+			Name: "MayActOtherIssuer",
+			Input: actorInput{
+				subject: &jwt.JWTClaims{
+					Subject: "foo",
+					Issuer:  "https://no-conditions.com/",
+					Extra: map[string]any{
+						"may_act": map[string]any{
+							"sub": "pipeline",
+						},
+					},
+				},
+				actor: actor,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[bool]) {
+				assert.Nil(t, result.Err)
+				assert.False(t, result.Success)
+			},
+		},
+		{
+			Name: "SuccessWithConditions",
+			Input: actorInput{
+				subject: &jwt.JWTClaims{
+					Subject: "foo",
+					Issuer:  "https://yes-conditions.com/",
+				},
+				actor: actor,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[bool]) {
+				assert.Nil(t, result.Err)
+				assert.True(t, result.Success)
+			},
+		},
+		{
+			Name: "ConditionNotSatisfied",
+			Input: actorInput{
+				subject: &jwt.JWTClaims{
+					Subject: "bar",
+					Issuer:  "https://yes-conditions.com/",
+				},
+				actor: actor,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[bool]) {
+				assert.Nil(t, result.Err)
+				assert.False(t, result.Success)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
+
+func TestScopeMappingEval(t *testing.T) {
+	t.Parallel()
+
+	testServer, err := storage.InMemoryCRDB()
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "initialization failed")
+	}
+
+	err = testServer.Start()
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "initialization failed")
+	}
+
+	t.Cleanup(func() {
+		testServer.Stop()
+	})
+
+	config := crdbx.Config{
+		URI: testServer.PGURL().String(),
+	}
+
+	seedData := storage.SeedData{
+		Issuers: []storage.SeedIssuer{
+			{
+				OwnerID: gidx.MustNewID("testten"),
+				ID:      gidx.MustNewID("testiss"),
+				Name:    "no-mapping",
+				URI:     "https://no-mapping.com/",
+				JWKSURI: "https://no-mapping.com/.well-known/jwks.json",
+			},
+			{
+				OwnerID:      gidx.MustNewID("testten"),
+				ID:           gidx.MustNewID("testiss"),
+				Name:         "yes-mapping",
+				URI:          "https://yes-mapping.com/",
+				JWKSURI:      "https://yes-mapping.com/.well-known/jwks.json",
+				ScopeMapping: `has(claims.roles) ? claims.roles.map(r, "role:" + r) : ["read"]`,
+			},
+			{
+				OwnerID:      gidx.MustNewID("testten"),
+				ID:           gidx.MustNewID("testiss"),
+				Name:         "dyn-mapping",
+				URI:          "https://dyn-mapping.com/",
+				JWKSURI:      "https://dyn-mapping.com/.well-known/jwks.json",
+				ScopeMapping: `claims.scp`,
+			},
+		},
+	}
+
+	storageEngine, err := storage.NewEngine(config, storage.WithMigrations(), storage.WithSeedData(seedData))
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "initialization failed")
+	}
+
+	strategy := NewScopeMappingStrategy(storageEngine)
+
+	runFn := func(ctx context.Context, claims *jwt.JWTClaims) testingx.TestResult[[]string] {
+		out, err := strategy.MapScopes(ctx, claims)
+
+		return testingx.TestResult[[]string]{
+			Success: out,
+			Err:     err,
+		}
+	}
+
+	testCases := []testingx.TestCase[*jwt.JWTClaims, []string]{
+		{
+			Name: "NoMapping",
+			Input: &jwt.JWTClaims{
+				Subject: "foo",
+				Issuer:  "https://no-mapping.com/",
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				assert.NoError(t, result.Err)
+				assert.Empty(t, result.Success)
+			},
+		},
+		{
+			Name: "MappedFromClaims",
+			Input: &jwt.JWTClaims{
+				Subject: "foo",
+				Issuer:  "https://yes-mapping.com/",
+				Extra: map[string]any{
+					"roles": []any{"admin", "viewer"},
+				},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				assert.NoError(t, result.Err)
+				assert.Equal(t, []string{"role:admin", "role:viewer"}, result.Success)
+			},
+		},
+		{
+			Name: "DefaultMapping",
+			Input: &jwt.JWTClaims{
+				Subject: "foo",
+				Issuer:  "https://yes-mapping.com/",
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				assert.NoError(t, result.Err)
+				assert.Equal(t, []string{"read"}, result.Success)
+			},
+		},
+		{
+			Name: "NotAList",
+			Input: &jwt.JWTClaims{
+				Subject: "foo",
+				Issuer:  "https://dyn-mapping.com/",
+				Extra: map[string]any{
+					"scp": "read",
+				},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				assert.ErrorIs(t, result.Err, ErrInvalidScopeMapping)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
@@ -16,8 +16,52 @@ var (
 		claim: "iss",
 	}
 
+	// ErrMissingIat represents an error where the 'iat' claim is missing from a token whose issuer limits token age.
+	ErrMissingIat = &ErrMissingClaim{
+		claim: "iat",
+	}
+
+	// ErrAlgorithmNotAllowed represents an error where a token is signed with an algorithm its issuer does not allow.
+	ErrAlgorithmNotAllowed = errors.New("token signing algorithm is not allowed")
+
+	// ErrTokenExpired represents an error where a token has expired.
+	ErrTokenExpired = errors.New("token is expired")
+
+	// ErrTokenNotValidYet represents an error where a token is used before its 'nbf' claim.
+	ErrTokenNotValidYet = errors.New("token is not valid yet")
+
+	// ErrTokenUsedBeforeIssued represents an error where a token is used before its 'iat' claim.
+	ErrTokenUsedBeforeIssued = errors.New("token used before issued")
+
+	// ErrTokenTooOld represents an error where a token was issued longer ago than its issuer's maximum token age.
+	ErrTokenTooOld = errors.New("token exceeds maximum age")
+
+	// ErrAudienceNotAllowed represents an error where a token's audience contains none of its issuer's required audiences.
+	ErrAudienceNotAllowed = errors.New("token audience does not contain a required audience")
+
+	// ErrUserInfoSubjectMismatch represents an error where a userinfo response describes a different subject than the token.
+	ErrUserInfoSubjectMismatch = errors.New("userinfo response subject does not match token subject")
+
 	// ErrInvalidClaimCondition represents an error where the claim condition expression is invalid.
 	ErrInvalidClaimCondition = errors.New("invalid claim condition expression")
+
+	// ErrInvalidScopeMapping represents an error where the scope mapping expression does not produce a list of strings.
+	ErrInvalidScopeMapping = errors.New("invalid scope mapping expression")
+
+	// ErrInvalidProfileMapping represents an error where the profile mapping expression does not produce a map of attributes.
+	ErrInvalidProfileMapping = errors.New("invalid profile mapping expression")
+
+	// ErrUnsupportedRequestedTokenType represents an error where the requested token type cannot be issued.
+	ErrUnsupportedRequestedTokenType = errors.New("unsupported requested token type")
+
+	// ErrUnexpectedTokenHeaderType represents an error where the "typ" header does not match the declared token type.
+	ErrUnexpectedTokenHeaderType = errors.New("token type header does not match declared token type")
+
+	// ErrIDTokensNotAccepted represents an error where the issuer has no client ID configured to validate ID tokens against.
+	ErrIDTokensNotAccepted = errors.New("issuer is not configured to accept ID tokens")
+
+	// ErrIDTokenAudienceMismatch represents an error where the ID token audience does not contain the issuer's client ID.
+	ErrIDTokenAudienceMismatch = errors.New("ID token audience does not match configured client ID")
 )
 
 // ErrMissingClaim represents an error where a required claim is missing.
@@ -0,0 +1,68 @@
+package rfc8693
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/ory/x/errorsx"
+
+	"go.infratographer.com/identity-api/internal/rfc7662"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// isJWT returns true if the given token has the shape of a JWS compact serialization.
+func isJWT(token string) bool {
+	return strings.Count(token, ".") == 2 //nolint:mnd
+}
+
+// introspectSubjectToken validates an opaque subject token using the introspection endpoint
+// of the given issuer. The introspected claims are checked against the policy of the issuer, as
+// for JWTs.
+func (s *TokenExchangeHandler) introspectSubjectToken(ctx context.Context, token, issuer string) (*jwt.JWTClaims, error) {
+	ctx, span := s.tracer.Start(ctx, "introspectSubjectToken")
+
+	defer span.End()
+
+	if len(issuer) == 0 {
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Missing required parameter '%s' for opaque subject token.", ParamSubjectTokenIssuer))
+	}
+
+	strategy := s.config.GetIntrospectionStrategy(ctx)
+	if strategy == nil {
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("Opaque subject tokens are not supported."))
+	}
+
+	claims, err := strategy.IntrospectToken(ctx, issuer, token)
+	if err == nil {
+		err = s.validateIntrospectedPolicy(ctx, claims)
+	}
+
+	switch {
+	case err == nil:
+		return claims, nil
+	case errors.Is(err, rfc7662.ErrIntrospectionFailed):
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithHintf("Unable to introspect subject token: %s", err))
+	default:
+		cause := types.ErrorInvalidTokenRequest{
+			Subject: map[string]string{
+				"issuer": issuer,
+			},
+		}
+
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Invalid subject token: %s", err).WithWrap(cause))
+	}
+}
+
+// validateIntrospectedPolicy checks introspected claims against the policy of their issuer.
+func (s *TokenExchangeHandler) validateIntrospectedPolicy(ctx context.Context, claims *jwt.JWTClaims) error {
+	issuer, err := s.config.GetIssuerStrategy(ctx).GetIssuerByURI(ctx, claims.Issuer)
+	if err != nil {
+		return err
+	}
+
+	return validateTokenPolicy(issuer, claims.ToMapClaims(), time.Now())
+}
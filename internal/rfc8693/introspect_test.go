@@ -0,0 +1,124 @@
+package rfc8693
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+type mockIntrospectionStrategy struct {
+	claims jwt.JWTClaims
+}
+
+func (s mockIntrospectionStrategy) IntrospectToken(_ context.Context, issuerURI, _ string) (*jwt.JWTClaims, error) {
+	claims := s.claims
+	claims.Issuer = issuerURI
+
+	return &claims, nil
+}
+
+type mockIssuerService struct {
+	types.IssuerService
+
+	issuer types.Issuer
+}
+
+func (s mockIssuerService) GetIssuerByURI(_ context.Context, _ string) (*types.Issuer, error) {
+	return &s.issuer, nil
+}
+
+// TestIntrospectSubjectToken checks that introspected subject tokens are validated against the
+// policy of their issuer.
+func TestIntrospectSubjectToken(t *testing.T) {
+	t.Parallel()
+
+	const issuerURI = "https://example.com/"
+
+	now := time.Now()
+
+	issuer := types.Issuer{
+		URI:               issuerURI,
+		RequiredAudiences: []string{"identity-api"},
+		MaxTokenAge:       time.Hour,
+	}
+
+	runFn := func(ctx context.Context, input jwt.JWTClaims) testingx.TestResult[*jwt.JWTClaims] {
+		handler := &TokenExchangeHandler{
+			tracer: otel.Tracer(instrumentationName),
+			config: &fositex.OAuth2Config{
+				IssuerStrategy:        mockIssuerService{issuer: issuer},
+				IntrospectionStrategy: mockIntrospectionStrategy{claims: input},
+			},
+		}
+
+		claims, err := handler.introspectSubjectToken(ctx, "opaque", issuerURI)
+
+		return testingx.TestResult[*jwt.JWTClaims]{
+			Success: claims,
+			Err:     err,
+		}
+	}
+
+	testCases := []testingx.TestCase[jwt.JWTClaims, *jwt.JWTClaims]{
+		{
+			Name: "Success",
+			Input: jwt.JWTClaims{
+				Subject:  "foo",
+				Audience: []string{"identity-api"},
+				IssuedAt: now.Add(-time.Minute),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[*jwt.JWTClaims]) {
+				require.NoError(t, result.Err)
+				assert.Equal(t, "foo", result.Success.Subject)
+				assert.Equal(t, issuerURI, result.Success.Issuer)
+			},
+		},
+		{
+			Name: "AudienceNotAllowed",
+			Input: jwt.JWTClaims{
+				Subject:  "foo",
+				Audience: []string{"another-app"},
+				IssuedAt: now.Add(-time.Minute),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[*jwt.JWTClaims]) {
+				assert.ErrorIs(t, result.Err, fosite.ErrInvalidRequest)
+				assert.Contains(t, fosite.ErrorToRFC6749Error(result.Err).HintField, ErrAudienceNotAllowed.Error())
+			},
+		},
+		{
+			Name: "TooOld",
+			Input: jwt.JWTClaims{
+				Subject:  "foo",
+				Audience: []string{"identity-api"},
+				IssuedAt: now.Add(-2 * time.Hour),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[*jwt.JWTClaims]) {
+				assert.ErrorIs(t, result.Err, fosite.ErrInvalidRequest)
+				assert.Contains(t, fosite.ErrorToRFC6749Error(result.Err).HintField, ErrTokenTooOld.Error())
+			},
+		},
+		{
+			Name: "MissingIat",
+			Input: jwt.JWTClaims{
+				Subject:  "foo",
+				Audience: []string{"identity-api"},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[*jwt.JWTClaims]) {
+				assert.ErrorIs(t, result.Err, fosite.ErrInvalidRequest)
+				assert.Contains(t, fosite.ErrorToRFC6749Error(result.Err).HintField, ErrMissingIat.Error())
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
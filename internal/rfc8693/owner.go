@@ -0,0 +1,21 @@
+package rfc8693
+
+import (
+	"context"
+
+	"github.com/ory/fosite"
+	"github.com/ory/x/errorsx"
+
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// getIssuer returns the issuer with the given URI. The issuer's owner is the home owner of subjects
+// authenticated by that issuer.
+func (s *TokenExchangeHandler) getIssuer(ctx context.Context, issuerURI string) (*types.Issuer, error) {
+	issuer, err := s.config.GetIssuerStrategy(ctx).GetIssuerByURI(ctx, issuerURI)
+	if err != nil {
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithHintf("unable to look up issuer: %s", err))
+	}
+
+	return issuer, nil
+}
@@ -0,0 +1,89 @@
+package rfc8693
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/ory/fosite/token/jwt"
+
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// timeValidationErrors are the validation errors reported for the time-based claims of a token.
+const timeValidationErrors = jwt.ValidationErrorExpired | jwt.ValidationErrorIssuedAt | jwt.ValidationErrorNotValidYet
+
+// isTimeValidationError returns true if the given error only reports invalid time-based claims. Claims
+// are validated after the token signature, so the signature of such tokens is valid.
+func isTimeValidationError(err error) bool {
+	var validationErr *jwt.ValidationError
+
+	if !errors.As(err, &validationErr) {
+		return false
+	}
+
+	return validationErr.Errors != 0 && validationErr.Errors&^timeValidationErrors == 0
+}
+
+// validateTokenPolicy checks the claims of a JWT with a valid signature against the policy of its
+// issuer: its time-based claims, allowing for the issuer's clock skew, its maximum age and its
+// required audiences.
+func validateTokenPolicy(issuer *types.Issuer, claims jwt.MapClaims, now time.Time) error {
+	skew := issuer.ClockSkew
+
+	if !claims.VerifyExpiresAt(now.Add(-skew).Unix(), false) {
+		return &jwt.ValidationError{
+			Errors: jwt.ValidationErrorExpired,
+			Inner:  ErrTokenExpired,
+		}
+	}
+
+	if !claims.VerifyNotBefore(now.Add(skew).Unix(), false) {
+		return &jwt.ValidationError{
+			Errors: jwt.ValidationErrorNotValidYet,
+			Inner:  ErrTokenNotValidYet,
+		}
+	}
+
+	if !claims.VerifyIssuedAt(now.Add(skew).Unix(), false) {
+		return &jwt.ValidationError{
+			Errors: jwt.ValidationErrorIssuedAt,
+			Inner:  ErrTokenUsedBeforeIssued,
+		}
+	}
+
+	var parsed jwt.JWTClaims
+
+	parsed.FromMapClaims(claims)
+
+	if issuer.MaxTokenAge > 0 {
+		if parsed.IssuedAt.IsZero() {
+			return &jwt.ValidationError{
+				Errors: jwt.ValidationErrorIssuedAt,
+				Inner:  ErrMissingIat,
+			}
+		}
+
+		if now.Sub(parsed.IssuedAt) > issuer.MaxTokenAge+skew {
+			return &jwt.ValidationError{
+				Errors: jwt.ValidationErrorIssuedAt,
+				Inner:  ErrTokenTooOld,
+			}
+		}
+	}
+
+	if len(issuer.RequiredAudiences) != 0 {
+		allowed := slices.ContainsFunc(issuer.RequiredAudiences, func(aud string) bool {
+			return claims.VerifyAudience(aud, true)
+		})
+
+		if !allowed {
+			return &jwt.ValidationError{
+				Errors: jwt.ValidationErrorAudience,
+				Inner:  ErrAudienceNotAllowed,
+			}
+		}
+	}
+
+	return nil
+}
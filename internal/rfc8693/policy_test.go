@@ -0,0 +1,191 @@
+package rfc8693
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// TestValidateTokenPolicy checks that token claims are validated against the policy of their issuer.
+func TestValidateTokenPolicy(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	type policyInput struct {
+		issuer types.Issuer
+		claims jwt.MapClaims
+	}
+
+	runFn := func(_ context.Context, input policyInput) testingx.TestResult[any] {
+		return testingx.TestResult[any]{
+			Err: validateTokenPolicy(&input.issuer, input.claims, now),
+		}
+	}
+
+	checkValid := func(_ context.Context, t *testing.T, result testingx.TestResult[any]) {
+		assert.NoError(t, result.Err)
+	}
+
+	checkErr := func(expected error) func(context.Context, *testing.T, testingx.TestResult[any]) {
+		return func(_ context.Context, t *testing.T, result testingx.TestResult[any]) {
+			var validationErr *jwt.ValidationError
+
+			require.ErrorAs(t, result.Err, &validationErr)
+			assert.ErrorIs(t, validationErr.Inner, expected)
+		}
+	}
+
+	testCases := []testingx.TestCase[policyInput, any]{
+		{
+			Name: "NoPolicy",
+			Input: policyInput{
+				claims: jwt.MapClaims{
+					"aud": "any",
+					"exp": now.Add(time.Minute).Unix(),
+				},
+			},
+			CheckFn: checkValid,
+		},
+		{
+			Name: "Expired",
+			Input: policyInput{
+				claims: jwt.MapClaims{
+					"exp": now.Add(-time.Minute).Unix(),
+				},
+			},
+			CheckFn: checkErr(ErrTokenExpired),
+		},
+		{
+			Name: "ExpiredWithinSkew",
+			Input: policyInput{
+				issuer: types.Issuer{
+					ClockSkew: 2 * time.Minute,
+				},
+				claims: jwt.MapClaims{
+					"exp": now.Add(-time.Minute).Unix(),
+				},
+			},
+			CheckFn: checkValid,
+		},
+		{
+			Name: "NotValidYet",
+			Input: policyInput{
+				claims: jwt.MapClaims{
+					"nbf": now.Add(time.Minute).Unix(),
+				},
+			},
+			CheckFn: checkErr(ErrTokenNotValidYet),
+		},
+		{
+			Name: "IssuedInFutureWithinSkew",
+			Input: policyInput{
+				issuer: types.Issuer{
+					ClockSkew: 2 * time.Minute,
+				},
+				claims: jwt.MapClaims{
+					"iat": now.Add(time.Minute).Unix(),
+					"nbf": now.Add(time.Minute).Unix(),
+				},
+			},
+			CheckFn: checkValid,
+		},
+		{
+			Name: "IssuedInFuture",
+			Input: policyInput{
+				claims: jwt.MapClaims{
+					"iat": now.Add(time.Minute).Unix(),
+				},
+			},
+			CheckFn: checkErr(ErrTokenUsedBeforeIssued),
+		},
+		{
+			Name: "MaxTokenAge",
+			Input: policyInput{
+				issuer: types.Issuer{
+					MaxTokenAge: time.Hour,
+				},
+				claims: jwt.MapClaims{
+					"iat": now.Add(-30 * time.Minute).Unix(),
+				},
+			},
+			CheckFn: checkValid,
+		},
+		{
+			Name: "TooOld",
+			Input: policyInput{
+				issuer: types.Issuer{
+					MaxTokenAge: time.Hour,
+				},
+				claims: jwt.MapClaims{
+					"iat": now.Add(-2 * time.Hour).Unix(),
+				},
+			},
+			CheckFn: checkErr(ErrTokenTooOld),
+		},
+		{
+			Name: "MissingIat",
+			Input: policyInput{
+				issuer: types.Issuer{
+					MaxTokenAge: time.Hour,
+				},
+				claims: jwt.MapClaims{},
+			},
+			CheckFn: checkErr(ErrMissingIat),
+		},
+		{
+			Name: "RequiredAudience",
+			Input: policyInput{
+				issuer: types.Issuer{
+					RequiredAudiences: []string{"identity-api", "other"},
+				},
+				claims: jwt.MapClaims{
+					"aud": []any{"some-app", "identity-api"},
+				},
+			},
+			CheckFn: checkValid,
+		},
+		{
+			Name: "AudienceNotAllowed",
+			Input: policyInput{
+				issuer: types.Issuer{
+					RequiredAudiences: []string{"identity-api"},
+				},
+				claims: jwt.MapClaims{
+					"aud": "some-app",
+				},
+			},
+			CheckFn: checkErr(ErrAudienceNotAllowed),
+		},
+		{
+			Name: "MissingAudience",
+			Input: policyInput{
+				issuer: types.Issuer{
+					RequiredAudiences: []string{"identity-api"},
+				},
+				claims: jwt.MapClaims{},
+			},
+			CheckFn: checkErr(ErrAudienceNotAllowed),
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
+
+// TestIsTimeValidationError checks that only errors for time-based claims are matched.
+func TestIsTimeValidationError(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isTimeValidationError(&jwt.ValidationError{Errors: jwt.ValidationErrorExpired}))
+	assert.True(t, isTimeValidationError(&jwt.ValidationError{Errors: jwt.ValidationErrorExpired | jwt.ValidationErrorIssuedAt}))
+	assert.False(t, isTimeValidationError(&jwt.ValidationError{Errors: jwt.ValidationErrorExpired | jwt.ValidationErrorSignatureInvalid}))
+	assert.False(t, isTimeValidationError(&jwt.ValidationError{}))
+	assert.False(t, isTimeValidationError(nil))
+}
@@ -0,0 +1,59 @@
+package rfc8693
+
+import (
+	"context"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/x/errorsx"
+
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+const responseRefreshToken = "refresh_token"
+
+// refreshTokenAllowed returns true if a refresh token should be issued alongside the exchanged token.
+// Refresh tokens are only issued to authenticated clients which are allowed the refresh token grant.
+func refreshTokenAllowed(requester fosite.AccessRequester) bool {
+	client := requester.GetClient()
+	if client == nil || len(client.GetID()) == 0 {
+		return false
+	}
+
+	return client.GetGrantTypes().Has(types.GrantTypeRefreshToken)
+}
+
+// refreshTokenExpiry returns the expiry of a refresh token issued now, or the zero time if refresh
+// tokens do not expire.
+func (s *TokenExchangeHandler) refreshTokenExpiry(ctx context.Context, requester fosite.AccessRequester) time.Time {
+	lifespan := fosite.GetEffectiveLifespan(requester.GetClient(), fosite.GrantTypeRefreshToken, fosite.RefreshToken, s.config.GetRefreshTokenLifespan(ctx))
+	if lifespan < 0 {
+		return time.Time{}
+	}
+
+	return time.Now().UTC().Add(lifespan).Round(time.Second)
+}
+
+// issueRefreshToken generates and stores a refresh token for the request, adding it to the response.
+// The stored request does not include the request form, so the subject and actor tokens are not persisted.
+func (s *TokenExchangeHandler) issueRefreshToken(ctx context.Context, requester fosite.AccessRequester, responder fosite.AccessResponder) error {
+	ctx, span := s.tracer.Start(ctx, "issueRefreshToken")
+
+	defer span.End()
+
+	token, signature, err := s.refreshTokenStrategy.GenerateRefreshToken(ctx, requester)
+	if err != nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	storeReq := requester.Sanitize([]string{})
+	storeReq.SetID(requester.GetID())
+
+	if err := s.refreshTokenStorage.CreateRefreshTokenSession(ctx, signature, "", storeReq); err != nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	responder.SetExtra(responseRefreshToken, token)
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package rfc8693
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// TestRefreshTokenAllowed checks that refresh tokens are only issued to authenticated clients allowed the refresh token grant.
+func TestRefreshTokenAllowed(t *testing.T) {
+	t.Parallel()
+
+	runFn := func(_ context.Context, input fosite.Client) testingx.TestResult[bool] {
+		request := fosite.NewAccessRequest(nil)
+		request.Client = input
+
+		return testingx.TestResult[bool]{
+			Success: refreshTokenAllowed(request),
+		}
+	}
+
+	testCases := []testingx.TestCase[fosite.Client, bool]{
+		{
+			Name:  "Unauthenticated",
+			Input: &fosite.DefaultClient{},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[bool]) {
+				assert.False(t, result.Success)
+			},
+		},
+		{
+			Name: "GrantNotAllowed",
+			Input: types.OAuthClient{
+				ID:         gidx.MustNewID(types.IdentityClientIDPrefix),
+				GrantTypes: types.DefaultOAuthClientGrantTypes(),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[bool]) {
+				assert.False(t, result.Success)
+			},
+		},
+		{
+			Name: "GrantAllowed",
+			Input: types.OAuthClient{
+				ID:         gidx.MustNewID(types.IdentityClientIDPrefix),
+				GrantTypes: []string{types.GrantTypeTokenExchange, types.GrantTypeRefreshToken},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[bool]) {
+				assert.True(t, result.Success)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
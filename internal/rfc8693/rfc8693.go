@@ -8,8 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/ory/fosite"
 	"github.com/ory/fosite/handler/oauth2"
 	"github.com/ory/fosite/token/jwt"
@@ -21,6 +23,7 @@ import (
 	"go.infratographer.com/x/gidx"
 
 	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/groups"
 	"go.infratographer.com/identity-api/internal/storage"
 	"go.infratographer.com/identity-api/internal/types"
 )
@@ -34,6 +37,9 @@ const (
 	ParamSubjectToken = "subject_token"
 	// ParamSubjectTokenType is the OAuth 2.0 request parameter for the subject token type.
 	ParamSubjectTokenType = "subject_token_type"
+	// ParamSubjectTokenIssuer is the request parameter identifying the issuer of an opaque subject token.
+	// It is not defined by RFC 8693, but is required to select the introspection endpoint for opaque tokens.
+	ParamSubjectTokenIssuer = "subject_token_issuer"
 	// ParamActorToken is the OAuth 2.0 request parameter for the actor token.
 	ParamActorToken = "actor_token"
 	// ParamActorTokenType is the OAuth 2.0 request parameter for the actor token type.
@@ -49,7 +55,10 @@ const (
 // ErrJWKSURIProviderNotDefined is returned when the issuer JWKS URI provider is not defined.
 var ErrJWKSURIProviderNotDefined = errors.New("no issuer JWKS URI provider defined")
 
-func findMatchingKey(ctx context.Context, config fositex.OAuth2Configurator, token *jwt.Token) (interface{}, error) {
+// findMatchingKey finds the key the token was signed with in the JWKS of its issuer. Only signing keys
+// whose algorithm matches the token's are used, and the token's algorithm must be one of the given
+// allowed algorithms, if any.
+func findMatchingKey(ctx context.Context, config fositex.OAuth2Configurator, token *jwt.Token, allowedAlgs []string) (interface{}, error) {
 	var claims jwt.JWTClaims
 
 	claims.FromMapClaims(token.Claims)
@@ -61,6 +70,15 @@ func findMatchingKey(ctx context.Context, config fositex.OAuth2Configurator, tok
 		}
 	}
 
+	alg := string(token.Method)
+
+	if len(allowedAlgs) != 0 && !slices.Contains(allowedAlgs, alg) {
+		return nil, &jwt.ValidationError{
+			Errors: jwt.ValidationErrorSignatureInvalid,
+			Inner:  fmt.Errorf("%w: %s", ErrAlgorithmNotAllowed, alg),
+		}
+	}
+
 	jwksURIProvider := config.GetIssuerJWKSURIProvider(ctx)
 	if jwksURIProvider == nil {
 		return nil, &jwt.ValidationError{
@@ -95,7 +113,7 @@ func findMatchingKey(ctx context.Context, config fositex.OAuth2Configurator, tok
 	keys := jwks.Key(kid)
 
 	for _, key := range keys {
-		if key.Use == "sig" {
+		if key.Use == "sig" && (key.Algorithm == "" || key.Algorithm == alg) {
 			return key, nil
 		}
 	}
@@ -110,14 +128,19 @@ func findMatchingKey(ctx context.Context, config fositex.OAuth2Configurator, tok
 // TokenExchangeHandler contains the logic for the token exchange grant type.
 // it implements the fosite.TokenEndpointHandler interface.
 type TokenExchangeHandler struct {
-	tracer              trace.Tracer
-	accessTokenStrategy oauth2.AccessTokenStrategy
-	accessTokenStorage  oauth2.AccessTokenStorage
-	config              fositex.OAuth2Configurator
+	tracer               trace.Tracer
+	accessTokenStrategy  oauth2.AccessTokenStrategy
+	accessTokenStorage   oauth2.AccessTokenStorage
+	refreshTokenStrategy oauth2.RefreshTokenStrategy
+	refreshTokenStorage  oauth2.RefreshTokenStorage
+	config               fositex.OAuth2Configurator
 }
 
-// implement the fosite.TokenEndpointHandler interface
-var _ fosite.TokenEndpointHandler = new(TokenExchangeHandler)
+// implement the fosite.TokenEndpointHandler and fositex.GrantTypeHandler interfaces
+var (
+	_ fosite.TokenEndpointHandler = new(TokenExchangeHandler)
+	_ fositex.GrantTypeHandler    = new(TokenExchangeHandler)
+)
 
 // NewTokenExchangeHandler works as a fositex.Factory to register this handler.
 var _ fositex.Factory = NewTokenExchangeHandler
@@ -127,20 +150,58 @@ func NewTokenExchangeHandler(config fositex.OAuth2Configurator, storage any, str
 	tracer := otel.Tracer(instrumentationName)
 
 	return &TokenExchangeHandler{
-		tracer:              tracer,
-		accessTokenStrategy: strategy.(oauth2.AccessTokenStrategy),
-		accessTokenStorage:  storage.(oauth2.AccessTokenStorage),
-		config:              config,
+		tracer:               tracer,
+		accessTokenStrategy:  strategy.(oauth2.AccessTokenStrategy),
+		accessTokenStorage:   storage.(oauth2.AccessTokenStorage),
+		refreshTokenStrategy: strategy.(oauth2.RefreshTokenStrategy),
+		refreshTokenStorage:  storage.(oauth2.RefreshTokenStorage),
+		config:               config,
 	}
 }
 
-func (s *TokenExchangeHandler) validateJWT(ctx context.Context, token string) (*jwt.Token, error) {
+// tokenDescription describes a token parameter for error hints and audit subjects.
+type tokenDescription struct {
+	name        string
+	auditPrefix string
+}
+
+var (
+	subjectTokenDescription = tokenDescription{
+		name: "subject token",
+	}
+
+	actorTokenDescription = tokenDescription{
+		name:        "actor token",
+		auditPrefix: "actor_",
+	}
+)
+
+func (s *TokenExchangeHandler) validateJWT(ctx context.Context, token string, desc tokenDescription) (*jwt.Token, error) {
+	var issuer *types.Issuer
+
 	// Side effectful key finding isn't great but neither is parsing the JWT twice
 	keyfunc := func(token *jwt.Token) (interface{}, error) {
-		return findMatchingKey(ctx, s.config, token)
+		var err error
+
+		issuer, err = s.getTokenIssuer(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+
+		return findMatchingKey(ctx, s.config, token, issuer.AllowedAlgorithms)
 	}
 
 	parsed, err := jwt.Parse(token, keyfunc)
+
+	// Time-based claims are checked again below, allowing for the issuer's clock skew.
+	if isTimeValidationError(err) {
+		err = nil
+	}
+
+	if err == nil {
+		err = validateTokenPolicy(issuer, parsed.Claims, time.Now())
+	}
+
 	if err == nil {
 		return parsed, nil
 	}
@@ -160,24 +221,69 @@ func (s *TokenExchangeHandler) validateJWT(ctx context.Context, token string) (*
 	default:
 		cause := types.ErrorInvalidTokenRequest{
 			Subject: map[string]string{
-				"issuer":  claims.Issuer,
-				"subject": claims.Subject,
+				desc.auditPrefix + "issuer":  claims.Issuer,
+				desc.auditPrefix + "subject": claims.Subject,
 			},
 		}
 
-		fositeErr := fosite.ErrInvalidRequest.WithHintf("Invalid subject token: %s", err).WithWrap(cause)
+		fositeErr := fosite.ErrInvalidRequest.WithHintf("Invalid %s: %s", desc.name, err).WithWrap(cause)
 		stackErr := errorsx.WithStack(fositeErr)
 
 		return nil, stackErr
 	}
 }
 
-func (s *TokenExchangeHandler) getSubjectClaims(ctx context.Context, token string) (*jwt.JWTClaims, error) {
+// getTokenIssuer looks up the issuer of the given unverified token.
+func (s *TokenExchangeHandler) getTokenIssuer(ctx context.Context, token *jwt.Token) (*types.Issuer, error) {
+	iss, _ := token.Claims["iss"].(string)
+	if len(iss) == 0 {
+		return nil, &jwt.ValidationError{
+			Errors: jwt.ValidationErrorIssuer,
+		}
+	}
+
+	issuer, err := s.config.GetIssuerStrategy(ctx).GetIssuerByURI(ctx, iss)
+	if err != nil {
+		return nil, &jwt.ValidationError{
+			Errors: jwt.ValidationErrorIssuer,
+			Inner:  err,
+		}
+	}
+
+	return issuer, nil
+}
+
+func (s *TokenExchangeHandler) getSubjectClaims(ctx context.Context, token, tokenType, issuer string) (*jwt.JWTClaims, error) {
 	ctx, span := s.tracer.Start(ctx, "getSubjectClaims")
 
 	defer span.End()
 
-	validated, err := s.validateJWT(ctx, token)
+	if tokenType == TokenTypeAccessToken && !isJWT(token) {
+		return s.introspectSubjectToken(ctx, token, issuer)
+	}
+
+	validated, err := s.validateJWT(ctx, token, subjectTokenDescription)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims jwt.JWTClaims
+
+	claims.FromMapClaims(validated.Claims)
+
+	if err := s.validateSubjectTokenType(ctx, tokenType, validated, &claims); err != nil {
+		return nil, err
+	}
+
+	return &claims, nil
+}
+
+func (s *TokenExchangeHandler) getActorClaims(ctx context.Context, token string) (*jwt.JWTClaims, error) {
+	ctx, span := s.tracer.Start(ctx, "getActorClaims")
+
+	defer span.End()
+
+	validated, err := s.validateJWT(ctx, token, actorTokenDescription)
 	if err != nil {
 		return nil, err
 	}
@@ -186,9 +292,80 @@ func (s *TokenExchangeHandler) getSubjectClaims(ctx context.Context, token strin
 
 	claims.FromMapClaims(validated.Claims)
 
+	if len(claims.Subject) == 0 {
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Invalid actor token: %s", ErrMissingSub))
+	}
+
 	return &claims, nil
 }
 
+// validateActor checks the actor token given in the request, if any, and returns its claims.
+// If no actor token was provided, nil claims are returned.
+func (s *TokenExchangeHandler) validateActor(ctx context.Context, form url.Values, subjectClaims *jwt.JWTClaims) (*jwt.JWTClaims, error) {
+	actorToken := form.Get(ParamActorToken)
+	actorTokenType := form.Get(ParamActorTokenType)
+
+	if len(actorToken) == 0 {
+		if len(actorTokenType) > 0 {
+			return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Parameter '%s' must not be set without '%s'.", ParamActorTokenType, ParamActorToken))
+		}
+
+		return nil, nil
+	}
+
+	if len(actorTokenType) == 0 {
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Missing required parameter '%s'.", ParamActorTokenType))
+	}
+
+	switch actorTokenType {
+	case TokenTypeJWT:
+		break
+	default:
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Unsupported actor token type '%s'.", actorTokenType))
+	}
+
+	actorClaims, err := s.getActorClaims(ctx, actorToken)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := s.config.GetClaimConditionStrategy(ctx).Eval(ctx, actorClaims)
+	if err != nil {
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("error evaluating actor claim conditions: %s", err))
+	}
+
+	if !ok {
+		cause := types.ErrorInvalidTokenRequest{
+			Subject: map[string]string{
+				"actor_issuer":  actorClaims.Issuer,
+				"actor_subject": actorClaims.Subject,
+			},
+		}
+
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("actor claim conditions not satisfied").WithWrap(cause))
+	}
+
+	allowed, err := s.config.GetActorConditionStrategy(ctx).Eval(ctx, subjectClaims, actorClaims)
+	if err != nil {
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("error evaluating actor conditions: %s", err))
+	}
+
+	if !allowed {
+		cause := types.ErrorInvalidTokenRequest{
+			Subject: map[string]string{
+				"issuer":        subjectClaims.Issuer,
+				"subject":       subjectClaims.Subject,
+				"actor_issuer":  actorClaims.Issuer,
+				"actor_subject": actorClaims.Subject,
+			},
+		}
+
+		return nil, errorsx.WithStack(fosite.ErrAccessDenied.WithHint("The actor is not permitted to act on behalf of the subject.").WithWrap(cause))
+	}
+
+	return actorClaims, nil
+}
+
 func (s *TokenExchangeHandler) getMappedSubjectClaims(ctx context.Context, claims *jwt.JWTClaims) (jwt.JWTClaimsContainer, error) {
 	ctx, span := s.tracer.Start(ctx, "getMappedSubjectClaims")
 
@@ -205,7 +382,9 @@ func (s *TokenExchangeHandler) getMappedSubjectClaims(ctx context.Context, claim
 }
 
 // HandleTokenEndpointRequest handles a RFC 8693 token request and provides a response that can be used to
-// generate a token. Currently only supports JWT subject tokens and impersonation semantics.
+// generate a token. Subject tokens may be JWTs, JWT access tokens or ID tokens; actor tokens must be JWTs. If an actor token is given,
+// the issued token describes the actor in an RFC 8693 act claim (delegation semantics); otherwise
+// the issued token impersonates the subject.
 func (s *TokenExchangeHandler) HandleTokenEndpointRequest(ctx context.Context, requester fosite.AccessRequester) error {
 	ctx, span := s.tracer.Start(ctx, "HandleTokenEndpointRequest")
 
@@ -213,6 +392,10 @@ func (s *TokenExchangeHandler) HandleTokenEndpointRequest(ctx context.Context, r
 
 	form := requester.GetRequestForm()
 
+	if client := requester.GetClient(); client != nil && len(client.GetID()) > 0 && !client.GetGrantTypes().Has(GrantTypeTokenExchange) {
+		return errorsx.WithStack(fosite.ErrUnauthorizedClient.WithHintf("The OAuth 2.0 Client is not allowed to use authorization grant '%s'.", GrantTypeTokenExchange))
+	}
+
 	subjectToken := form.Get(ParamSubjectToken)
 	if len(subjectToken) == 0 {
 		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Missing required parameter '%s'.", ParamSubjectToken))
@@ -223,23 +406,38 @@ func (s *TokenExchangeHandler) HandleTokenEndpointRequest(ctx context.Context, r
 		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Missing required parameter '%s'.", ParamSubjectTokenType))
 	}
 
-	actorToken := form.Get(ParamActorToken)
-	if len(actorToken) > 0 {
-		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Delegation is not supported by this handler."))
+	if !supportedSubjectTokenType(subjectTokenType) {
+		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Unsupported subject token type '%s'.", subjectTokenType))
 	}
 
-	switch subjectTokenType {
-	case TokenTypeJWT:
-		break
-	default:
-		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Unsupported subject token type '%s'.", subjectTokenType))
+	requestedTokenType := form.Get(ParamRequestedTokenType)
+	if _, err := issuedTokenType(requestedTokenType, subjectTokenType); err != nil {
+		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Unsupported requested token type '%s'.", requestedTokenType))
+	}
+
+	claims, err := s.getSubjectClaims(ctx, subjectToken, subjectTokenType, form.Get(ParamSubjectTokenIssuer))
+	if err != nil {
+		return err
 	}
 
-	claims, err := s.getSubjectClaims(ctx, subjectToken)
+	actorClaims, err := s.validateActor(ctx, form, claims)
 	if err != nil {
 		return err
 	}
 
+	if actorClaims != nil {
+		span.SetAttributes(
+			attribute.String(
+				"actor_claims.iss",
+				actorClaims.Issuer,
+			),
+			attribute.String(
+				"actor_claims.sub",
+				actorClaims.Subject,
+			),
+		)
+	}
+
 	// Set JWT claims as attributes if we have them
 	span.SetAttributes(
 		attribute.String(
@@ -261,6 +459,19 @@ func (s *TokenExchangeHandler) HandleTokenEndpointRequest(ctx context.Context, r
 		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("claim conditions not satisfied"))
 	}
 
+	issuer, err := s.getIssuer(ctx, claims.Issuer)
+	if err != nil {
+		return err
+	}
+
+	if err := s.grantAudiences(ctx, requester, issuer, claims.Subject); err != nil {
+		return err
+	}
+
+	if err := s.grantScopes(ctx, requester, claims); err != nil {
+		return err
+	}
+
 	mappedClaims, err := s.getMappedSubjectClaims(ctx, claims)
 	if err != nil {
 		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("error mapping claims: %s", err))
@@ -286,11 +497,32 @@ func (s *TokenExchangeHandler) HandleTokenEndpointRequest(ctx context.Context, r
 		return errorsx.WithStack(fosite.ErrServerError.WithHint("could not start transaction"))
 	}
 
-	userInfo, err := s.populateUserInfo(dbCtx, &mappedSubjectClaim)
+	// The user info and the record of the issued access token are committed together at the end
+	// of the request, and rolled back if the request fails before that.
+	committed := false
+
+	defer func() {
+		if !committed {
+			_ = txManager.RollbackContext(dbCtx)
+		}
+	}()
+
+	userInfo, storedUserInfo, err := s.populateUserInfo(dbCtx, issuer, claims, mappedSubjectClaim.Subject, subjectToken, subjectTokenType)
 	if err != nil {
 		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("unable to populate user info: %s", err))
 	}
 
+	if storedUserInfo != nil && storedUserInfo.Disabled {
+		cause := types.ErrorInvalidTokenRequest{
+			Subject: map[string]string{
+				"issuer":  claims.Issuer,
+				"subject": claims.Subject,
+			},
+		}
+
+		return errorsx.WithStack(fosite.ErrAccessDenied.WithHint("The user is disabled.").WithWrap(cause))
+	}
+
 	if subOverride, ok := mappedClaims.ToMapClaims()["identity-api.infratographer.com/sub"]; ok && subOverride != nil && subOverride.(string) != "" {
 		issHash := sha256.Sum256([]byte(userInfo.Issuer))
 
@@ -306,17 +538,12 @@ func (s *TokenExchangeHandler) HandleTokenEndpointRequest(ctx context.Context, r
 
 	userInfo, err = userInfoSvc.StoreUserInfo(dbCtx, userInfo)
 	if err != nil {
-		rbErr := txManager.RollbackContext(dbCtx)
-		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("unable to store user info: %s / rollback error: %s", err, rbErr))
-	}
-
-	err = txManager.CommitContext(dbCtx)
-	if err != nil {
-		return errorsx.WithStack(fosite.ErrServerError.WithHintf("could not commit user info: %s", err))
+		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("unable to store user info: %s", err))
 	}
 
 	var newClaims jwt.JWTClaims
 
+	newClaims.JTI = uuid.NewString()
 	newClaims.Subject = userInfo.ID.String()
 	newClaims.Issuer = s.config.GetAccessTokenIssuer(ctx)
 
@@ -326,11 +553,36 @@ func (s *TokenExchangeHandler) HandleTokenEndpointRequest(ctx context.Context, r
 		}
 	}
 
+	if actorClaims != nil {
+		newClaims.Add(ClaimActor, newActorClaim(claims, actorClaims))
+	}
+
+	homeOwnerID := issuer.OwnerID
+
+	ownerID, err := groups.RequestedOwner(ctx, s.config.GetOwnerAccessStrategy(ctx), form.Get(fositex.ParamOwnerID), userInfo.ID, homeOwnerID)
+	if err != nil {
+		return err
+	}
+
+	if ownerID != "" {
+		newClaims.Add(fositex.ClaimOwnerID, ownerID.String())
+	}
+
+	if err := groups.AddClaim(ctx, s.config.GetGroupsClaimStrategy(ctx), &newClaims, userInfo.ID, homeOwnerID, ownerID); err != nil {
+		return err
+	}
+
 	expiry := time.Now().Add(s.config.GetAccessTokenLifespan(ctx))
 	expiryMap := map[fosite.TokenType]time.Time{
 		fosite.AccessToken: expiry,
 	}
 
+	if refreshTokenAllowed(requester) {
+		if refreshExpiry := s.refreshTokenExpiry(ctx, requester); !refreshExpiry.IsZero() {
+			expiryMap[fosite.RefreshToken] = refreshExpiry
+		}
+	}
+
 	var clientID *string
 
 	maybeClientID := requester.GetClient().GetID()
@@ -340,7 +592,7 @@ func (s *TokenExchangeHandler) HandleTokenEndpointRequest(ctx context.Context, r
 
 	newClaims.Add(ClaimClientID, clientID)
 
-	kid := s.config.GetSigningKey(ctx).KeyID
+	kid := s.config.GetSigner(ctx).Public().KeyID
 
 	headers := jwt.Headers{}
 	headers.Add("kid", kid)
@@ -387,10 +639,27 @@ func (s *TokenExchangeHandler) HandleTokenEndpointRequest(ctx context.Context, r
 	requester.GrantAudience(userInfoAud)
 	requester.SetSession(session)
 
+	if err := s.accessTokenStorage.CreateAccessTokenSession(dbCtx, newClaims.JTI, requester.Sanitize([]string{})); err != nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithHintf("could not store access token: %s", err))
+	}
+
+	if err := txManager.CommitContext(dbCtx); err != nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithHintf("could not commit user info: %s", err))
+	}
+
+	committed = true
+
+	if storedUserInfo != nil {
+		s.publishUserUpdated(ctx, *storedUserInfo, userInfo)
+	}
+
+	s.recordLastSeen(ctx, userInfo.ID, maybeClientID)
+
 	return nil
 }
 
-// PopulateTokenEndpointResponse populates the response with a token.
+// PopulateTokenEndpointResponse populates the response with a token. If the authenticated client is allowed
+// the refresh token grant, a refresh token is issued as well.
 func (s *TokenExchangeHandler) PopulateTokenEndpointResponse(ctx context.Context, requester fosite.AccessRequester, responder fosite.AccessResponder) error {
 	ctx, span := s.tracer.Start(ctx, "PopulateTokenEndpointResponse")
 
@@ -400,16 +669,30 @@ func (s *TokenExchangeHandler) PopulateTokenEndpointResponse(ctx context.Context
 		return errorsx.WithStack(fosite.ErrUnknownRequest)
 	}
 
+	form := requester.GetRequestForm()
+
+	tokenType, err := issuedTokenType(form.Get(ParamRequestedTokenType), form.Get(ParamSubjectTokenType))
+	if err != nil {
+		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHint(err.Error()))
+	}
+
 	token, _, err := s.accessTokenStrategy.GenerateAccessToken(ctx, requester)
 	if err != nil {
 		return err
 	}
 
 	responder.SetAccessToken(token)
-	responder.SetExtra(responseIssuedTokenType, TokenTypeJWT)
+	responder.SetExtra(responseIssuedTokenType, tokenType)
+	responder.SetScopes(requester.GetGrantedScopes())
 	responder.SetTokenType(fosite.BearerAccessToken)
 	responder.SetExpiresIn(s.config.GetAccessTokenLifespan(ctx))
 
+	if refreshTokenAllowed(requester) {
+		if err := s.issueRefreshToken(ctx, requester, responder); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -423,33 +706,7 @@ func (s *TokenExchangeHandler) CanHandleTokenEndpointRequest(_ context.Context,
 	return requester.GetGrantTypes().ExactOne(GrantTypeTokenExchange)
 }
 
-func (s *TokenExchangeHandler) populateUserInfo(ctx context.Context, claims *jwt.JWTClaims) (types.UserInfo, error) {
-	ctx, span := s.tracer.Start(ctx, "populateUserInfo")
-
-	defer span.End()
-
-	userInfoSvc := s.config.GetUserInfoStrategy(ctx)
-
-	userInfo, err := userInfoSvc.LookupUserInfoByClaims(ctx, claims.Issuer, claims.Subject)
-	if err != nil {
-		// We can handle ErrUserInfoNotFound by hitting the
-		// issuers userinfo endpoint, but if some other error
-		// came back bail.
-		if !errors.Is(err, types.ErrUserInfoNotFound) {
-			fmt.Println("couldn't find issuer in lookup")
-			return types.UserInfo{}, err
-		}
-	} else {
-		return userInfo, nil
-	}
-
-	claimsMap := claims.ToMap()
-
-	userInfo, err = userInfoSvc.ParseUserInfoFromClaims(claimsMap)
-	if err != nil {
-		fmt.Println("failed to fetch userinfo")
-		return types.UserInfo{}, err
-	}
-
-	return userInfo, nil
+// GrantType returns the grant type handled by this handler.
+func (s *TokenExchangeHandler) GrantType() string {
+	return GrantTypeTokenExchange
 }
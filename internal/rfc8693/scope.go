@@ -0,0 +1,92 @@
+package rfc8693
+
+import (
+	"context"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/ory/x/errorsx"
+	"go.opentelemetry.io/otel/attribute"
+
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// grantableScopes returns the scopes to grant for the given requested scopes. Each requested scope must
+// be allowed by every allowlist. If no scopes were requested, every scope in the first allowlist which
+// is allowed by the remaining allowlists is granted.
+func grantableScopes(strategy fosite.ScopeStrategy, requested []string, allowlists ...[]string) ([]string, error) {
+	if len(allowlists) == 0 {
+		return requested, nil
+	}
+
+	allowed := func(scope string) bool {
+		for _, allowlist := range allowlists {
+			if !strategy(allowlist, scope) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if len(requested) == 0 {
+		out := []string{}
+
+		for _, scope := range allowlists[0] {
+			if allowed(scope) {
+				out = append(out, scope)
+			}
+		}
+
+		return out, nil
+	}
+
+	for _, scope := range requested {
+		if !allowed(scope) {
+			return nil, errorsx.WithStack(fosite.ErrInvalidScope.WithHintf("The requested scope '%s' is not allowed.", scope))
+		}
+	}
+
+	return requested, nil
+}
+
+// grantScopes grants the requested scopes, or all scopes mapped from the subject claims if none were requested.
+// Requested scopes must be mapped from the subject claims by the issuer's scope mapping and, if a client
+// authenticated, be allowed for that client.
+func (s *TokenExchangeHandler) grantScopes(ctx context.Context, requester fosite.AccessRequester, claims *jwt.JWTClaims) error {
+	ctx, span := s.tracer.Start(ctx, "grantScopes")
+
+	defer span.End()
+
+	mapped, err := s.config.GetScopeMappingStrategy(ctx).MapScopes(ctx, claims)
+	if err != nil {
+		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("error mapping scopes: %s", err))
+	}
+
+	allowlists := [][]string{mapped}
+
+	client := requester.GetClient()
+	if client != nil && len(client.GetID()) > 0 {
+		allowlists = append(allowlists, client.GetScopes())
+	}
+
+	scopes, err := grantableScopes(s.config.GetScopeStrategy(ctx), requester.GetRequestedScopes(), allowlists...)
+	if err != nil {
+		cause := types.ErrorInvalidTokenRequest{
+			Subject: map[string]string{
+				"issuer":  claims.Issuer,
+				"subject": claims.Subject,
+			},
+		}
+
+		return errorsx.WithStack(fosite.ErrorToRFC6749Error(err).WithWrap(cause))
+	}
+
+	for _, scope := range scopes {
+		requester.GrantScope(scope)
+	}
+
+	span.SetAttributes(attribute.StringSlice("scopes", scopes))
+
+	return nil
+}
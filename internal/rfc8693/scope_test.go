@@ -0,0 +1,111 @@
+package rfc8693
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+)
+
+// TestGrantableScopes checks that requested scopes are checked against every allowlist.
+func TestGrantableScopes(t *testing.T) {
+	t.Parallel()
+
+	type scopeInput struct {
+		requested  []string
+		allowlists [][]string
+	}
+
+	runFn := func(_ context.Context, input scopeInput) testingx.TestResult[[]string] {
+		out, err := grantableScopes(fosite.WildcardScopeStrategy, input.requested, input.allowlists...)
+
+		return testingx.TestResult[[]string]{
+			Success: out,
+			Err:     err,
+		}
+	}
+
+	testCases := []testingx.TestCase[scopeInput, []string]{
+		{
+			Name: "AllMappedScopes",
+			Input: scopeInput{
+				allowlists: [][]string{
+					{"read", "write"},
+				},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				assert.NoError(t, result.Err)
+				assert.Equal(t, []string{"read", "write"}, result.Success)
+			},
+		},
+		{
+			Name: "MappedScopesRestrictedByClient",
+			Input: scopeInput{
+				allowlists: [][]string{
+					{"read", "write"},
+					{"read"},
+				},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				assert.NoError(t, result.Err)
+				assert.Equal(t, []string{"read"}, result.Success)
+			},
+		},
+		{
+			Name: "RequestedSubset",
+			Input: scopeInput{
+				requested: []string{"write"},
+				allowlists: [][]string{
+					{"read", "write"},
+				},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				assert.NoError(t, result.Err)
+				assert.Equal(t, []string{"write"}, result.Success)
+			},
+		},
+		{
+			Name: "RequestedWildcard",
+			Input: scopeInput{
+				requested: []string{"tenant.read"},
+				allowlists: [][]string{
+					{"tenant.*"},
+				},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				assert.NoError(t, result.Err)
+				assert.Equal(t, []string{"tenant.read"}, result.Success)
+			},
+		},
+		{
+			Name: "RequestedNotMapped",
+			Input: scopeInput{
+				requested: []string{"admin"},
+				allowlists: [][]string{
+					{"read", "write"},
+				},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				assert.ErrorIs(t, result.Err, fosite.ErrInvalidScope)
+			},
+		},
+		{
+			Name: "RequestedNotAllowedForClient",
+			Input: scopeInput{
+				requested: []string{"write"},
+				allowlists: [][]string{
+					{"read", "write"},
+					{"read"},
+				},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[[]string]) {
+				assert.ErrorIs(t, result.Err, fosite.ErrInvalidScope)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
@@ -0,0 +1,120 @@
+package rfc8693
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/ory/x/errorsx"
+
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+const (
+	// TokenTypeAccessToken is the token type for OAuth 2.0 access tokens per RFC 8693.
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	// TokenTypeIDToken is the token type for OpenID Connect ID tokens per RFC 8693.
+	TokenTypeIDToken = "urn:ietf:params:oauth:token-type:id_token"
+	// ParamRequestedTokenType is the OAuth 2.0 request parameter for the requested token type.
+	ParamRequestedTokenType = "requested_token_type"
+
+	headerType = "typ"
+)
+
+// accessTokenHeaderTypes are the values of the "typ" header accepted for JWT access tokens.
+// RFC 9068 requires "at+jwt", but many providers still issue access tokens typed as plain JWTs.
+var accessTokenHeaderTypes = []string{"at+jwt", "application/at+jwt", "jwt"}
+
+// supportedSubjectTokenType returns true if the given token type may be used as a subject token type.
+func supportedSubjectTokenType(tokenType string) bool {
+	switch tokenType {
+	case TokenTypeJWT, TokenTypeAccessToken, TokenTypeIDToken:
+		return true
+	default:
+		return false
+	}
+}
+
+// issuedTokenType returns the token type to report in the token response, given the requested
+// token type and subject token type. Issued tokens are always JWT access tokens, so either of
+// those types may be requested. If no type is requested, access token subject tokens produce
+// access tokens and everything else produces JWTs.
+func issuedTokenType(requestedTokenType, subjectTokenType string) (string, error) {
+	switch requestedTokenType {
+	case TokenTypeJWT, TokenTypeAccessToken:
+		return requestedTokenType, nil
+	case "":
+	default:
+		return "", ErrUnsupportedRequestedTokenType
+	}
+
+	if subjectTokenType == TokenTypeAccessToken {
+		return TokenTypeAccessToken, nil
+	}
+
+	return TokenTypeJWT, nil
+}
+
+// validateSubjectTokenType performs checks specific to the declared type of the subject token,
+// after the token signature and standard claims have been validated.
+func (s *TokenExchangeHandler) validateSubjectTokenType(ctx context.Context, tokenType string, token *jwt.Token, claims *jwt.JWTClaims) error {
+	var err error
+
+	switch tokenType {
+	case TokenTypeAccessToken:
+		err = validateAccessTokenType(token)
+	case TokenTypeIDToken:
+		err = s.validateIDTokenType(ctx, token, claims)
+	default:
+		return nil
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	cause := types.ErrorInvalidTokenRequest{
+		Subject: map[string]string{
+			"issuer":  claims.Issuer,
+			"subject": claims.Subject,
+		},
+	}
+
+	return errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Invalid subject token: %s", err).WithWrap(cause))
+}
+
+func validateAccessTokenType(token *jwt.Token) error {
+	typ, ok := token.Header[headerType].(string)
+	if !ok || len(typ) == 0 {
+		return nil
+	}
+
+	if !slices.Contains(accessTokenHeaderTypes, strings.ToLower(typ)) {
+		return ErrUnexpectedTokenHeaderType
+	}
+
+	return nil
+}
+
+func (s *TokenExchangeHandler) validateIDTokenType(ctx context.Context, token *jwt.Token, claims *jwt.JWTClaims) error {
+	if typ, ok := token.Header[headerType].(string); ok && strings.HasSuffix(strings.ToLower(typ), "at+jwt") {
+		return ErrUnexpectedTokenHeaderType
+	}
+
+	issuer, err := s.config.GetIssuerStrategy(ctx).GetIssuerByURI(ctx, claims.Issuer)
+	if err != nil {
+		return err
+	}
+
+	if len(issuer.ClientID) == 0 {
+		return ErrIDTokensNotAccepted
+	}
+
+	if !slices.Contains(claims.Audience, issuer.ClientID) {
+		return ErrIDTokenAudienceMismatch
+	}
+
+	return nil
+}
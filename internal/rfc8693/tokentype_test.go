@@ -0,0 +1,138 @@
+package rfc8693
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+)
+
+// TestIssuedTokenType checks that the issued token type follows the requested and subject token types.
+func TestIssuedTokenType(t *testing.T) {
+	t.Parallel()
+
+	type tokenTypeInput struct {
+		requested string
+		subject   string
+	}
+
+	runFn := func(_ context.Context, input tokenTypeInput) testingx.TestResult[string] {
+		out, err := issuedTokenType(input.requested, input.subject)
+
+		return testingx.TestResult[string]{
+			Success: out,
+			Err:     err,
+		}
+	}
+
+	testCases := []testingx.TestCase[tokenTypeInput, string]{
+		{
+			Name: "DefaultJWT",
+			Input: tokenTypeInput{
+				subject: TokenTypeJWT,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[string]) {
+				assert.NoError(t, result.Err)
+				assert.Equal(t, TokenTypeJWT, result.Success)
+			},
+		},
+		{
+			Name: "DefaultIDToken",
+			Input: tokenTypeInput{
+				subject: TokenTypeIDToken,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[string]) {
+				assert.NoError(t, result.Err)
+				assert.Equal(t, TokenTypeJWT, result.Success)
+			},
+		},
+		{
+			Name: "DefaultAccessToken",
+			Input: tokenTypeInput{
+				subject: TokenTypeAccessToken,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[string]) {
+				assert.NoError(t, result.Err)
+				assert.Equal(t, TokenTypeAccessToken, result.Success)
+			},
+		},
+		{
+			Name: "RequestedAccessToken",
+			Input: tokenTypeInput{
+				requested: TokenTypeAccessToken,
+				subject:   TokenTypeIDToken,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[string]) {
+				assert.NoError(t, result.Err)
+				assert.Equal(t, TokenTypeAccessToken, result.Success)
+			},
+		},
+		{
+			Name: "RequestedIDToken",
+			Input: tokenTypeInput{
+				requested: TokenTypeIDToken,
+				subject:   TokenTypeIDToken,
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[string]) {
+				assert.ErrorIs(t, result.Err, ErrUnsupportedRequestedTokenType)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
+
+// TestValidateAccessTokenType checks that the typ header of access tokens is validated.
+func TestValidateAccessTokenType(t *testing.T) {
+	t.Parallel()
+
+	runFn := func(_ context.Context, header map[string]any) testingx.TestResult[any] {
+		err := validateAccessTokenType(&jwt.Token{Header: header})
+
+		return testingx.TestResult[any]{
+			Err: err,
+		}
+	}
+
+	testCases := []testingx.TestCase[map[string]any, any]{
+		{
+			Name:  "NoHeader",
+			Input: map[string]any{},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[any]) {
+				assert.NoError(t, result.Err)
+			},
+		},
+		{
+			Name: "ATJWT",
+			Input: map[string]any{
+				"typ": "at+jwt",
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[any]) {
+				assert.NoError(t, result.Err)
+			},
+		},
+		{
+			Name: "PlainJWT",
+			Input: map[string]any{
+				"typ": "JWT",
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[any]) {
+				assert.NoError(t, result.Err)
+			},
+		},
+		{
+			Name: "Logout",
+			Input: map[string]any{
+				"typ": "logout+jwt",
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[any]) {
+				assert.ErrorIs(t, result.Err, ErrUnexpectedTokenHeaderType)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
@@ -0,0 +1,282 @@
+package rfc8693
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"time"
+
+	"github.com/ory/fosite/token/jwt"
+	"go.infratographer.com/x/gidx"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"go.infratographer.com/identity-api/internal/celutils"
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+const (
+	claimName  = "name"
+	claimEmail = "email"
+
+	// userEventTimeout bounds the time spent publishing a user event.
+	userEventTimeout = 10 * time.Second
+)
+
+// populateUserInfo builds the user info for the subject of the given claims, identified by the given
+// (possibly mapped) subject, and returns it along with the stored user info, if any. Profile data is
+// taken from the token claims first. If the claims lack it and the stored copy is missing or stale,
+// it is then fetched from the issuer's userinfo endpoint. Attributes are produced from the claims by
+// the issuer's profile mapping. The stored copy is then updated according to the issuer's user info
+// update mode. A failure to fetch user info does not fail the exchange.
+func (s *TokenExchangeHandler) populateUserInfo(
+	ctx context.Context,
+	issuer *types.Issuer,
+	claims *jwt.JWTClaims,
+	subject, subjectToken, subjectTokenType string,
+) (types.UserInfo, *types.UserInfo, error) {
+	ctx, span := s.tracer.Start(ctx, "populateUserInfo")
+
+	defer span.End()
+
+	userInfoSvc := s.config.GetUserInfoStrategy(ctx)
+
+	mappedClaims := *claims
+	mappedClaims.Subject = subject
+
+	userInfo, err := userInfoSvc.ParseUserInfoFromClaims(mappedClaims.ToMap())
+	if err != nil {
+		return types.UserInfo{}, nil, err
+	}
+
+	userInfo.Attributes, err = mapProfileAttributes(issuer, claims)
+	if err != nil {
+		return types.UserInfo{}, nil, err
+	}
+
+	var stored *types.UserInfo
+
+	found, err := userInfoSvc.LookupUserInfoByClaims(ctx, claims.Issuer, subject)
+
+	switch {
+	case err == nil:
+		stored = &found
+	case !errors.Is(err, types.ErrUserInfoNotFound):
+		return types.UserInfo{}, nil, err
+	}
+
+	mode := issuer.UserInfoUpdateMode.OrDefault()
+
+	span.SetAttributes(attribute.String("user_info_update_mode", string(mode)))
+
+	fetchStrategy := s.config.GetUserInfoFetchStrategy(ctx)
+
+	// ID tokens are not accepted by userinfo endpoints, which expect access tokens. The profiles of
+	// existing users are not fetched if they are never updated.
+	fetchable := fetchStrategy != nil && issuer.UserInfoURI != "" && subjectTokenType != TokenTypeIDToken &&
+		(stored == nil || mode != types.UserInfoUpdateModeNever)
+
+	// complete records whether userInfo holds the whole current profile, rather than only the
+	// values found in the token claims.
+	complete := !fetchable
+
+	now := time.Now()
+
+	if fetchable && userInfoNeedsRefresh(userInfo, stored, now, s.config.GetUserInfoRefreshInterval(ctx)) {
+		fetched, err := s.fetchUserInfo(ctx, fetchStrategy, issuer, claims.Subject, subjectToken, now)
+		if err != nil {
+			span.RecordError(err)
+		} else {
+			userInfo = mergeUserInfo(userInfo, fetched)
+			complete = true
+		}
+	}
+
+	if stored != nil {
+		userInfo = updateUserInfo(mode, *stored, userInfo, complete)
+	}
+
+	return userInfo, stored, nil
+}
+
+// publishUserUpdated publishes a user updated event if the profile of an existing user changed. Events
+// are published in the background once the exchange has been committed, so they do not delay the
+// token response.
+func (s *TokenExchangeHandler) publishUserUpdated(ctx context.Context, previous, user types.UserInfo) {
+	strategy := s.config.GetUserEventStrategy(ctx)
+	if strategy == nil || previous.ProfileEqual(user) {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, userEventTimeout)
+		defer cancel()
+
+		ctx, span := s.tracer.Start(ctx, "publishUserUpdated")
+		defer span.End()
+
+		if err := strategy.UpdateUser(ctx, previous, user); err != nil {
+			span.RecordError(err)
+		}
+	}()
+}
+
+// recordLastSeen records that the user exchanged a token, and that a token was issued to the
+// authenticated client, if any.
+func (s *TokenExchangeHandler) recordLastSeen(ctx context.Context, userID gidx.PrefixedID, clientID string) {
+	strategy := s.config.GetLastSeenStrategy(ctx)
+	if strategy == nil {
+		return
+	}
+
+	now := time.Now()
+
+	strategy.RecordUserExchange(userID, now)
+
+	if id, err := gidx.Parse(clientID); err == nil {
+		strategy.RecordClientToken(id, now)
+	}
+}
+
+// fetchUserInfo fetches the profile data of the token subject from the issuer's userinfo endpoint.
+func (s *TokenExchangeHandler) fetchUserInfo(
+	ctx context.Context,
+	strategy fositex.UserInfoFetchStrategy,
+	issuer *types.Issuer,
+	subject, token string,
+	now time.Time,
+) (types.UserInfo, error) {
+	ctx, span := s.tracer.Start(ctx, "fetchUserInfo")
+
+	defer span.End()
+
+	claims, err := strategy.FetchUserInfo(ctx, issuer.UserInfoURI, token)
+	if err != nil {
+		return types.UserInfo{}, err
+	}
+
+	// Per OIDC Core section 5.3.2, the response must not be used if its subject does not match the token.
+	if sub, _ := claims["sub"].(string); sub != subject {
+		return types.UserInfo{}, ErrUserInfoSubjectMismatch
+	}
+
+	name, _ := claims[claimName].(string)
+	email, _ := claims[claimEmail].(string)
+
+	return types.UserInfo{
+		Name:      name,
+		Email:     email,
+		FetchedAt: now,
+	}, nil
+}
+
+// userInfoNeedsRefresh reports whether user info should be fetched from the issuer's userinfo
+// endpoint, given the user info from the token claims and the stored user info, if any.
+func userInfoNeedsRefresh(fromClaims types.UserInfo, stored *types.UserInfo, now time.Time, interval time.Duration) bool {
+	if fromClaims.Name != "" && fromClaims.Email != "" {
+		return false
+	}
+
+	return stored == nil || stored.FetchedAt.IsZero() || now.Sub(stored.FetchedAt) >= interval
+}
+
+// mapProfileAttributes evaluates the profile mapping of the issuer against the given claims, returning
+// the attributes of the user. If the issuer has no profile mapping, nil is returned.
+func mapProfileAttributes(issuer *types.Issuer, claims *jwt.JWTClaims) (map[string]any, error) {
+	if issuer.ProfileMapping == nil || issuer.ProfileMapping.AST() == nil {
+		return nil, nil
+	}
+
+	subSHA256Bytes := sha256.Sum256([]byte(claims.Subject))
+	subSHA256 := hex.EncodeToString(subSHA256Bytes[0:])
+
+	inputEnv := map[string]any{
+		celutils.CELVariableClaims:    claims.ToMapClaims(),
+		celutils.CELVariableSubSHA256: subSHA256,
+	}
+
+	res, err := celutils.Eval(issuer.ProfileMapping.AST(), inputEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	// Converting to a JSON object ensures the attributes can be stored and returned as JSON.
+	attributes, err := res.ConvertToNative(reflect.TypeOf(&structpb.Struct{}))
+	if err != nil {
+		return nil, fmt.Errorf("%w: unexpected type for profile mapping result: %s", ErrInvalidProfileMapping, err)
+	}
+
+	return attributes.(*structpb.Struct).AsMap(), nil
+}
+
+// updateUserInfo updates the stored user info with the user info from an exchanged token according
+// to the given mode. If the exchanged user info is not complete, values it lacks are kept in every mode,
+// as they may only be missing because the issuer's userinfo endpoint was not called. The identity
+// of the exchanged user info is kept. Attributes are kept if the issuer has no profile mapping, and
+// are otherwise replaced in the always mode and merged with the mapped attributes in the on change mode.
+func updateUserInfo(mode types.UserInfoUpdateMode, stored, userInfo types.UserInfo, complete bool) types.UserInfo {
+	switch mode {
+	case types.UserInfoUpdateModeNever:
+		userInfo.Name = stored.Name
+		userInfo.Email = stored.Email
+		userInfo.Attributes = stored.Attributes
+	case types.UserInfoUpdateModeAlways:
+		if !complete {
+			userInfo = mergeUserInfo(userInfo, stored)
+		}
+
+		if userInfo.Attributes == nil {
+			userInfo.Attributes = stored.Attributes
+		}
+	default:
+		userInfo = mergeUserInfo(userInfo, stored)
+		userInfo.Attributes = mergeAttributes(userInfo.Attributes, stored.Attributes)
+	}
+
+	if userInfo.FetchedAt.IsZero() {
+		userInfo.FetchedAt = stored.FetchedAt
+	}
+
+	return userInfo
+}
+
+// mergeUserInfo fills the profile data missing from userInfo from other. The identity of userInfo
+// is kept.
+func mergeUserInfo(userInfo, other types.UserInfo) types.UserInfo {
+	if userInfo.Name == "" {
+		userInfo.Name = other.Name
+	}
+
+	if userInfo.Email == "" {
+		userInfo.Email = other.Email
+	}
+
+	if userInfo.FetchedAt.IsZero() {
+		userInfo.FetchedAt = other.FetchedAt
+	}
+
+	return userInfo
+}
+
+// mergeAttributes returns the stored attributes updated with the mapped attributes.
+func mergeAttributes(mapped, stored map[string]any) map[string]any {
+	if mapped == nil {
+		return stored
+	}
+
+	out := maps.Clone(stored)
+	if out == nil {
+		out = make(map[string]any, len(mapped))
+	}
+
+	maps.Copy(out, mapped)
+
+	return out
+}
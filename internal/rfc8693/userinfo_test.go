@@ -0,0 +1,268 @@
+package rfc8693
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// TestUserInfoNeedsRefresh checks that user info is only fetched when the token claims lack profile
+// data and the stored copy is missing or stale.
+func TestUserInfoNeedsRefresh(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	type refreshInput struct {
+		fromClaims types.UserInfo
+		stored     *types.UserInfo
+	}
+
+	runFn := func(_ context.Context, input refreshInput) testingx.TestResult[bool] {
+		return testingx.TestResult[bool]{
+			Success: userInfoNeedsRefresh(input.fromClaims, input.stored, now, time.Hour),
+		}
+	}
+
+	checkFn := func(expected bool) func(context.Context, *testing.T, testingx.TestResult[bool]) {
+		return func(_ context.Context, t *testing.T, result testingx.TestResult[bool]) {
+			assert.Equal(t, expected, result.Success)
+		}
+	}
+
+	testCases := []testingx.TestCase[refreshInput, bool]{
+		{
+			Name: "ClaimsComplete",
+			Input: refreshInput{
+				fromClaims: types.UserInfo{Name: "Foo", Email: "foo@example.com"},
+			},
+			CheckFn: checkFn(false),
+		},
+		{
+			Name: "NotStored",
+			Input: refreshInput{
+				fromClaims: types.UserInfo{Name: "Foo"},
+			},
+			CheckFn: checkFn(true),
+		},
+		{
+			Name: "NeverFetched",
+			Input: refreshInput{
+				stored: &types.UserInfo{Name: "Foo"},
+			},
+			CheckFn: checkFn(true),
+		},
+		{
+			Name: "Stale",
+			Input: refreshInput{
+				stored: &types.UserInfo{Name: "Foo", FetchedAt: now.Add(-2 * time.Hour)},
+			},
+			CheckFn: checkFn(true),
+		},
+		{
+			Name: "Fresh",
+			Input: refreshInput{
+				stored: &types.UserInfo{Name: "Foo", FetchedAt: now.Add(-time.Minute)},
+			},
+			CheckFn: checkFn(false),
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
+
+// TestMergeUserInfo checks that missing profile data is filled in without changing the identity of
+// the user info.
+func TestMergeUserInfo(t *testing.T) {
+	t.Parallel()
+
+	fetchedAt := time.Now()
+
+	fromClaims := types.UserInfo{
+		Issuer:  "https://example.com/",
+		Subject: "foo",
+		Name:    "Foo",
+	}
+
+	fetched := types.UserInfo{
+		Name:      "Foo Bar",
+		Email:     "foo@example.com",
+		FetchedAt: fetchedAt,
+	}
+
+	expected := types.UserInfo{
+		Issuer:    "https://example.com/",
+		Subject:   "foo",
+		Name:      "Foo",
+		Email:     "foo@example.com",
+		FetchedAt: fetchedAt,
+	}
+
+	assert.Equal(t, expected, mergeUserInfo(fromClaims, fetched))
+}
+
+// TestUpdateUserInfo checks that stored user info is updated according to the issuer's update mode.
+func TestUpdateUserInfo(t *testing.T) {
+	t.Parallel()
+
+	stored := types.UserInfo{
+		Issuer:  "https://example.com/",
+		Subject: "foo",
+		Name:    "Foo",
+		Email:   "foo@example.com",
+		Attributes: map[string]any{
+			"department": "sales",
+			"team":       "a",
+		},
+	}
+
+	fromToken := types.UserInfo{
+		Issuer:  "https://example.com/",
+		Subject: "foo",
+		Name:    "Foo Bar",
+		Attributes: map[string]any{
+			"department": "engineering",
+		},
+	}
+
+	mapped := map[string]any{
+		"department": "engineering",
+	}
+
+	merged := map[string]any{
+		"department": "engineering",
+		"team":       "a",
+	}
+
+	type updateInput struct {
+		mode     types.UserInfoUpdateMode
+		complete bool
+	}
+
+	runFn := func(_ context.Context, input updateInput) testingx.TestResult[types.UserInfo] {
+		return testingx.TestResult[types.UserInfo]{
+			Success: updateUserInfo(input.mode, stored, fromToken, input.complete),
+		}
+	}
+
+	checkFn := func(name, email string, attributes map[string]any) func(context.Context, *testing.T, testingx.TestResult[types.UserInfo]) {
+		return func(_ context.Context, t *testing.T, result testingx.TestResult[types.UserInfo]) {
+			assert.Equal(t, name, result.Success.Name)
+			assert.Equal(t, email, result.Success.Email)
+			assert.Equal(t, attributes, result.Success.Attributes)
+			assert.Equal(t, stored.Subject, result.Success.Subject)
+		}
+	}
+
+	testCases := []testingx.TestCase[updateInput, types.UserInfo]{
+		{
+			Name:    "Never",
+			Input:   updateInput{mode: types.UserInfoUpdateModeNever, complete: true},
+			CheckFn: checkFn("Foo", "foo@example.com", stored.Attributes),
+		},
+		{
+			Name:    "AlwaysComplete",
+			Input:   updateInput{mode: types.UserInfoUpdateModeAlways, complete: true},
+			CheckFn: checkFn("Foo Bar", "", mapped),
+		},
+		{
+			Name:    "AlwaysIncomplete",
+			Input:   updateInput{mode: types.UserInfoUpdateModeAlways},
+			CheckFn: checkFn("Foo Bar", "foo@example.com", mapped),
+		},
+		{
+			Name:    "OnChange",
+			Input:   updateInput{mode: types.UserInfoUpdateModeOnChange, complete: true},
+			CheckFn: checkFn("Foo Bar", "foo@example.com", merged),
+		},
+		{
+			Name:    "Default",
+			Input:   updateInput{complete: true},
+			CheckFn: checkFn("Foo Bar", "foo@example.com", merged),
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
+
+// TestMapProfileAttributes checks that the profile mapping of an issuer produces JSON attributes.
+func TestMapProfileAttributes(t *testing.T) {
+	t.Parallel()
+
+	claims := &jwt.JWTClaims{
+		Subject: "foo",
+		Extra: map[string]any{
+			"department": "engineering",
+			"groups":     []any{"admins", "users"},
+		},
+	}
+
+	runFn := func(_ context.Context, expr string) testingx.TestResult[map[string]any] {
+		issuer := &types.Issuer{}
+
+		if expr != "" {
+			mapping, err := types.NewProfileMapping(expr)
+			if err != nil {
+				return testingx.TestResult[map[string]any]{
+					Err: err,
+				}
+			}
+
+			issuer.ProfileMapping = mapping
+		}
+
+		attributes, err := mapProfileAttributes(issuer, claims)
+
+		return testingx.TestResult[map[string]any]{
+			Success: attributes,
+			Err:     err,
+		}
+	}
+
+	testCases := []testingx.TestCase[string, map[string]any]{
+		{
+			Name:  "NoMapping",
+			Input: "",
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[map[string]any]) {
+				require.NoError(t, res.Err)
+				assert.Nil(t, res.Success)
+			},
+		},
+		{
+			Name:  "Success",
+			Input: `{"department": claims.department, "groups": claims.groups, "admin": "admins" in claims.groups, "level": 3}`,
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[map[string]any]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, map[string]any{
+					"department": "engineering",
+					"groups":     []any{"admins", "users"},
+					"admin":      true,
+					"level":      float64(3),
+				}, res.Success)
+			},
+		},
+		{
+			Name:  "NotAMap",
+			Input: "claims.department",
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[map[string]any]) {
+				assert.ErrorIs(t, res.Err, ErrInvalidProfileMapping)
+			},
+		},
+		{
+			Name:  "NotJSON",
+			Input: "{1: claims.department}",
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[map[string]any]) {
+				assert.ErrorIs(t, res.Err, ErrInvalidProfileMapping)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
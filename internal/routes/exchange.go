@@ -11,6 +11,8 @@ import (
 	"go.uber.org/zap"
 
 	"go.infratographer.com/identity-api/internal/auditx"
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/rfc8693"
 	"go.infratographer.com/identity-api/internal/types"
 )
 
@@ -72,6 +74,15 @@ func (h *tokenHandler) Handle(c echo.Context) error {
 		"subject": session.JWTClaims.Subject,
 	}
 
+	if ownerID, ok := session.JWTClaims.Extra[fositex.ClaimOwnerID].(string); ok {
+		subject["owner_id"] = ownerID
+	}
+
+	if actorIssuer, actorSubject, ok := rfc8693.ActorFromClaims(session.JWTClaims); ok {
+		subject["actor_issuer"] = actorIssuer
+		subject["actor_subject"] = actorSubject
+	}
+
 	auditx.SetSubject(c, subject)
 
 	response, err := h.provider.NewAccessResponse(ctx, accessRequest)
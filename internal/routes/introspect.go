@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/x/errorsx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/identity-api/internal/auditx"
+)
+
+type introspectHandler struct {
+	logger   *zap.SugaredLogger
+	provider fosite.OAuth2Provider
+}
+
+// Handle processes the request for the token introspection handler.
+func (h *introspectHandler) Handle(c echo.Context) error {
+	var session oauth2.JWTSession
+
+	ctx := c.Request().Context()
+
+	// Introspection requests must be authenticated with OAuth client credentials, rather than an access token.
+	if _, _, ok := c.Request().BasicAuth(); !ok || fosite.AccessTokenFromRequest(c.Request()) != "" {
+		err := errorsx.WithStack(fosite.ErrRequestUnauthorized.WithHint("Introspection requests must be authenticated with OAuth client credentials."))
+
+		setContextFromError(c, err)
+		h.provider.WriteIntrospectionError(ctx, c.Response(), err)
+
+		return nil
+	}
+
+	response, err := h.provider.NewIntrospectionRequest(ctx, c.Request(), &session)
+	if err != nil {
+		setContextFromError(c, err)
+
+		h.logger.Errorf("Error occurred in NewIntrospectionRequest: %+v", err)
+		h.provider.WriteIntrospectionError(ctx, c.Response(), err)
+
+		return nil
+	}
+
+	auditx.SetSubject(c, map[string]string{
+		"subject": response.GetAccessRequester().GetSession().GetSubject(),
+	})
+
+	h.provider.WriteIntrospectionResponse(ctx, c.Response(), response)
+
+	return nil
+}
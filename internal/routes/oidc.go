@@ -5,35 +5,99 @@ import (
 	"net/url"
 
 	"github.com/labstack/echo/v4"
+	"github.com/ory/fosite"
 	"go.uber.org/zap"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+)
+
+const (
+	authMethodClientSecretBasic = "client_secret_basic"
+	authMethodClientSecretPost  = "client_secret_post"
+	subjectTypePublic           = "public"
 )
 
 type oidcHandler struct {
 	logger *zap.SugaredLogger
 	issuer string
+	config fositex.OAuth2Configurator
 }
 
+// providerJSON is the RFC 8414 authorization server metadata document, which also includes the
+// fields required by OpenID Connect Discovery 1.0.
 type providerJSON struct {
-	Issuer      string `json:"issuer"`
-	AuthURL     string `json:"authorization_endpoint,omitempty"`
-	TokenURL    string `json:"token_endpoint"`
-	JWKSURL     string `json:"jwks_uri"`
-	UserInfoURL string `json:"userinfo_endpoint"`
+	Issuer                                    string   `json:"issuer"`
+	AuthURL                                   string   `json:"authorization_endpoint,omitempty"`
+	TokenURL                                  string   `json:"token_endpoint"`
+	JWKSURL                                   string   `json:"jwks_uri"`
+	UserInfoURL                               string   `json:"userinfo_endpoint"`
+	IntrospectionURL                          string   `json:"introspection_endpoint,omitempty"`
+	RevocationURL                             string   `json:"revocation_endpoint,omitempty"`
+	GrantTypesSupported                       []string `json:"grant_types_supported"`
+	ResponseTypesSupported                    []string `json:"response_types_supported"`
+	SubjectTypesSupported                     []string `json:"subject_types_supported"`
+	TokenEndpointAuthMethodsSupported         []string `json:"token_endpoint_auth_methods_supported"`
+	IntrospectionEndpointAuthMethodsSupported []string `json:"introspection_endpoint_auth_methods_supported,omitempty"`
+	RevocationEndpointAuthMethodsSupported    []string `json:"revocation_endpoint_auth_methods_supported,omitempty"`
+	IDTokenSigningAlgValuesSupported          []string `json:"id_token_signing_alg_values_supported"`
 }
 
-// Handle processes the request for the OIDC handler.
+// Handle processes the request for the OIDC handler. The same document is served for OpenID Connect
+// discovery and RFC 8414 authorization server metadata.
 func (h *oidcHandler) Handle(ctx echo.Context) error {
 	issuer, err := url.Parse(h.issuer)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "bad issuer").SetInternal(err)
 	}
 
+	reqCtx := ctx.Request().Context()
+
 	out := providerJSON{
-		Issuer:      h.issuer,
-		TokenURL:    issuer.JoinPath("/token").String(),
-		JWKSURL:     issuer.JoinPath("/jwks.json").String(),
-		UserInfoURL: issuer.JoinPath("/userinfo").String(),
+		Issuer:                            h.issuer,
+		TokenURL:                          issuer.JoinPath("/token").String(),
+		JWKSURL:                           issuer.JoinPath("/jwks.json").String(),
+		UserInfoURL:                       issuer.JoinPath("/userinfo").String(),
+		GrantTypesSupported:               []string{},
+		ResponseTypesSupported:            []string{},
+		SubjectTypesSupported:             []string{subjectTypePublic},
+		TokenEndpointAuthMethodsSupported: []string{authMethodClientSecretBasic, authMethodClientSecretPost},
+		IDTokenSigningAlgValuesSupported:  []string{},
+	}
+
+	for _, handler := range h.config.GetTokenEndpointHandlers(reqCtx) {
+		if gh, ok := handler.(fositex.GrantTypeHandler); ok {
+			out.GrantTypesSupported = appendUnique(out.GrantTypesSupported, gh.GrantType())
+		}
+	}
+
+	if len(h.config.GetTokenIntrospectionHandlers(reqCtx)) > 0 {
+		out.IntrospectionURL = issuer.JoinPath("/introspect").String()
+		// Introspection requests must be authenticated with HTTP basic authentication.
+		out.IntrospectionEndpointAuthMethodsSupported = []string{authMethodClientSecretBasic}
+	}
+
+	if len(h.config.GetRevocationHandlers(reqCtx)) > 0 {
+		out.RevocationURL = issuer.JoinPath("/revoke").String()
+		out.RevocationEndpointAuthMethodsSupported = []string{authMethodClientSecretBasic, authMethodClientSecretPost}
+	}
+
+	// Only keys published by the JWKS handler are listed, so relying parties can verify every
+	// advertised algorithm.
+	if jwks := h.config.GetActiveSigningJWKS(reqCtx); jwks != nil {
+		for _, key := range jwks.Keys {
+			if public := key.Public(); public.Valid() {
+				out.IDTokenSigningAlgValuesSupported = appendUnique(out.IDTokenSigningAlgValuesSupported, public.Algorithm)
+			}
+		}
 	}
 
 	return ctx.JSON(http.StatusOK, out)
 }
+
+func appendUnique(values []string, value string) []string {
+	if fosite.Arguments(values).Has(value) {
+		return values
+	}
+
+	return append(values, value)
+}
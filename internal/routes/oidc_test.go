@@ -2,19 +2,80 @@ package routes
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	jose "github.com/go-jose/go-jose/v3"
 	"github.com/labstack/echo/v4"
+	"github.com/ory/fosite"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/oauth2"
+	"go.infratographer.com/identity-api/internal/rfc8693"
 )
 
 func TestHandle(t *testing.T) {
 	t.Parallel()
 
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	// Only the active asymmetric key is advertised: pending and retired keys do not sign tokens, and
+	// symmetric keys are not published.
+	keyRing, err := fositex.NewKeyRing([]fositex.SigningKey{
+		{Signer: fositex.NewKeySigner(jose.JSONWebKey{KeyID: "a", Algorithm: "RS256", Key: rsaKey}), State: fositex.KeyStateActive},
+		{Signer: fositex.NewKeySigner(jose.JSONWebKey{KeyID: "b", Algorithm: "ES256", Key: p256Key}), State: fositex.KeyStatePending},
+		{Signer: fositex.NewKeySigner(jose.JSONWebKey{KeyID: "c", Algorithm: "ES384", Key: p384Key}), State: fositex.KeyStateRetired},
+		{Signer: fositex.NewKeySigner(jose.JSONWebKey{KeyID: "d", Algorithm: "HS256", Key: []byte("secret")}), State: fositex.KeyStateActive},
+	})
+	require.NoError(t, err)
+
+	config := &fositex.OAuth2Config{
+		Config: &fosite.Config{
+			TokenEndpointHandlers: fosite.TokenEndpointHandlers{
+				&rfc8693.TokenExchangeHandler{},
+				&oauth2.ClientCredentialsGrantHandler{},
+			},
+			TokenIntrospectionHandlers: fosite.TokenIntrospectionHandlers{
+				&oauth2.TokenIntrospector{},
+			},
+		},
+		KeyRing: keyRing,
+	}
+
+	expect := func(issuer, base string) providerJSON {
+		return providerJSON{
+			Issuer:           issuer,
+			TokenURL:         base + "/token",
+			JWKSURL:          base + "/jwks.json",
+			UserInfoURL:      base + "/userinfo",
+			IntrospectionURL: base + "/introspect",
+			GrantTypesSupported: []string{
+				"urn:ietf:params:oauth:grant-type:token-exchange",
+				"client_credentials",
+			},
+			ResponseTypesSupported:                    []string{},
+			SubjectTypesSupported:                     []string{"public"},
+			TokenEndpointAuthMethodsSupported:         []string{"client_secret_basic", "client_secret_post"},
+			IntrospectionEndpointAuthMethodsSupported: []string{"client_secret_basic"},
+			IDTokenSigningAlgValuesSupported:          []string{"RS256"},
+		}
+	}
+
 	testCases := []struct {
 		name   string
 		issuer string
@@ -23,42 +84,22 @@ func TestHandle(t *testing.T) {
 		{
 			"root with slash",
 			"https://test.local/",
-			providerJSON{
-				Issuer:      "https://test.local/",
-				TokenURL:    "https://test.local/token",
-				JWKSURL:     "https://test.local/jwks.json",
-				UserInfoURL: "https://test.local/userinfo",
-			},
+			expect("https://test.local/", "https://test.local"),
 		},
 		{
 			"root without slash",
 			"https://test.local",
-			providerJSON{
-				Issuer:      "https://test.local",
-				TokenURL:    "https://test.local/token",
-				JWKSURL:     "https://test.local/jwks.json",
-				UserInfoURL: "https://test.local/userinfo",
-			},
+			expect("https://test.local", "https://test.local"),
 		},
 		{
 			"subpath with slash",
 			"https://test.local/some/path/",
-			providerJSON{
-				Issuer:      "https://test.local/some/path/",
-				TokenURL:    "https://test.local/some/path/token",
-				JWKSURL:     "https://test.local/some/path/jwks.json",
-				UserInfoURL: "https://test.local/some/path/userinfo",
-			},
+			expect("https://test.local/some/path/", "https://test.local/some/path"),
 		},
 		{
 			"subpath without slash",
 			"https://test.local/some/path",
-			providerJSON{
-				Issuer:      "https://test.local/some/path",
-				TokenURL:    "https://test.local/some/path/token",
-				JWKSURL:     "https://test.local/some/path/jwks.json",
-				UserInfoURL: "https://test.local/some/path/userinfo",
-			},
+			expect("https://test.local/some/path", "https://test.local/some/path"),
 		},
 	}
 
@@ -69,6 +110,7 @@ func TestHandle(t *testing.T) {
 
 			handler := oidcHandler{
 				issuer: tc.issuer,
+				config: config,
 			}
 
 			e := echo.New()
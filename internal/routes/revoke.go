@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/ory/fosite"
+	"go.uber.org/zap"
+)
+
+type revokeHandler struct {
+	logger   *zap.SugaredLogger
+	provider fosite.OAuth2Provider
+}
+
+// Handle processes the request for the token revocation handler.
+func (h *revokeHandler) Handle(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.provider.NewRevocationRequest(ctx, c.Request())
+	if err != nil {
+		setContextFromError(c, err)
+
+		h.logger.Errorf("Error occurred in NewRevocationRequest: %+v", err)
+	}
+
+	h.provider.WriteRevocationResponse(ctx, c.Response(), err)
+
+	return nil
+}
@@ -76,6 +76,14 @@ func (r *Router) Routes(rg *echo.Group) {
 		logger:   r.logger,
 		provider: r.provider,
 	}
+	introspect := &introspectHandler{
+		logger:   r.logger,
+		provider: r.provider,
+	}
+	revoke := &revokeHandler{
+		logger:   r.logger,
+		provider: r.provider,
+	}
 	jwks := &jwksHandler{
 		logger: r.logger,
 		config: r.config,
@@ -83,6 +91,7 @@ func (r *Router) Routes(rg *echo.Group) {
 	oidc := &oidcHandler{
 		logger: r.logger,
 		issuer: r.issuer,
+		config: r.config,
 	}
 
 	rg.POST(
@@ -90,14 +99,26 @@ func (r *Router) Routes(rg *echo.Group) {
 		tok.Handle,
 		r.auditMiddlware.AuditWithType("TokenRequest"),
 	)
+	rg.POST(
+		"/introspect",
+		introspect.Handle,
+		r.auditMiddlware.AuditWithType("TokenIntrospectionRequest"),
+	)
+	rg.POST(
+		"/revoke",
+		revoke.Handle,
+		r.auditMiddlware.AuditWithType("TokenRevocationRequest"),
+	)
 	rg.GET("/jwks.json", jwks.Handle)
 	rg.GET("/.well-known/openid-configuration", oidc.Handle)
+	rg.GET("/.well-known/oauth-authorization-server", oidc.Handle)
 }
 
 // SkipNoAuthRoutes returns true if the requesting path should not have auth validated for it.
 func SkipNoAuthRoutes(c echo.Context) bool {
 	switch c.Request().URL.Path {
-	case "/token", "/jwks.json", "/.well-known/openid-configuration":
+	case "/token", "/introspect", "/revoke", "/jwks.json", "/.well-known/openid-configuration",
+		"/.well-known/oauth-authorization-server":
 		return true
 	default:
 		return false
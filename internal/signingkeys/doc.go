@@ -0,0 +1,2 @@
+// Package signingkeys periodically loads the token signing keys stored in the database.
+package signingkeys
@@ -0,0 +1,183 @@
+package signingkeys
+
+import (
+	"context"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+const (
+	// KeySource is the key ring source of the signing keys stored in the database.
+	KeySource = "database"
+
+	// DefaultRefreshInterval is how often signing keys are reloaded if no interval is configured.
+	DefaultRefreshInterval = time.Minute
+	// DefaultBootstrapAlgorithm is the algorithm of the bootstrap key if no algorithm is configured.
+	DefaultBootstrapAlgorithm = jose.ES256
+	// defaultAccessTokenLifespan matches the fosite default when no access token lifespan is configured.
+	defaultAccessTokenLifespan = time.Hour
+)
+
+// Loader loads the signing keys stored in the database into a key ring, so every replica publishes
+// the same JWKS and signs with the same key.
+type Loader struct {
+	keySvc       types.SigningKeyService
+	keyRing      *fositex.KeyRing
+	interval     time.Duration
+	retention    time.Duration
+	bootstrapAlg jose.SignatureAlgorithm
+	logger       *zap.Logger
+	now          func() time.Time
+}
+
+// Opt represents an option for configuring a Loader.
+type Opt func(*Loader)
+
+// WithLogger sets the logger for the Loader.
+func WithLogger(logger *zap.Logger) Opt {
+	return func(l *Loader) {
+		l.logger = logger
+	}
+}
+
+// NewLoader creates a Loader given a signing key service, the key ring to load keys into and the
+// OAuth configuration.
+func NewLoader(keySvc types.SigningKeyService, keyRing *fositex.KeyRing, config fositex.Config, opts ...Opt) *Loader {
+	interval := config.SigningKeys.RefreshInterval
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+
+	bootstrapAlg := config.SigningKeys.BootstrapAlgorithm
+	if bootstrapAlg == "" {
+		bootstrapAlg = DefaultBootstrapAlgorithm
+	}
+
+	// Retired keys are published until every token they signed has expired.
+	retention := time.Duration(config.AccessTokenLifespan) * time.Second
+	if retention <= 0 {
+		retention = defaultAccessTokenLifespan
+	}
+
+	l := &Loader{
+		keySvc:       keySvc,
+		keyRing:      keyRing,
+		interval:     interval,
+		retention:    retention,
+		bootstrapAlg: bootstrapAlg,
+		logger:       zap.NewNop(),
+		now:          time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// Interval returns how often signing keys are reloaded.
+func (l *Loader) Interval() time.Duration {
+	return l.interval
+}
+
+// Run reloads the signing keys every interval until the given context is canceled. If the keys
+// cannot be loaded, the current keys are kept.
+func (l *Loader) Run(ctx context.Context) {
+	ticker := time.NewTicker(l.interval)
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := l.Load(ctx); err != nil {
+			l.logger.Error("failed to reload signing keys", zap.Error(err))
+		}
+	}
+}
+
+// Load loads the signing keys stored in the database into the key ring. If neither the database nor
+// the key ring holds any key, a key is generated and activated, so the server can sign tokens.
+func (l *Loader) Load(ctx context.Context) error {
+	keys, err := l.keySvc.ListSigningKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 && l.keyRing.Signer() == nil {
+		key, err := l.bootstrap(ctx)
+		if err != nil {
+			return err
+		}
+
+		keys = types.SigningKeys{key}
+	}
+
+	now := l.now()
+
+	signingKeys := make([]fositex.SigningKey, 0, len(keys))
+
+	for _, key := range keys {
+		if key.State == types.SigningKeyStateRetired && key.RetiredAt.Add(l.retention).Before(now) {
+			continue
+		}
+
+		jwk, err := fositex.ParsePKCS8Key(key.ID.String(), jose.SignatureAlgorithm(key.Algorithm), key.PrivateKey)
+		if err != nil {
+			return err
+		}
+
+		signingKeys = append(signingKeys, fositex.SigningKey{
+			Signer:     fositex.NewKeySigner(jwk),
+			State:      fositex.KeyState(key.State),
+			ActivateAt: key.ActivateAt,
+		})
+	}
+
+	if err := l.keyRing.SetSource(KeySource, signingKeys); err != nil {
+		return err
+	}
+
+	l.logger.Debug("loaded signing keys", zap.Int("count", len(signingKeys)))
+
+	return nil
+}
+
+// bootstrap generates and stores an active signing key.
+func (l *Loader) bootstrap(ctx context.Context) (*types.SigningKey, error) {
+	privateKey, err := fositex.GenerateKey(l.bootstrapAlg)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := gidx.NewID(types.IdentitySigningKeyIDPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := l.keySvc.CreateSigningKey(ctx, types.SigningKey{
+		ID:         id,
+		Algorithm:  string(l.bootstrapAlg),
+		State:      types.SigningKeyStateActive,
+		PrivateKey: privateKey,
+		ActivateAt: l.now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	l.logger.Info("generated signing key", zap.String("signing_key_id", id.String()))
+
+	return key, nil
+}
@@ -0,0 +1,149 @@
+package signingkeys
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+type mockSigningKeyService struct {
+	types.SigningKeyService
+
+	keys types.SigningKeys
+}
+
+func (s *mockSigningKeyService) ListSigningKeys(_ context.Context) (types.SigningKeys, error) {
+	return s.keys, nil
+}
+
+func (s *mockSigningKeyService) CreateSigningKey(_ context.Context, key types.SigningKey) (*types.SigningKey, error) {
+	s.keys = append(s.keys, &key)
+
+	return &key, nil
+}
+
+func newKey(t *testing.T, state types.SigningKeyState, activateAt, retiredAt time.Time) *types.SigningKey {
+	t.Helper()
+
+	privateKey, err := fositex.GenerateKey(jose.ES256)
+	require.NoError(t, err)
+
+	return &types.SigningKey{
+		ID:         gidx.MustNewID(types.IdentitySigningKeyIDPrefix),
+		Algorithm:  string(jose.ES256),
+		State:      state,
+		PrivateKey: privateKey,
+		ActivateAt: activateAt,
+		RetiredAt:  retiredAt,
+	}
+}
+
+// TestLoad checks that stored keys are loaded into the key ring, and that a key is generated when no
+// key exists.
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	active := newKey(t, types.SigningKeyStateActive, now.Add(-2*time.Hour), time.Time{})
+	retired := newKey(t, types.SigningKeyStateRetired, now.Add(-3*time.Hour), now.Add(-time.Minute))
+	expired := newKey(t, types.SigningKeyStateRetired, now.Add(-4*time.Hour), now.Add(-2*time.Hour))
+
+	type input struct {
+		keys []*types.SigningKey
+	}
+
+	type result struct {
+		stored       types.SigningKeys
+		signingKeyID string
+		keyIDs       []string
+	}
+
+	runFn := func(ctx context.Context, in input) testingx.TestResult[result] {
+		svc := &mockSigningKeyService{
+			keys: in.keys,
+		}
+
+		oauth2Config, err := fositex.NewOAuth2Config(fositex.Config{
+			Issuer: "https://example.com/",
+			SigningKeys: fositex.SigningKeysConfig{
+				EncryptionKey: "abcd1234abcd1234abcd1234abcd1234",
+			},
+		})
+		if err != nil {
+			return testingx.TestResult[result]{
+				Err: err,
+			}
+		}
+
+		loader := NewLoader(svc, oauth2Config.KeyRing, fositex.Config{AccessTokenLifespan: int(time.Hour / time.Second)})
+
+		if err := loader.Load(ctx); err != nil {
+			return testingx.TestResult[result]{
+				Err: err,
+			}
+		}
+
+		out := result{
+			stored:       svc.keys,
+			signingKeyID: oauth2Config.KeyRing.Signer().Public().KeyID,
+		}
+
+		for _, key := range oauth2Config.KeyRing.JWKS().Keys {
+			out.keyIDs = append(out.keyIDs, key.KeyID)
+		}
+
+		return testingx.TestResult[result]{
+			Success: out,
+		}
+	}
+
+	testCases := []testingx.TestCase[input, result]{
+		{
+			Name:  "Bootstrap",
+			Input: input{},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				require.Len(t, res.Success.stored, 1)
+
+				key := res.Success.stored[0]
+
+				assert.Equal(t, types.SigningKeyStateActive, key.State)
+				assert.Equal(t, string(DefaultBootstrapAlgorithm), key.Algorithm)
+				assert.Equal(t, key.ID.String(), res.Success.signingKeyID)
+			},
+		},
+		{
+			Name: "RetiredKeysExpire",
+			Input: input{
+				keys: []*types.SigningKey{expired, retired, active},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				require.NoError(t, res.Err)
+				assert.Len(t, res.Success.stored, 3)
+				assert.Equal(t, active.ID.String(), res.Success.signingKeyID)
+				assert.Equal(t, []string{retired.ID.String(), active.ID.String()}, res.Success.keyIDs)
+			},
+		},
+		{
+			Name: "NoSigningKey",
+			Input: input{
+				keys: []*types.SigningKey{retired},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[result]) {
+				assert.ErrorIs(t, res.Err, fositex.ErrInvalidKey)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
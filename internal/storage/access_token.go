@@ -0,0 +1,210 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ory/fosite"
+)
+
+var accessTokenCols = struct {
+	JTI         string
+	RequestID   string
+	ClientID    string
+	Subject     string
+	Audience    string
+	RequestedAt string
+	ExpiresAt   string
+	Request     string
+}{
+	JTI:         "jti",
+	RequestID:   "request_id",
+	ClientID:    "client_id",
+	Subject:     "subject",
+	Audience:    "audience",
+	RequestedAt: "requested_at",
+	ExpiresAt:   "expires_at",
+	Request:     "request",
+}
+
+var (
+	accessTokenColumns = []string{
+		accessTokenCols.JTI,
+		accessTokenCols.RequestID,
+		accessTokenCols.ClientID,
+		accessTokenCols.Subject,
+		accessTokenCols.Audience,
+		accessTokenCols.RequestedAt,
+		accessTokenCols.ExpiresAt,
+		accessTokenCols.Request,
+	}
+	accessTokenColumnsStr = strings.Join(accessTokenColumns, ", ")
+)
+
+// deleteExpiredTokensBatchSize is the number of expired records deleted by each statement, so large
+// backlogs are not deleted in a single transaction.
+const deleteExpiredTokensBatchSize = 1000
+
+// accessTokenService stores a record of issued access tokens. Access tokens are keyed by their jti claim,
+// which is used as the access token signature by fositex.JTIStrategy.
+type accessTokenService struct {
+	db      *sql.DB
+	clients fosite.ClientManager
+
+	deleteBatchSize int
+}
+
+func newAccessTokenService(db *sql.DB, clients fosite.ClientManager) (*accessTokenService, error) {
+	return &accessTokenService{
+		db:              db,
+		clients:         clients,
+		deleteBatchSize: deleteExpiredTokensBatchSize,
+	}, nil
+}
+
+// CreateAccessTokenSession implements oauth2.AccessTokenStorage
+func (s *accessTokenService) CreateAccessTokenSession(ctx context.Context, jti string, request fosite.Requester) error {
+	conn, err := contextConn(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	storedBytes, err := marshalRequest(request)
+	if err != nil {
+		return err
+	}
+
+	var clientID *string
+
+	if id := requestClientID(request); len(id) > 0 {
+		clientID = &id
+	}
+
+	q := fmt.Sprintf(`INSERT INTO access_tokens (%s) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`, accessTokenColumnsStr)
+
+	_, err = conn.ExecContext(
+		ctx,
+		q,
+		jti,
+		request.GetID(),
+		clientID,
+		sessionSubject(request.GetSession()),
+		strings.Join(request.GetGrantedAudience(), " "),
+		request.GetRequestedAt(),
+		request.GetSession().GetExpiresAt(fosite.AccessToken),
+		string(storedBytes),
+	)
+
+	return err
+}
+
+// GetAccessTokenSession implements oauth2.AccessTokenStorage. If the access token has been revoked, the
+// request is returned along with fosite.ErrInactiveToken.
+func (s *accessTokenService) GetAccessTokenSession(ctx context.Context, jti string, session fosite.Session) (fosite.Requester, error) {
+	conn, err := contextConn(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	q := fmt.Sprintf(
+		`SELECT %s, EXISTS (SELECT 1 FROM revoked_tokens WHERE revoked_tokens.jti = access_tokens.jti) FROM access_tokens WHERE jti = $1`,
+		accessTokenColumnsStr,
+	)
+
+	var (
+		tokenID, requestID, subject, audience string
+		clientID                              sql.NullString
+		requestedAt, expiresAt                time.Time
+		storedBytes                           []byte
+		revoked                               bool
+	)
+
+	err = conn.QueryRowContext(ctx, q, jti).Scan(
+		&tokenID,
+		&requestID,
+		&clientID,
+		&subject,
+		&audience,
+		&requestedAt,
+		&expiresAt,
+		&storedBytes,
+		&revoked,
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, fosite.ErrNotFound
+	case err != nil:
+		return nil, err
+	default:
+	}
+
+	request, err := unmarshalRequest(ctx, s.clients, storedBytes, requestID, clientID.String, requestedAt, session)
+	if err != nil {
+		return nil, err
+	}
+
+	if revoked {
+		return request, fosite.ErrInactiveToken
+	}
+
+	return request, nil
+}
+
+// DeleteAccessTokenSession implements oauth2.AccessTokenStorage
+func (s *accessTokenService) DeleteAccessTokenSession(ctx context.Context, jti string) error {
+	conn, err := contextConn(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, `DELETE FROM access_tokens WHERE jti = $1`, jti)
+
+	return err
+}
+
+// deleteEndedRefreshTokensQuery deletes refresh tokens issued without an expiry, once no refresh token of
+// their session is active. Rotated tokens are kept while the session is active to detect their reuse.
+const deleteEndedRefreshTokensQuery = `DELETE FROM refresh_tokens
+WHERE expires_at IS NULL AND requested_at < $1
+AND request_id NOT IN (SELECT request_id FROM refresh_tokens WHERE active)
+LIMIT $2`
+
+// DeleteExpiredTokens removes access and refresh tokens which expired before the given time, along with
+// their deny-list entries, returning the number of records removed. Refresh tokens issued without an
+// expiry are removed once their session has been revoked. Records are deleted in batches, each in its
+// own transaction.
+func (s *accessTokenService) DeleteExpiredTokens(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+
+	for _, q := range []string{
+		`DELETE FROM access_tokens WHERE expires_at < $1 LIMIT $2`,
+		`DELETE FROM refresh_tokens WHERE expires_at < $1 LIMIT $2`,
+		deleteEndedRefreshTokensQuery,
+		`DELETE FROM revoked_tokens WHERE expires_at < $1 LIMIT $2`,
+	} {
+		for {
+			result, err := s.db.ExecContext(ctx, q, before, s.deleteBatchSize)
+			if err != nil {
+				return total, err
+			}
+
+			count, err := result.RowsAffected()
+			if err != nil {
+				return total, err
+			}
+
+			total += count
+
+			if count == 0 {
+				break
+			}
+		}
+	}
+
+	return total, nil
+}
@@ -0,0 +1,292 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach-go/v2/testserver"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+var (
+	_ oauth2.AccessTokenStorage = &accessTokenService{}
+	_ types.AccessTokenService  = &accessTokenService{}
+)
+
+func TestAccessTokenService(t *testing.T) {
+	t.Parallel()
+
+	db, shutdown := testserver.NewDBForTest(t, testserver.CustomVersionOpt(TestServerCRDBVersion))
+
+	err := runMigrations(db)
+	if err != nil {
+		shutdown()
+		t.Fatal(err)
+	}
+
+	t.Cleanup(shutdown)
+
+	oauthClientStore, err := newOAuthClientManager(db)
+	require.NoError(t, err)
+
+	accessTokenSvc, err := newAccessTokenService(db, oauthClientStore)
+	require.NoError(t, err)
+
+	subject := gidx.MustNewID(types.IdentityUserIDPrefix)
+
+	newRequest := func(requestID string, expiresAt time.Time) *fosite.Request {
+		request := fosite.NewRequest()
+
+		request.ID = requestID
+		request.RequestedAt = time.Now().UTC().Round(time.Second)
+		request.GrantedAudience = fosite.Arguments{"https://example.com/userinfo"}
+		request.Session = &oauth2.JWTSession{
+			JWTClaims: &jwt.JWTClaims{
+				Subject: subject.String(),
+			},
+			ExpiresAt: map[fosite.TokenType]time.Time{
+				fosite.AccessToken: expiresAt,
+			},
+		}
+
+		return request
+	}
+
+	activeRequest := newRequest("active", time.Now().Add(time.Hour).UTC().Round(time.Second))
+	expiredRequest := newRequest("expired", time.Now().Add(-time.Hour).UTC().Round(time.Second))
+
+	require.NoError(t, accessTokenSvc.CreateAccessTokenSession(context.Background(), "active-jti", activeRequest))
+	require.NoError(t, accessTokenSvc.CreateAccessTokenSession(context.Background(), "expired-jti", expiredRequest))
+
+	t.Run("GetAccessTokenSession", func(t *testing.T) {
+		t.Parallel()
+
+		runFn := func(ctx context.Context, input string) testingx.TestResult[fosite.Requester] {
+			res, err := accessTokenSvc.GetAccessTokenSession(ctx, input, &oauth2.JWTSession{})
+
+			return testingx.TestResult[fosite.Requester]{
+				Success: res,
+				Err:     err,
+			}
+		}
+
+		testCases := []testingx.TestCase[string, fosite.Requester]{
+			{
+				Name:  "NotFound",
+				Input: "missing-jti",
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[fosite.Requester]) {
+					assert.ErrorIs(t, res.Err, fosite.ErrNotFound)
+				},
+			},
+			{
+				Name:  "Found",
+				Input: "active-jti",
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[fosite.Requester]) {
+					require.NoError(t, res.Err)
+
+					assert.Equal(t, activeRequest.GetID(), res.Success.GetID())
+					assert.Empty(t, res.Success.GetClient().GetID())
+					assert.Equal(t, activeRequest.GetGrantedAudience(), res.Success.GetGrantedAudience())
+
+					session, ok := res.Success.GetSession().(*oauth2.JWTSession)
+					require.True(t, ok)
+
+					assert.Equal(t, subject.String(), session.JWTClaims.Subject)
+				},
+			},
+		}
+
+		testingx.RunTests(context.Background(), t, testCases, runFn)
+	})
+
+	t.Run("DeleteExpiredTokens", func(t *testing.T) {
+		t.Parallel()
+
+		runFn := func(ctx context.Context, input time.Time) testingx.TestResult[int64] {
+			count, err := accessTokenSvc.DeleteExpiredTokens(ctx, input)
+
+			return testingx.TestResult[int64]{
+				Success: count,
+				Err:     err,
+			}
+		}
+
+		testCases := []testingx.TestCase[time.Time, int64]{
+			{
+				Name:  "Success",
+				Input: time.Now().Add(-time.Minute),
+				CheckFn: func(ctx context.Context, t *testing.T, res testingx.TestResult[int64]) {
+					require.NoError(t, res.Err)
+					assert.Equal(t, int64(1), res.Success)
+
+					_, err := accessTokenSvc.GetAccessTokenSession(ctx, "expired-jti", &oauth2.JWTSession{})
+					assert.ErrorIs(t, err, fosite.ErrNotFound)
+				},
+			},
+		}
+
+		testingx.RunTests(context.Background(), t, testCases, runFn)
+	})
+}
+
+// TestDeleteExpiredTokensBatches checks that expired tokens are deleted in more than one batch.
+func TestDeleteExpiredTokensBatches(t *testing.T) {
+	t.Parallel()
+
+	db, shutdown := testserver.NewDBForTest(t, testserver.CustomVersionOpt(TestServerCRDBVersion))
+
+	err := runMigrations(db)
+	if err != nil {
+		shutdown()
+		t.Fatal(err)
+	}
+
+	t.Cleanup(shutdown)
+
+	oauthClientStore, err := newOAuthClientManager(db)
+	require.NoError(t, err)
+
+	accessTokenSvc, err := newAccessTokenService(db, oauthClientStore)
+	require.NoError(t, err)
+
+	accessTokenSvc.deleteBatchSize = 2
+
+	subject := gidx.MustNewID(types.IdentityUserIDPrefix)
+	expiresAt := time.Now().Add(-time.Hour).UTC().Round(time.Second)
+
+	for i := range 5 {
+		request := fosite.NewRequest()
+
+		request.ID = fmt.Sprintf("expired-%d", i)
+		request.RequestedAt = time.Now().UTC().Round(time.Second)
+		request.Session = &oauth2.JWTSession{
+			JWTClaims: &jwt.JWTClaims{
+				Subject: subject.String(),
+			},
+			ExpiresAt: map[fosite.TokenType]time.Time{
+				fosite.AccessToken: expiresAt,
+			},
+		}
+
+		require.NoError(t, accessTokenSvc.CreateAccessTokenSession(context.Background(), request.ID+"-jti", request))
+	}
+
+	runFn := func(ctx context.Context, input time.Time) testingx.TestResult[int64] {
+		count, err := accessTokenSvc.DeleteExpiredTokens(ctx, input)
+
+		return testingx.TestResult[int64]{
+			Success: count,
+			Err:     err,
+		}
+	}
+
+	testCases := []testingx.TestCase[time.Time, int64]{
+		{
+			Name:  "Success",
+			Input: time.Now().Add(-time.Minute),
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[int64]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, int64(5), res.Success)
+
+				var remaining int
+
+				require.NoError(t, db.QueryRow(`SELECT count(*) FROM access_tokens`).Scan(&remaining))
+				assert.Zero(t, remaining)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
+
+// TestDeleteNonExpiringRefreshTokens checks that refresh tokens issued without an expiry are deleted once
+// their session has been revoked.
+func TestDeleteNonExpiringRefreshTokens(t *testing.T) {
+	t.Parallel()
+
+	db, shutdown := testserver.NewDBForTest(t, testserver.CustomVersionOpt(TestServerCRDBVersion))
+
+	err := runMigrations(db)
+	if err != nil {
+		shutdown()
+		t.Fatal(err)
+	}
+
+	t.Cleanup(shutdown)
+
+	oauthClientStore, err := newOAuthClientManager(db)
+	require.NoError(t, err)
+
+	accessTokenSvc, err := newAccessTokenService(db, oauthClientStore)
+	require.NoError(t, err)
+
+	refreshTokenSvc, err := newRefreshTokenService(db, oauthClientStore)
+	require.NoError(t, err)
+
+	subject := gidx.MustNewID(types.IdentityUserIDPrefix)
+
+	createToken := func(requestID, signature string) {
+		request := fosite.NewRequest()
+
+		request.ID = requestID
+		request.RequestedAt = time.Now().Add(-time.Hour).UTC().Round(time.Second)
+		request.Session = &oauth2.JWTSession{
+			JWTClaims: &jwt.JWTClaims{
+				Subject: subject.String(),
+			},
+		}
+
+		require.NoError(t, refreshTokenSvc.CreateRefreshTokenSession(context.Background(), signature, "", request))
+	}
+
+	// The revoked session has no active refresh token left.
+	createToken("revoked", "revoked-sig")
+	require.NoError(t, refreshTokenSvc.RevokeRefreshToken(context.Background(), "revoked"))
+
+	// The rotated session has an inactive refresh token alongside the active one it was rotated to.
+	createToken("rotated", "rotated-sig")
+	require.NoError(t, refreshTokenSvc.RotateRefreshToken(context.Background(), "rotated", "rotated-sig"))
+	createToken("rotated", "rotated-new-sig")
+
+	runFn := func(ctx context.Context, input time.Time) testingx.TestResult[int64] {
+		count, err := accessTokenSvc.DeleteExpiredTokens(ctx, input)
+
+		return testingx.TestResult[int64]{
+			Success: count,
+			Err:     err,
+		}
+	}
+
+	testCases := []testingx.TestCase[time.Time, int64]{
+		{
+			Name:  "Success",
+			Input: time.Now().Add(-time.Minute),
+			CheckFn: func(ctx context.Context, t *testing.T, res testingx.TestResult[int64]) {
+				require.NoError(t, res.Err)
+				assert.Equal(t, int64(1), res.Success)
+
+				_, err := refreshTokenSvc.GetRefreshTokenSession(ctx, "revoked-sig", &oauth2.JWTSession{})
+				assert.ErrorIs(t, err, fosite.ErrNotFound)
+
+				_, err = refreshTokenSvc.GetRefreshTokenSession(ctx, "rotated-sig", &oauth2.JWTSession{})
+				assert.ErrorIs(t, err, fosite.ErrInactiveToken)
+
+				_, err = refreshTokenSvc.GetRefreshTokenSession(ctx, "rotated-new-sig", &oauth2.JWTSession{})
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
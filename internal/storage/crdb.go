@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 
-	"github.com/ory/fosite"
 	"go.infratographer.com/x/crdbx"
 )
 
@@ -13,24 +12,13 @@ type engine struct {
 	*userInfoService
 	*oauthClientManager
 	*groupService
+	*refreshTokenService
+	*accessTokenService
+	*signingKeyService
+	*lastSeenService
 	db *sql.DB
 }
 
-// CreateAccessTokenSession implements oauth2.AccessTokenStorage
-func (*engine) CreateAccessTokenSession(_ context.Context, _ string, _ fosite.Requester) (err error) {
-	return nil
-}
-
-// DeleteAccessTokenSession implements oauth2.AccessTokenStorage
-func (*engine) DeleteAccessTokenSession(_ context.Context, _ string) (err error) {
-	panic("unimplemented")
-}
-
-// GetAccessTokenSession implements oauth2.AccessTokenStorage
-func (*engine) GetAccessTokenSession(_ context.Context, _ string, _ fosite.Session) (request fosite.Requester, err error) {
-	panic("unimplemented")
-}
-
 func newCRDBEngine(config crdbx.Config, options ...EngineOption) (*engine, error) {
 	// Always enable tracing for the DB; spans will just be associated with a no-op tracer
 	db, err := crdbx.NewDB(config, true)
@@ -58,12 +46,36 @@ func newCRDBEngine(config crdbx.Config, options ...EngineOption) (*engine, error
 		return nil, err
 	}
 
+	refreshTokenSvc, err := newRefreshTokenService(db, oauthClientManager)
+	if err != nil {
+		return nil, err
+	}
+
+	accessTokenSvc, err := newAccessTokenService(db, oauthClientManager)
+	if err != nil {
+		return nil, err
+	}
+
+	signingKeySvc, err := newSigningKeyService(db)
+	if err != nil {
+		return nil, err
+	}
+
+	lastSeenSvc, err := newLastSeenService(db)
+	if err != nil {
+		return nil, err
+	}
+
 	out := &engine{
-		issuerService:      issSvc,
-		userInfoService:    userInfoSvc,
-		oauthClientManager: oauthClientManager,
-		groupService:       groupSvc,
-		db:                 db,
+		issuerService:       issSvc,
+		userInfoService:     userInfoSvc,
+		oauthClientManager:  oauthClientManager,
+		groupService:        groupSvc,
+		refreshTokenService: refreshTokenSvc,
+		accessTokenService:  accessTokenSvc,
+		signingKeyService:   signingKeySvc,
+		lastSeenService:     lastSeenSvc,
+		db:                  db,
 	}
 
 	for _, opt := range options {
@@ -81,13 +93,38 @@ func (eng *engine) BeginContext(ctx context.Context) (context.Context, error) {
 }
 
 func (eng *engine) CommitContext(ctx context.Context) error {
+	defer eng.releaseIssuerCache(ctx)
+
 	return commitContextTx(ctx)
 }
 
 func (eng *engine) RollbackContext(ctx context.Context) error {
+	defer eng.releaseIssuerCache(ctx)
+
 	return rollbackContextTx(ctx)
 }
 
+func (eng *engine) releaseIssuerCache(ctx context.Context) {
+	if eng.issuerService.cache != nil {
+		eng.issuerService.cache.release(ctx)
+	}
+}
+
+// BeginTX implements fosite storage.Transactional
+func (eng *engine) BeginTX(ctx context.Context) (context.Context, error) {
+	return eng.BeginContext(ctx)
+}
+
+// Commit implements fosite storage.Transactional
+func (eng *engine) Commit(ctx context.Context) error {
+	return eng.CommitContext(ctx)
+}
+
+// Rollback implements fosite storage.Transactional
+func (eng *engine) Rollback(ctx context.Context) error {
+	return eng.RollbackContext(ctx)
+}
+
 func (eng *engine) seedDatabase(ctx context.Context, data SeedData) error {
 	return eng.issuerService.seedDatabase(ctx, data.Issuers)
 }
@@ -3,6 +3,7 @@ package storage
 import (
 	"io"
 	"os"
+	"time"
 
 	"go.infratographer.com/x/crdbx"
 	"go.infratographer.com/x/gidx"
@@ -11,13 +12,28 @@ import (
 
 // SeedIssuer represents the seed data for a single issuer.
 type SeedIssuer struct {
-	OwnerID         gidx.PrefixedID   `yaml:"ownerID"`
-	ID              gidx.PrefixedID   `yaml:"id"`
-	Name            string            `yaml:"name"`
-	URI             string            `yaml:"uri"`
-	JWKSURI         string            `yaml:"jwksURI"`
-	ClaimMappings   map[string]string `yaml:"claimMappings"`
-	ClaimConditions string            `yaml:"claimConditions"`
+	OwnerID                   gidx.PrefixedID   `yaml:"ownerID"`
+	ID                        gidx.PrefixedID   `yaml:"id"`
+	Name                      string            `yaml:"name"`
+	URI                       string            `yaml:"uri"`
+	JWKSURI                   string            `yaml:"jwksURI"`
+	UserInfoURI               string            `yaml:"userinfoURI"`
+	Discovery                 bool              `yaml:"discovery"`
+	ClientID                  string            `yaml:"clientID"`
+	IntrospectionURI          string            `yaml:"introspectionURI"`
+	IntrospectionClientID     string            `yaml:"introspectionClientID"`
+	IntrospectionClientSecret string            `yaml:"introspectionClientSecret"`
+	AllowedAudiences          []string          `yaml:"allowedAudiences"`
+	AllowedAlgorithms         []string          `yaml:"allowedAlgorithms"`
+	RequiredAudiences         []string          `yaml:"requiredAudiences"`
+	MaxTokenAge               time.Duration     `yaml:"maxTokenAge"`
+	ClockSkew                 time.Duration     `yaml:"clockSkew"`
+	UserInfoUpdateMode        string            `yaml:"userInfoUpdateMode"`
+	ClaimMappings             map[string]string `yaml:"claimMappings"`
+	ClaimConditions           string            `yaml:"claimConditions"`
+	ActorConditions           string            `yaml:"actorConditions"`
+	ScopeMapping              string            `yaml:"scopeMapping"`
+	ProfileMapping            string            `yaml:"profileMapping"`
 }
 
 // SeedData represents the seed data for an identity-api instance on startup.
@@ -48,14 +64,15 @@ func parseSeedData(path string) (SeedData, error) {
 	return out, nil
 }
 
-// SeedDatabase seeds the database using the data at the given path
-func SeedDatabase(config crdbx.Config, path string) error {
+// SeedDatabase seeds the database using the data at the given path. The given options are applied
+// before the data is seeded.
+func SeedDatabase(config crdbx.Config, path string, opts ...EngineOption) error {
 	data, err := parseSeedData(path)
 	if err != nil {
 		return err
 	}
 
-	_, err = newCRDBEngine(config, WithSeedData(data))
+	_, err = newCRDBEngine(config, append(opts, WithSeedData(data))...)
 
 	return err
 }
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+)
+
+// encryptionKeySize selects AES-256.
+const encryptionKeySize = 32
+
+// errCiphertextTooShort is returned when a stored ciphertext is shorter than its nonce.
+var errCiphertextTooShort = errors.New("ciphertext too short")
+
+// newAEAD derives an AES-GCM key from the given secret. The info binds the derived key to its use,
+// so the same secret may encrypt different kinds of values.
+func newAEAD(secret, info string) (cipher.AEAD, error) {
+	key, err := hkdf.Key(sha256.New, []byte(secret), nil, info, encryptionKeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the plaintext, binding it to the given additional data. The nonce is prepended to
+// the ciphertext.
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts a ciphertext created by seal.
+func open(aead cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
+	size := aead.NonceSize()
+	if len(ciphertext) < size {
+		return nil, errCiphertextTooShort
+	}
+
+	return aead.Open(nil, ciphertext[:size], ciphertext[size:], additionalData)
+}
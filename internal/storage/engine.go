@@ -22,6 +22,11 @@ type Engine interface {
 	types.UserInfoService
 	types.OAuthClientManager
 	types.GroupService
+	types.RefreshTokenService
+	types.AccessTokenService
+	types.TokenRevocationService
+	types.SigningKeyService
+	types.LastSeenService
 	TransactionManager
 }
 
@@ -58,14 +63,44 @@ func buildIssuerFromSeed(seed SeedIssuer) (types.Issuer, error) {
 		return types.Issuer{}, err
 	}
 
+	actorConditions, err := types.NewClaimConditions(seed.ActorConditions)
+	if err != nil {
+		return types.Issuer{}, err
+	}
+
+	scopeMapping, err := types.NewScopeMapping(seed.ScopeMapping)
+	if err != nil {
+		return types.Issuer{}, err
+	}
+
+	profileMapping, err := types.NewProfileMapping(seed.ProfileMapping)
+	if err != nil {
+		return types.Issuer{}, err
+	}
+
 	out := types.Issuer{
-		OwnerID:         seed.OwnerID,
-		ID:              seed.ID,
-		Name:            seed.Name,
-		URI:             seed.URI,
-		JWKSURI:         seed.JWKSURI,
-		ClaimMappings:   claimMappings,
-		ClaimConditions: claimConditions,
+		OwnerID:                   seed.OwnerID,
+		ID:                        seed.ID,
+		Name:                      seed.Name,
+		URI:                       seed.URI,
+		JWKSURI:                   seed.JWKSURI,
+		UserInfoURI:               seed.UserInfoURI,
+		Discovery:                 seed.Discovery,
+		ClientID:                  seed.ClientID,
+		IntrospectionURI:          seed.IntrospectionURI,
+		IntrospectionClientID:     seed.IntrospectionClientID,
+		IntrospectionClientSecret: seed.IntrospectionClientSecret,
+		AllowedAudiences:          seed.AllowedAudiences,
+		AllowedAlgorithms:         seed.AllowedAlgorithms,
+		RequiredAudiences:         seed.RequiredAudiences,
+		MaxTokenAge:               seed.MaxTokenAge,
+		ClockSkew:                 seed.ClockSkew,
+		UserInfoUpdateMode:        types.UserInfoUpdateMode(seed.UserInfoUpdateMode),
+		ClaimMappings:             claimMappings,
+		ClaimConditions:           claimConditions,
+		ActorConditions:           actorConditions,
+		ScopeMapping:              scopeMapping,
+		ProfileMapping:            profileMapping,
 	}
 
 	return out, nil
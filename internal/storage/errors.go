@@ -11,6 +11,12 @@ var (
 	ErrorMissingContextTx = fmt.Errorf("no transaction provided in context")
 	// ErrorInvalidContextTx represents an error where the given context transaction is of the wrong type.
 	ErrorInvalidContextTx = fmt.Errorf("invalid type for transaction context")
+	// ErrorSigningKeyDecrypt represents an error where a stored signing key could not be decrypted,
+	// such as when the encryption key has changed.
+	ErrorSigningKeyDecrypt = fmt.Errorf("failed to decrypt signing key")
+	// ErrorIssuerSecretDecrypt represents an error where the stored introspection client secret of an
+	// issuer could not be decrypted, such as when the encryption key has changed.
+	ErrorIssuerSecretDecrypt = fmt.Errorf("failed to decrypt issuer introspection client secret")
 )
 
 const (
@@ -19,11 +19,15 @@ var groupCols = struct {
 	OwnerID     string
 	Name        string
 	Description string
+	CreatedAt   string
+	UpdatedAt   string
 }{
 	ID:          "id",
 	OwnerID:     "owner_id",
 	Name:        "name",
 	Description: "description",
+	CreatedAt:   "created_at",
+	UpdatedAt:   "updated_at",
 }
 
 var groupMemberCols = struct {
@@ -37,6 +41,7 @@ var groupMemberCols = struct {
 var groupColsStr = strings.Join([]string{
 	groupCols.ID, groupCols.OwnerID,
 	groupCols.Name, groupCols.Description,
+	groupCols.CreatedAt, groupCols.UpdatedAt,
 }, ", ")
 
 const (
@@ -141,6 +146,7 @@ func (gs *groupService) scanGroup(row *sql.Row) (*types.Group, error) {
 
 	err := row.Scan(
 		&g.ID, &g.OwnerID, &g.Name, &g.Description,
+		&g.CreatedAt, &g.UpdatedAt,
 	)
 
 	switch {
@@ -177,7 +183,7 @@ func (gs *groupService) ListGroupsByOwner(ctx context.Context, ownerID gidx.Pref
 	for rows.Next() {
 		g := &types.Group{}
 
-		if err := rows.Scan(&g.ID, &g.OwnerID, &g.Name, &g.Description); err != nil {
+		if err := rows.Scan(&g.ID, &g.OwnerID, &g.Name, &g.Description, &g.CreatedAt, &g.UpdatedAt); err != nil {
 			return nil, err
 		}
 
@@ -209,11 +215,13 @@ func (gs *groupService) UpdateGroup(ctx context.Context, id gidx.PrefixedID, upd
 	}
 
 	q := fmt.Sprintf(
-		"UPDATE groups SET (%s, %s) = ($1, $2) WHERE %s = $3",
-		groupCols.Name, groupCols.Description, groupCols.ID,
+		"UPDATE groups SET (%s, %s, %s) = ($1, $2, now()) WHERE %s = $3 RETURNING %s",
+		groupCols.Name, groupCols.Description, groupCols.UpdatedAt, groupCols.ID, groupCols.UpdatedAt,
 	)
 
-	if _, err := tx.ExecContext(ctx, q, incoming.Name, incoming.Description, incoming.ID); err != nil {
+	row := tx.QueryRowContext(ctx, q, incoming.Name, incoming.Description, incoming.ID)
+
+	if err := row.Scan(&incoming.UpdatedAt); err != nil {
 		if isPQDuplicateKeyError(err) {
 			return nil, types.ErrGroupExists
 		}
@@ -413,6 +421,8 @@ func (gs *groupService) ListGroupsBySubject(ctx context.Context, subject gidx.Pr
 			fmt.Sprintf("%s.%s", groupsTable, groupCols.Name),
 			fmt.Sprintf("%s.%s", groupsTable, groupCols.Description),
 			fmt.Sprintf("%s.%s", groupsTable, groupCols.OwnerID),
+			fmt.Sprintf("%s.%s", groupsTable, groupCols.CreatedAt),
+			fmt.Sprintf("%s.%s", groupsTable, groupCols.UpdatedAt),
 		}, ", "),
 		// FROM
 		membersTable,
@@ -446,7 +456,7 @@ func (gs *groupService) ListGroupsBySubject(ctx context.Context, subject gidx.Pr
 	for rows.Next() {
 		g := &types.Group{}
 
-		if err := rows.Scan(&g.ID, &g.Name, &g.Description, &g.OwnerID); err != nil {
+		if err := rows.Scan(&g.ID, &g.Name, &g.Description, &g.OwnerID, &g.CreatedAt, &g.UpdatedAt); err != nil {
 			return nil, err
 		}
 
@@ -456,6 +466,42 @@ func (gs *groupService) ListGroupsBySubject(ctx context.Context, subject gidx.Pr
 	return groups, nil
 }
 
+// ListGroupIDsBySubject retrieves the IDs of all groups that a subject is a member of. Unlike
+// ListGroupsBySubject, memberships are read within the context transaction, if any, so memberships
+// added recently are included.
+func (gs *groupService) ListGroupIDsBySubject(ctx context.Context, subject gidx.PrefixedID) ([]gidx.PrefixedID, error) {
+	q := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE %s = $1 ORDER BY %s",
+		groupMemberCols.GroupID, membersTable, groupMemberCols.SubjectID, groupMemberCols.GroupID,
+	)
+
+	conn, err := contextConn(ctx, gs.db)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, q, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close() //nolint:errcheck
+
+	var groupIDs []gidx.PrefixedID
+
+	for rows.Next() {
+		var groupID gidx.PrefixedID
+
+		if err := rows.Scan(&groupID); err != nil {
+			return nil, err
+		}
+
+		groupIDs = append(groupIDs, groupID)
+	}
+
+	return groupIDs, rows.Err()
+}
+
 func (gs *groupService) GroupMembersCount(ctx context.Context, groupID gidx.PrefixedID) (int, error) {
 	q := fmt.Sprintf(
 		"SELECT COUNT(*) FROM %s WHERE %s = $1",
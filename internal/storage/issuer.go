@@ -2,10 +2,13 @@ package storage
 
 import (
 	"context"
+	"crypto/cipher"
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"go.infratographer.com/x/gidx"
 
@@ -14,21 +17,57 @@ import (
 )
 
 var issuerCols = struct {
-	OwnerID    string
-	ID         string
-	Name       string
-	URI        string
-	JWKSURI    string
-	Mappings   string
-	Conditions string
+	OwnerID                      string
+	ID                           string
+	Name                         string
+	URI                          string
+	JWKSURI                      string
+	UserInfoURI                  string
+	Discovery                    string
+	ClientID                     string
+	Introspection                string
+	IntrospectionID              string
+	IntrospectionSecret          string
+	IntrospectionSecretEncrypted string
+	AllowedAudiences             string
+	AllowedAlgorithms            string
+	RequiredAudiences            string
+	MaxTokenAge                  string
+	ClockSkew                    string
+	UpdateMode                   string
+	Mappings                     string
+	Conditions                   string
+	ActorConditions              string
+	ScopeMapping                 string
+	ProfileMapping               string
+	CreatedAt                    string
+	UpdatedAt                    string
 }{
-	OwnerID:    "owner_id",
-	ID:         "id",
-	Name:       "name",
-	URI:        "uri",
-	JWKSURI:    "jwksuri",
-	Mappings:   "mappings",
-	Conditions: "conditions",
+	OwnerID:                      "owner_id",
+	ID:                           "id",
+	Name:                         "name",
+	URI:                          "uri",
+	JWKSURI:                      "jwksuri",
+	UserInfoURI:                  "userinfo_uri",
+	Discovery:                    "discovery",
+	ClientID:                     "client_id",
+	Introspection:                "introspection_uri",
+	IntrospectionID:              "introspection_client_id",
+	IntrospectionSecret:          "introspection_client_secret",
+	IntrospectionSecretEncrypted: "introspection_client_secret_encrypted",
+	AllowedAudiences:             "allowed_audiences",
+	AllowedAlgorithms:            "allowed_algorithms",
+	RequiredAudiences:            "required_audiences",
+	MaxTokenAge:                  "max_token_age",
+	ClockSkew:                    "clock_skew",
+	UpdateMode:                   "user_info_update_mode",
+	Mappings:                     "mappings",
+	Conditions:                   "conditions",
+	ActorConditions:              "actor_conditions",
+	ScopeMapping:                 "scope_mapping",
+	ProfileMapping:               "profile_mapping",
+	CreatedAt:                    "created_at",
+	UpdatedAt:                    "updated_at",
 }
 
 var (
@@ -40,13 +79,35 @@ var (
 		issuerCols.JWKSURI,
 		issuerCols.Mappings,
 		issuerCols.Conditions,
+		issuerCols.ActorConditions,
+		issuerCols.ClientID,
+		issuerCols.Introspection,
+		issuerCols.IntrospectionID,
+		issuerCols.IntrospectionSecret,
+		issuerCols.AllowedAudiences,
+		issuerCols.ScopeMapping,
+		issuerCols.UserInfoURI,
+		issuerCols.Discovery,
+		issuerCols.AllowedAlgorithms,
+		issuerCols.RequiredAudiences,
+		issuerCols.MaxTokenAge,
+		issuerCols.ClockSkew,
+		issuerCols.UpdateMode,
+		issuerCols.ProfileMapping,
+		issuerCols.IntrospectionSecretEncrypted,
 	}
 	issuerColumnsStr = strings.Join(issuerColumns, ", ")
+
+	// issuerSelectColumnsStr adds the columns set by the database to the issuer columns.
+	issuerSelectColumnsStr = strings.Join(append(slices.Clone(issuerColumns), issuerCols.CreatedAt, issuerCols.UpdatedAt), ", ")
 )
 
 // issuerService represents a SQL-backed issuer service.
 type issuerService struct {
-	db *sql.DB
+	db    *sql.DB
+	cache *issuerCache
+	// aead encrypts introspection client secrets, if an encryption key is set.
+	aead cipher.AEAD
 }
 
 func newIssuerService(db *sql.DB) (*issuerService, error) {
@@ -69,7 +130,7 @@ func (s *issuerService) seedDatabase(ctx context.Context, issuers []SeedIssuer)
 			return err
 		}
 
-		err = s.insertIssuer(ctx, iss)
+		err = s.insertIssuer(ctx, &iss)
 		if err != nil {
 			return err
 		}
@@ -89,7 +150,7 @@ func (s *issuerService) seedDatabase(ctx context.Context, issuers []SeedIssuer)
 
 // CreateIssuer creates an issuer.
 func (s *issuerService) CreateIssuer(ctx context.Context, iss types.Issuer) (*types.Issuer, error) {
-	err := s.insertIssuer(ctx, iss)
+	err := s.insertIssuer(ctx, &iss)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +161,7 @@ func (s *issuerService) CreateIssuer(ctx context.Context, iss types.Issuer) (*ty
 // GetIssuerByID gets an issuer by ID. This function will use a transaction in the context if one
 // exists.
 func (s *issuerService) GetIssuerByID(ctx context.Context, id gidx.PrefixedID) (*types.Issuer, error) {
-	query := fmt.Sprintf("SELECT %s FROM issuers WHERE id = $1", issuerColumnsStr)
+	query := fmt.Sprintf("SELECT %s FROM issuers WHERE id = $1", issuerSelectColumnsStr)
 
 	var row *sql.Row
 
@@ -122,7 +183,7 @@ func (s *issuerService) GetIssuerByID(ctx context.Context, id gidx.PrefixedID) (
 func (s *issuerService) GetOwnerIssuers(ctx context.Context, id gidx.PrefixedID, pagination crdbx.Paginator) (types.Issuers, error) {
 	paginate := crdbx.Paginate(pagination, crdbx.ContextAsOfSystemTime(ctx, "-1m"))
 
-	query := fmt.Sprintf("SELECT %s FROM issuers %s WHERE owner_id = $1 %s %s %s", issuerColumnsStr,
+	query := fmt.Sprintf("SELECT %s FROM issuers %s WHERE owner_id = $1 %s %s %s", issuerSelectColumnsStr,
 		paginate.AsOfSystemTime(),
 		paginate.AndWhere(2), //nolint:mnd
 		paginate.OrderClause(),
@@ -151,9 +212,29 @@ func (s *issuerService) GetOwnerIssuers(ctx context.Context, id gidx.PrefixedID,
 }
 
 // GetIssuerByURI looks up the given issuer by URI, returning the issuer if one exists. This function will
-// use a transaction in the context if one exists.
+// use a transaction in the context if one exists. If the issuer cache is enabled, cached issuers are
+// returned without a lookup.
 func (s *issuerService) GetIssuerByURI(ctx context.Context, uri string) (*types.Issuer, error) {
-	query := fmt.Sprintf("SELECT %s FROM issuers WHERE uri = $1", issuerColumnsStr)
+	if s.cache == nil {
+		return s.fetchIssuerByURI(ctx, uri)
+	}
+
+	if iss, ok := s.cache.get(uri); ok {
+		return iss, nil
+	}
+
+	iss, err := s.fetchIssuerByURI(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.set(ctx, iss)
+
+	return iss, nil
+}
+
+func (s *issuerService) fetchIssuerByURI(ctx context.Context, uri string) (*types.Issuer, error) {
+	query := fmt.Sprintf("SELECT %s FROM issuers WHERE uri = $1", issuerSelectColumnsStr)
 
 	var row *sql.Row
 
@@ -171,6 +252,36 @@ func (s *issuerService) GetIssuerByURI(ctx context.Context, uri string) (*types.
 	return s.scanIssuer(row)
 }
 
+// ListDiscoveryIssuers lists the issuers whose endpoints are discovered from their OIDC provider metadata.
+func (s *issuerService) ListDiscoveryIssuers(ctx context.Context) (types.Issuers, error) {
+	conn, err := contextConn(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM issuers WHERE %s ORDER BY id", issuerSelectColumnsStr, issuerCols.Discovery)
+
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close() //nolint:errcheck
+
+	var issuers types.Issuers
+
+	for rows.Next() {
+		iss, err := s.scanIssuer(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		issuers = append(issuers, iss)
+	}
+
+	return issuers, rows.Err()
+}
+
 // UpdateIssuer updates an issuer with the given values.
 func (s *issuerService) UpdateIssuer(ctx context.Context, id gidx.PrefixedID, update types.IssuerUpdate) (*types.Issuer, error) {
 	tx, err := getContextTx(ctx)
@@ -183,12 +294,27 @@ func (s *issuerService) UpdateIssuer(ctx context.Context, id gidx.PrefixedID, up
 		return nil, err
 	}
 
+	if update.IntrospectionClientSecret != nil {
+		secret, encryptedSecret, err := s.sealIntrospectionSecret(id, *update.IntrospectionClientSecret)
+		if err != nil {
+			return nil, err
+		}
+
+		bindings = append(bindings,
+			colBinding{column: issuerCols.IntrospectionSecret, value: secret},
+			colBinding{column: issuerCols.IntrospectionSecretEncrypted, value: encryptedSecret},
+		)
+	}
+
 	params, args := colBindingsToParams(bindings)
+	params = withUpdatedAt(params, issuerCols.UpdatedAt)
 
-	query := fmt.Sprintf("UPDATE issuers SET %s WHERE id = $%d RETURNING %s", params, len(args)+1, issuerColumnsStr)
+	query := fmt.Sprintf("UPDATE issuers SET %s WHERE id = $%d RETURNING %s", params, len(args)+1, issuerSelectColumnsStr)
 
 	args = append(args, id)
 
+	s.invalidateIssuer(ctx, id)
+
 	row := tx.QueryRowContext(ctx, query, args...)
 
 	return s.scanIssuer(row)
@@ -201,6 +327,13 @@ func (s *issuerService) DeleteIssuer(ctx context.Context, id gidx.PrefixedID) er
 		return err
 	}
 
+	s.invalidateIssuer(ctx, id)
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE subject IN (SELECT id FROM user_info WHERE iss_id = $1);`, id)
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.ExecContext(ctx, `DELETE FROM user_info WHERE iss_id = $1;`, id)
 	if err != nil {
 		return err
@@ -223,6 +356,13 @@ func (s *issuerService) DeleteIssuer(ctx context.Context, id gidx.PrefixedID) er
 	return nil
 }
 
+// invalidateIssuer removes the issuer with the given ID from the issuer cache, if it is enabled.
+func (s *issuerService) invalidateIssuer(ctx context.Context, id gidx.PrefixedID) {
+	if s.cache != nil {
+		s.cache.invalidate(ctx, id)
+	}
+}
+
 type rowScanner interface {
 	Scan(dest ...any) error
 }
@@ -232,9 +372,21 @@ func (s *issuerService) scanIssuer(row rowScanner) (*types.Issuer, error) {
 		iss     types.Issuer
 		mapping sql.NullString
 		cond    sql.NullString
+		actCond sql.NullString
+		aud     string
+		scopes  sql.NullString
+		profile sql.NullString
+		secret  []byte
+		algs    string
+		reqAud  string
+		maxAge  int64
+		skew    int64
 	)
 
-	err := row.Scan(&iss.OwnerID, &iss.ID, &iss.Name, &iss.URI, &iss.JWKSURI, &mapping, &cond)
+	err := row.Scan(&iss.OwnerID, &iss.ID, &iss.Name, &iss.URI, &iss.JWKSURI, &mapping, &cond, &actCond, &iss.ClientID,
+		&iss.IntrospectionURI, &iss.IntrospectionClientID, &iss.IntrospectionClientSecret, &aud, &scopes,
+		&iss.UserInfoURI, &iss.Discovery, &algs, &reqAud, &maxAge, &skew, &iss.UserInfoUpdateMode, &profile,
+		&secret, &iss.CreatedAt, &iss.UpdatedAt)
 
 	switch {
 	case errors.Is(err, sql.ErrNoRows):
@@ -244,6 +396,17 @@ func (s *issuerService) scanIssuer(row rowScanner) (*types.Issuer, error) {
 	default:
 	}
 
+	iss.IntrospectionClientSecret, err = s.openIntrospectionSecret(iss.ID, iss.IntrospectionClientSecret, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	iss.AllowedAudiences = strings.Fields(aud)
+	iss.AllowedAlgorithms = strings.Fields(algs)
+	iss.RequiredAudiences = strings.Fields(reqAud)
+	iss.MaxTokenAge = time.Duration(maxAge) * time.Second
+	iss.ClockSkew = time.Duration(skew) * time.Second
+
 	c := types.ClaimsMapping{}
 	conditions := types.ClaimConditions{}
 
@@ -264,10 +427,41 @@ func (s *issuerService) scanIssuer(row rowScanner) (*types.Issuer, error) {
 		iss.ClaimConditions = &conditions
 	}
 
+	if actCond.Valid {
+		actorConditions := types.ClaimConditions{}
+
+		if err = actorConditions.UnmarshalJSON([]byte(actCond.String)); err != nil {
+			return nil, err
+		}
+
+		iss.ActorConditions = &actorConditions
+	}
+
+	if scopes.Valid {
+		scopeMapping := types.ScopeMapping{}
+
+		if err = scopeMapping.UnmarshalJSON([]byte(scopes.String)); err != nil {
+			return nil, err
+		}
+
+		iss.ScopeMapping = &scopeMapping
+	}
+
+	if profile.Valid {
+		profileMapping := types.ProfileMapping{}
+
+		if err = profileMapping.UnmarshalJSON([]byte(profile.String)); err != nil {
+			return nil, err
+		}
+
+		iss.ProfileMapping = &profileMapping
+	}
+
 	return &iss, nil
 }
 
-func (s *issuerService) insertIssuer(ctx context.Context, iss types.Issuer) error {
+// insertIssuer inserts the issuer, setting the times it was created and updated.
+func (s *issuerService) insertIssuer(ctx context.Context, iss *types.Issuer) error {
 	tx, err := getContextTx(ctx)
 	if err != nil {
 		return err
@@ -277,10 +471,11 @@ func (s *issuerService) insertIssuer(ctx context.Context, iss types.Issuer) erro
         INSERT INTO issuers (
             %s
         ) VALUES
-        ($1, $2, $3, $4, $5, $6, $7);
+        ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
+        RETURNING %s, %s;
         `
 
-	q = fmt.Sprintf(q, issuerColumnsStr)
+	q = fmt.Sprintf(q, issuerColumnsStr, issuerCols.CreatedAt, issuerCols.UpdatedAt)
 
 	mappings, err := iss.ClaimMappings.MarshalJSON()
 	if err != nil {
@@ -296,7 +491,39 @@ func (s *issuerService) insertIssuer(ctx context.Context, iss types.Issuer) erro
 		}
 	}
 
-	_, err = tx.ExecContext(
+	actorConditions := []byte{}
+
+	if iss.ActorConditions != nil {
+		actorConditions, err = iss.ActorConditions.MarshalJSON()
+		if err != nil {
+			return err
+		}
+	}
+
+	scopeMapping := []byte{}
+
+	if iss.ScopeMapping != nil {
+		scopeMapping, err = iss.ScopeMapping.MarshalJSON()
+		if err != nil {
+			return err
+		}
+	}
+
+	profileMapping := []byte{}
+
+	if iss.ProfileMapping != nil {
+		profileMapping, err = iss.ProfileMapping.MarshalJSON()
+		if err != nil {
+			return err
+		}
+	}
+
+	secret, encryptedSecret, err := s.sealIntrospectionSecret(iss.ID, iss.IntrospectionClientSecret)
+	if err != nil {
+		return err
+	}
+
+	return tx.QueryRowContext(
 		ctx,
 		q,
 		iss.OwnerID,
@@ -306,7 +533,21 @@ func (s *issuerService) insertIssuer(ctx context.Context, iss types.Issuer) erro
 		iss.JWKSURI,
 		string(mappings),
 		string(conditions),
-	)
-
-	return err
+		string(actorConditions),
+		iss.ClientID,
+		iss.IntrospectionURI,
+		iss.IntrospectionClientID,
+		secret,
+		strings.Join(iss.AllowedAudiences, " "),
+		string(scopeMapping),
+		iss.UserInfoURI,
+		iss.Discovery,
+		strings.Join(iss.AllowedAlgorithms, " "),
+		strings.Join(iss.RequiredAudiences, " "),
+		int64(iss.MaxTokenAge/time.Second),
+		int64(iss.ClockSkew/time.Second),
+		iss.UserInfoUpdateMode,
+		string(profileMapping),
+		encryptedSecret,
+	).Scan(&iss.CreatedAt, &iss.UpdatedAt)
 }
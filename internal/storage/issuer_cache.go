@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// DefaultIssuerCacheTTL is how long issuers are cached if no TTL is configured.
+const DefaultIssuerCacheTTL = 30 * time.Second
+
+// WithIssuerCache caches issuers looked up by URI for the given TTL. Issuers updated or deleted
+// through this engine are removed from the cache once the change is committed, while changes made
+// through other replicas are picked up when the cached issuer expires. A zero TTL uses
+// DefaultIssuerCacheTTL, and a negative TTL disables the cache.
+func WithIssuerCache(ttl time.Duration) EngineOption {
+	return func(e *engine) error {
+		if ttl < 0 {
+			return nil
+		}
+
+		if ttl == 0 {
+			ttl = DefaultIssuerCacheTTL
+		}
+
+		e.issuerService.cache = newIssuerCache(ttl)
+
+		return nil
+	}
+}
+
+type issuerCacheEntry struct {
+	issuer    *types.Issuer
+	expiresAt time.Time
+}
+
+// issuerCache caches issuers by URI. Cached issuers are shared between callers, and must not be modified.
+type issuerCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu    sync.RWMutex
+	byURI map[string]issuerCacheEntry
+	// pending holds the issuers changed by each open transaction, which are removed from the cache
+	// again once the transaction ends, as they may have been cached from another connection meanwhile.
+	pending map[*sql.Tx][]gidx.PrefixedID
+}
+
+func newIssuerCache(ttl time.Duration) *issuerCache {
+	return &issuerCache{
+		ttl:     ttl,
+		now:     time.Now,
+		byURI:   make(map[string]issuerCacheEntry),
+		pending: make(map[*sql.Tx][]gidx.PrefixedID),
+	}
+}
+
+// get returns the cached issuer with the given URI, if it has not expired.
+func (c *issuerCache) get(uri string) (*types.Issuer, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.byURI[uri]
+	if !ok || !c.now().Before(entry.expiresAt) {
+		return nil, false
+	}
+
+	return entry.issuer, true
+}
+
+// set caches the issuer. Issuers read within a transaction may include uncommitted changes, so they
+// are not cached.
+func (c *issuerCache) set(ctx context.Context, iss *types.Issuer) {
+	if _, err := getContextTx(ctx); err == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.byURI[iss.URI] = issuerCacheEntry{
+		issuer:    iss,
+		expiresAt: c.now().Add(c.ttl),
+	}
+}
+
+// invalidate removes the issuer with the given ID from the cache, and again when the context
+// transaction ends.
+func (c *issuerCache) invalidate(ctx context.Context, id gidx.PrefixedID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remove(id)
+
+	if tx, err := getContextTx(ctx); err == nil {
+		c.pending[tx] = append(c.pending[tx], id)
+	}
+}
+
+// release removes the issuers changed by the context transaction from the cache once it has been
+// committed or rolled back.
+func (c *issuerCache) release(ctx context.Context) {
+	tx, err := getContextTx(ctx)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range c.pending[tx] {
+		c.remove(id)
+	}
+
+	delete(c.pending, tx)
+}
+
+func (c *issuerCache) remove(id gidx.PrefixedID) {
+	for uri, entry := range c.byURI {
+		if entry.issuer.ID == id {
+			delete(c.byURI, uri)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// TestIssuerCache checks that cached issuers expire, and are removed when they are changed.
+func TestIssuerCache(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	cache := newIssuerCache(time.Minute)
+	cache.now = func() time.Time {
+		return now
+	}
+
+	iss := &types.Issuer{
+		ID:  gidx.MustNewID(types.IdentityIssuerIDPrefix),
+		URI: "https://example.com/",
+	}
+
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, txKey, &sql.Tx{})
+
+	// Issuers read within a transaction are not cached.
+	cache.set(txCtx, iss)
+
+	_, ok := cache.get(iss.URI)
+	assert.False(t, ok)
+
+	cache.set(ctx, iss)
+
+	cached, ok := cache.get(iss.URI)
+	assert.True(t, ok)
+	assert.Same(t, iss, cached)
+
+	now = now.Add(time.Minute)
+
+	_, ok = cache.get(iss.URI)
+	assert.False(t, ok)
+
+	// Issuers changed in a transaction are removed again once it ends.
+	cache.set(ctx, iss)
+	cache.invalidate(txCtx, iss.ID)
+
+	_, ok = cache.get(iss.URI)
+	assert.False(t, ok)
+
+	cache.set(ctx, iss)
+	cache.release(txCtx)
+
+	_, ok = cache.get(iss.URI)
+	assert.False(t, ok)
+	assert.Empty(t, cache.pending)
+}
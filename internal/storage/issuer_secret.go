@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// issuerSecretEncryptionInfo binds keys derived from the configured secret to issuer secret encryption.
+const issuerSecretEncryptionInfo = "identity-api issuer introspection secrets"
+
+// WithIssuerSecretEncryptionKey encrypts the introspection client secrets of issuers with a key derived
+// from the given secret. Secrets stored before the key was set are encrypted when the engine is created.
+// Without a key, issuers cannot be given an introspection client secret.
+func WithIssuerSecretEncryptionKey(secret string) EngineOption {
+	return func(e *engine) error {
+		if secret == "" {
+			return nil
+		}
+
+		aead, err := newAEAD(secret, issuerSecretEncryptionInfo)
+		if err != nil {
+			return err
+		}
+
+		e.issuerService.aead = aead
+
+		return e.issuerService.encryptIntrospectionSecrets(context.Background())
+	}
+}
+
+// sealIntrospectionSecret returns the values stored for the introspection client secret of the
+// issuer with the given ID. Secrets are only stored encrypted, so types.ErrIssuerSecretsDisabled
+// is returned if no encryption key is set.
+func (s *issuerService) sealIntrospectionSecret(id gidx.PrefixedID, secret string) (string, []byte, error) {
+	if secret == "" {
+		return "", nil, nil
+	}
+
+	if s.aead == nil {
+		return "", nil, types.ErrIssuerSecretsDisabled
+	}
+
+	encrypted, err := seal(s.aead, []byte(secret), []byte(id))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "", encrypted, nil
+}
+
+// openIntrospectionSecret returns the introspection client secret of the issuer with the given ID from
+// its stored values.
+func (s *issuerService) openIntrospectionSecret(id gidx.PrefixedID, secret string, encrypted []byte) (string, error) {
+	if encrypted == nil {
+		return secret, nil
+	}
+
+	if s.aead == nil {
+		return "", fmt.Errorf("%w %s: no encryption key set", ErrorIssuerSecretDecrypt, id)
+	}
+
+	plaintext, err := open(s.aead, encrypted, []byte(id))
+	if err != nil {
+		return "", fmt.Errorf("%w %s: %w", ErrorIssuerSecretDecrypt, id, err)
+	}
+
+	return string(plaintext), nil
+}
+
+// encryptIntrospectionSecrets encrypts the introspection client secrets stored in plaintext.
+func (s *issuerService) encryptIntrospectionSecrets(ctx context.Context) error {
+	query := fmt.Sprintf("SELECT %s, %s FROM issuers WHERE %s != ''",
+		issuerCols.ID, issuerCols.IntrospectionSecret, issuerCols.IntrospectionSecret,
+	)
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close() //nolint:errcheck
+
+	secrets := make(map[gidx.PrefixedID]string)
+
+	for rows.Next() {
+		var (
+			id     gidx.PrefixedID
+			secret string
+		)
+
+		if err := rows.Scan(&id, &secret); err != nil {
+			return err
+		}
+
+		secrets[id] = secret
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Only secrets which were not changed meanwhile, such as by another replica, are replaced.
+	update := fmt.Sprintf("UPDATE issuers SET %s = '', %s = $1 WHERE %s = $2 AND %s = $3",
+		issuerCols.IntrospectionSecret, issuerCols.IntrospectionSecretEncrypted,
+		issuerCols.ID, issuerCols.IntrospectionSecret,
+	)
+
+	for id, secret := range secrets {
+		_, encrypted, err := s.sealIntrospectionSecret(id, secret)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.db.ExecContext(ctx, update, encrypted, id, secret); err != nil {
+			return err
+		}
+
+		s.invalidateIssuer(ctx, id)
+	}
+
+	return nil
+}
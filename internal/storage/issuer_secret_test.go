@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach-go/v2/testserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+func TestIssuerSecretEncryption(t *testing.T) {
+	t.Parallel()
+
+	db, shutdown := testserver.NewDBForTest(t, testserver.CustomVersionOpt(TestServerCRDBVersion))
+
+	err := runMigrations(db)
+	if err != nil {
+		shutdown()
+		t.Fatal(err)
+	}
+
+	t.Cleanup(shutdown)
+
+	newSvc := func(secret string) *issuerService {
+		svc, err := newIssuerService(db)
+		require.NoError(t, err)
+
+		if secret != "" {
+			svc.aead, err = newAEAD(secret, issuerSecretEncryptionInfo)
+			require.NoError(t, err)
+		}
+
+		return svc
+	}
+
+	plainSvc := newSvc("")
+	svc := newSvc("abcd1234abcd1234abcd1234abcd1234")
+	otherSvc := newSvc("another secret")
+
+	ownerID := gidx.MustNewID("testten")
+
+	createIssuer := func(svc *issuerService, name, secret string) gidx.PrefixedID {
+		ctx, err := beginTxContext(context.Background(), db)
+		require.NoError(t, err)
+
+		iss, err := svc.CreateIssuer(ctx, types.Issuer{
+			OwnerID:                   ownerID,
+			ID:                        gidx.MustNewID("testiss"),
+			Name:                      name,
+			URI:                       "https://" + name + ".example.com/",
+			IntrospectionURI:          "https://" + name + ".example.com/introspect",
+			IntrospectionClientID:     "identity-api",
+			IntrospectionClientSecret: secret,
+		})
+		require.NoError(t, err)
+		require.NoError(t, commitContextTx(ctx))
+
+		return iss.ID
+	}
+
+	// Secrets stored in plaintext before an encryption key was required.
+	createPlaintext := func(name, secret string) gidx.PrefixedID {
+		id := createIssuer(plainSvc, name, "")
+
+		_, err := db.Exec(`UPDATE issuers SET introspection_client_secret = $1 WHERE id = $2`, secret, id)
+		require.NoError(t, err)
+
+		return id
+	}
+
+	created := createIssuer(svc, "created", "created-secret")
+	existing := createPlaintext("existing", "existing-secret")
+	updated := createPlaintext("updated", "old-secret")
+
+	t.Run("NoKey", func(t *testing.T) {
+		ctx, err := beginTxContext(context.Background(), db)
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			_ = rollbackContextTx(ctx)
+		})
+
+		_, err = plainSvc.CreateIssuer(ctx, types.Issuer{
+			OwnerID:                   ownerID,
+			ID:                        gidx.MustNewID("testiss"),
+			Name:                      "plaintext",
+			URI:                       "https://plaintext.example.com/",
+			IntrospectionURI:          "https://plaintext.example.com/introspect",
+			IntrospectionClientID:     "identity-api",
+			IntrospectionClientSecret: "plaintext-secret",
+		})
+		assert.ErrorIs(t, err, types.ErrIssuerSecretsDisabled)
+	})
+
+	require.NoError(t, svc.encryptIntrospectionSecrets(context.Background()))
+
+	updateCtx, err := beginTxContext(context.Background(), db)
+	require.NoError(t, err)
+
+	newSecret := "new-secret"
+
+	_, err = svc.UpdateIssuer(updateCtx, updated, types.IssuerUpdate{IntrospectionClientSecret: &newSecret})
+	require.NoError(t, err)
+	require.NoError(t, commitContextTx(updateCtx))
+
+	type input struct {
+		svc *issuerService
+		id  gidx.PrefixedID
+	}
+
+	runFn := func(ctx context.Context, in input) testingx.TestResult[string] {
+		iss, err := in.svc.GetIssuerByID(ctx, in.id)
+		if err != nil {
+			return testingx.TestResult[string]{Err: err}
+		}
+
+		return testingx.TestResult[string]{Success: iss.IntrospectionClientSecret}
+	}
+
+	checkSecret := func(id gidx.PrefixedID, expected string) func(context.Context, *testing.T, testingx.TestResult[string]) {
+		return func(_ context.Context, t *testing.T, res testingx.TestResult[string]) {
+			require.NoError(t, res.Err)
+			assert.Equal(t, expected, res.Success)
+
+			var (
+				stored    string
+				encrypted []byte
+			)
+
+			err := db.QueryRow(`SELECT introspection_client_secret, introspection_client_secret_encrypted FROM issuers WHERE id = $1`, id).
+				Scan(&stored, &encrypted)
+			require.NoError(t, err)
+
+			assert.Empty(t, stored)
+			assert.NotEmpty(t, encrypted)
+			assert.NotContains(t, string(encrypted), expected)
+		}
+	}
+
+	testCases := []testingx.TestCase[input, string]{
+		{
+			Name:    "Created",
+			Input:   input{svc: svc, id: created},
+			CheckFn: checkSecret(created, "created-secret"),
+		},
+		{
+			Name:    "EncryptedExisting",
+			Input:   input{svc: svc, id: existing},
+			CheckFn: checkSecret(existing, "existing-secret"),
+		},
+		{
+			Name:    "Updated",
+			Input:   input{svc: svc, id: updated},
+			CheckFn: checkSecret(updated, newSecret),
+		},
+		{
+			Name:  "WrongKey",
+			Input: input{svc: otherSvc, id: created},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[string]) {
+				assert.ErrorIs(t, res.Err, ErrorIssuerSecretDecrypt)
+			},
+		},
+		{
+			Name:  "NoKey",
+			Input: input{svc: plainSvc, id: created},
+			CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[string]) {
+				assert.ErrorIs(t, res.Err, ErrorIssuerSecretDecrypt)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
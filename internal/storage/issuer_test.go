@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/cockroach-go/v2/testserver"
 	"github.com/stretchr/testify/assert"
@@ -32,6 +33,38 @@ func compareIssuers(t *testing.T, exp types.Issuer, obs types.Issuer) {
 	exp.ClaimConditions = nil
 	obs.ClaimConditions = nil
 
+	exp.ActorConditions = nil
+	obs.ActorConditions = nil
+
+	exp.ScopeMapping = nil
+	obs.ScopeMapping = nil
+
+	exp.ProfileMapping = nil
+	obs.ProfileMapping = nil
+
+	// Empty audience lists may be either nil or empty
+	if len(exp.AllowedAudiences) == 0 && len(obs.AllowedAudiences) == 0 {
+		exp.AllowedAudiences = nil
+		obs.AllowedAudiences = nil
+	}
+
+	if len(exp.AllowedAlgorithms) == 0 && len(obs.AllowedAlgorithms) == 0 {
+		exp.AllowedAlgorithms = nil
+		obs.AllowedAlgorithms = nil
+	}
+
+	if len(exp.RequiredAudiences) == 0 && len(obs.RequiredAudiences) == 0 {
+		exp.RequiredAudiences = nil
+		obs.RequiredAudiences = nil
+	}
+
+	// Timestamps are set by the database
+	assert.False(t, obs.CreatedAt.IsZero())
+	assert.False(t, obs.UpdatedAt.Before(obs.CreatedAt))
+
+	exp.CreatedAt, exp.UpdatedAt = time.Time{}, time.Time{}
+	obs.CreatedAt, obs.UpdatedAt = time.Time{}, time.Time{}
+
 	assert.Equal(t, exp, obs)
 	assert.Equal(t, expMappings, obsMappings)
 }
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.infratographer.com/x/gidx"
+)
+
+// setLastSeenQuery sets a time column of the rows with the given IDs, unless it is already later.
+// The IDs and times are passed as two arrays of the same length.
+const setLastSeenQuery = `
+        UPDATE %[1]s SET %[2]s = v.at
+        FROM (SELECT unnest($1::STRING[]) AS id, unnest($2::TIMESTAMPTZ[]) AS at) AS v
+        WHERE %[1]s.id = v.id AND (%[1]s.%[2]s IS NULL OR %[1]s.%[2]s < v.at)
+        `
+
+type lastSeenService struct {
+	db *sql.DB
+}
+
+func newLastSeenService(db *sql.DB) (*lastSeenService, error) {
+	return &lastSeenService{
+		db: db,
+	}, nil
+}
+
+// SetUsersLastExchangeAt records the time each of the given users last exchanged a token. Users
+// which no longer exist are ignored.
+func (s *lastSeenService) SetUsersLastExchangeAt(ctx context.Context, times map[gidx.PrefixedID]time.Time) error {
+	return s.setLastSeen(ctx, "user_info", userInfoCols.LastExchangeAt, times)
+}
+
+// SetOAuthClientsLastTokenIssuedAt records the time a token was last issued to each of the given
+// OAuth clients. Clients which no longer exist are ignored.
+func (s *lastSeenService) SetOAuthClientsLastTokenIssuedAt(ctx context.Context, times map[gidx.PrefixedID]time.Time) error {
+	return s.setLastSeen(ctx, "oauth_clients", oauthClientCols.LastTokenIssuedAt, times)
+}
+
+func (s *lastSeenService) setLastSeen(ctx context.Context, table, col string, times map[gidx.PrefixedID]time.Time) error {
+	if len(times) == 0 {
+		return nil
+	}
+
+	conn, err := contextConn(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(times))
+	ats := make([]string, 0, len(times))
+
+	for id, at := range times {
+		ids = append(ids, id.String())
+		ats = append(ats, at.UTC().Format(time.RFC3339Nano))
+	}
+
+	_, err = conn.ExecContext(ctx, fmt.Sprintf(setLastSeenQuery, table, col), pq.Array(ids), pq.Array(ats))
+
+	return err
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach-go/v2/testserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+var _ types.LastSeenService = &lastSeenService{}
+
+func TestLastSeenService(t *testing.T) {
+	t.Parallel()
+
+	db, shutdown := testserver.NewDBForTest(t, testserver.CustomVersionOpt(TestServerCRDBVersion))
+
+	err := runMigrations(db)
+	if err != nil {
+		shutdown()
+		t.Fatal(err)
+	}
+
+	t.Cleanup(shutdown)
+
+	ownerID := gidx.MustNewID("testten")
+	issuer := SeedIssuer{
+		OwnerID: ownerID,
+		ID:      gidx.MustNewID("testiss"),
+		Name:    "Example",
+		URI:     "https://example.com/",
+		JWKSURI: "https://example.com/.well-known/jwks.json",
+	}
+
+	issSvc, err := newIssuerService(db)
+	require.NoError(t, err)
+
+	require.NoError(t, issSvc.seedDatabase(context.Background(), []SeedIssuer{issuer}))
+
+	userSvc, err := newUserInfoService(db)
+	require.NoError(t, err)
+
+	clientSvc, err := newOAuthClientManager(db)
+	require.NoError(t, err)
+
+	svc, err := newLastSeenService(db)
+	require.NoError(t, err)
+
+	seedCtx, err := beginTxContext(context.Background(), db)
+	require.NoError(t, err)
+
+	user, err := userSvc.StoreUserInfo(seedCtx, types.UserInfo{
+		Name:    "Maliketh",
+		Issuer:  issuer.URI,
+		Subject: "sub0|malikadmin",
+	})
+	require.NoError(t, err)
+
+	client, err := clientSvc.CreateOAuthClient(seedCtx, types.OAuthClient{
+		OwnerID: ownerID,
+		Name:    "my-client",
+		Secret:  "foobar",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, commitContextTx(seedCtx))
+
+	type lastSeen struct {
+		user   time.Time
+		client time.Time
+	}
+
+	now := time.Now().Truncate(time.Microsecond)
+
+	runFn := func(ctx context.Context, at time.Time) testingx.TestResult[lastSeen] {
+		users := map[gidx.PrefixedID]time.Time{
+			user.ID: at,
+			gidx.MustNewID(types.IdentityUserIDPrefix): at,
+		}
+
+		if err := svc.SetUsersLastExchangeAt(ctx, users); err != nil {
+			return testingx.TestResult[lastSeen]{Err: err}
+		}
+
+		clients := map[gidx.PrefixedID]time.Time{
+			client.ID: at,
+		}
+
+		if err := svc.SetOAuthClientsLastTokenIssuedAt(ctx, clients); err != nil {
+			return testingx.TestResult[lastSeen]{Err: err}
+		}
+
+		storedUser, err := userSvc.LookupUserInfoByID(ctx, user.ID)
+		if err != nil {
+			return testingx.TestResult[lastSeen]{Err: err}
+		}
+
+		storedClient, err := clientSvc.LookupOAuthClientByID(ctx, client.ID)
+
+		return testingx.TestResult[lastSeen]{
+			Success: lastSeen{
+				user:   storedUser.LastExchangeAt,
+				client: storedClient.LastTokenIssuedAt,
+			},
+			Err: err,
+		}
+	}
+
+	checkLastSeen := func(_ context.Context, t *testing.T, res testingx.TestResult[lastSeen]) {
+		require.NoError(t, res.Err)
+		assert.True(t, now.Equal(res.Success.user), "unexpected user last exchange time")
+		assert.True(t, now.Equal(res.Success.client), "unexpected client last token issued time")
+	}
+
+	require.NoError(t, runFn(context.Background(), now).Err)
+
+	testCases := []testingx.TestCase[time.Time, lastSeen]{
+		{
+			Name:    "Same",
+			Input:   now,
+			CheckFn: checkLastSeen,
+		},
+		{
+			Name:    "Earlier",
+			Input:   now.Add(-time.Hour),
+			CheckFn: checkLastSeen,
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE issuers
+ADD COLUMN actor_conditions VARCHAR;
+-- +goose Down
+ALTER TABLE issuers DROP COLUMN actor_conditions;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE issuers
+ADD COLUMN client_id VARCHAR NOT NULL DEFAULT '';
+-- +goose Down
+ALTER TABLE issuers DROP COLUMN client_id;
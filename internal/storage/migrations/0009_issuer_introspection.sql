@@ -0,0 +1,10 @@
+-- +goose Up
+ALTER TABLE issuers
+ADD COLUMN introspection_uri VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN introspection_client_id VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN introspection_client_secret VARCHAR NOT NULL DEFAULT '';
+-- +goose Down
+ALTER TABLE issuers
+DROP COLUMN introspection_uri,
+DROP COLUMN introspection_client_id,
+DROP COLUMN introspection_client_secret;
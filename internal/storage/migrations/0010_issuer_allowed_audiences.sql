@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE issuers
+ADD COLUMN allowed_audiences VARCHAR NOT NULL DEFAULT '';
+-- +goose Down
+ALTER TABLE issuers DROP COLUMN allowed_audiences;
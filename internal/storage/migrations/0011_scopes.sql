@@ -0,0 +1,10 @@
+-- +goose Up
+ALTER TABLE issuers
+ADD COLUMN scope_mapping VARCHAR;
+
+ALTER TABLE oauth_clients
+ADD COLUMN scopes VARCHAR NOT NULL DEFAULT '';
+-- +goose Down
+ALTER TABLE oauth_clients DROP COLUMN scopes;
+
+ALTER TABLE issuers DROP COLUMN scope_mapping;
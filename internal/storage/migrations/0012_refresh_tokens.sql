@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE oauth_clients
+ADD COLUMN grant_types VARCHAR NOT NULL DEFAULT 'client_credentials urn:ietf:params:oauth:grant-type:token-exchange';
+
+CREATE TABLE refresh_tokens (
+    signature VARCHAR PRIMARY KEY NOT NULL,
+    request_id VARCHAR NOT NULL,
+    client_id VARCHAR(29) NOT NULL,
+    subject VARCHAR NOT NULL,
+    active BOOL NOT NULL DEFAULT true,
+    requested_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ,
+    request JSONB NOT NULL,
+    INDEX refresh_tokens_request_id_idx (request_id),
+    INDEX refresh_tokens_client_id_idx (client_id),
+    INDEX refresh_tokens_subject_idx (subject)
+);
+-- +goose Down
+DROP TABLE refresh_tokens;
+
+ALTER TABLE oauth_clients DROP COLUMN grant_types;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE access_tokens (
+    jti VARCHAR PRIMARY KEY NOT NULL,
+    request_id VARCHAR NOT NULL,
+    client_id VARCHAR(29),
+    subject VARCHAR NOT NULL,
+    audience VARCHAR NOT NULL DEFAULT '',
+    requested_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    request JSONB NOT NULL,
+    INDEX access_tokens_request_id_idx (request_id),
+    INDEX access_tokens_client_id_idx (client_id),
+    INDEX access_tokens_subject_idx (subject),
+    INDEX access_tokens_expires_at_idx (expires_at)
+);
+
+CREATE INDEX refresh_tokens_expires_at_idx ON refresh_tokens (expires_at);
+-- +goose Down
+DROP INDEX refresh_tokens@refresh_tokens_expires_at_idx;
+
+DROP TABLE access_tokens;
@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE revoked_tokens (
+    jti VARCHAR PRIMARY KEY NOT NULL,
+    subject VARCHAR NOT NULL,
+    client_id VARCHAR(29),
+    revoked_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    INDEX revoked_tokens_expires_at_idx (expires_at)
+);
+-- +goose Down
+DROP TABLE revoked_tokens;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE signing_keys (
+    id VARCHAR(29) PRIMARY KEY NOT NULL,
+    algorithm VARCHAR NOT NULL,
+    state VARCHAR NOT NULL,
+    private_key BYTES NOT NULL,
+    activate_at TIMESTAMPTZ,
+    retired_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose Down
+DROP TABLE signing_keys;
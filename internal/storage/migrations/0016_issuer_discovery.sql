@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE issuers
+ADD COLUMN userinfo_uri VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN discovery BOOL NOT NULL DEFAULT false;
+-- +goose Down
+ALTER TABLE issuers DROP COLUMN userinfo_uri, DROP COLUMN discovery;
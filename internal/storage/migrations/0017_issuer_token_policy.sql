@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE issuers
+ADD COLUMN allowed_algorithms VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN required_audiences VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN max_token_age INT8 NOT NULL DEFAULT 0,
+ADD COLUMN clock_skew INT8 NOT NULL DEFAULT 0;
+-- +goose Down
+ALTER TABLE issuers DROP COLUMN allowed_algorithms, DROP COLUMN required_audiences, DROP COLUMN max_token_age, DROP COLUMN clock_skew;
@@ -0,0 +1,4 @@
+-- +goose Up
+ALTER TABLE user_info ADD COLUMN fetched_at TIMESTAMPTZ;
+-- +goose Down
+ALTER TABLE user_info DROP COLUMN fetched_at;
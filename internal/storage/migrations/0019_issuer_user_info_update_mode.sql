@@ -0,0 +1,4 @@
+-- +goose Up
+ALTER TABLE issuers ADD COLUMN user_info_update_mode VARCHAR NOT NULL DEFAULT '';
+-- +goose Down
+ALTER TABLE issuers DROP COLUMN user_info_update_mode;
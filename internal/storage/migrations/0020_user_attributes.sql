@@ -0,0 +1,10 @@
+-- +goose Up
+ALTER TABLE issuers
+ADD COLUMN profile_mapping VARCHAR;
+
+ALTER TABLE user_info
+ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}';
+-- +goose Down
+ALTER TABLE user_info DROP COLUMN attributes;
+
+ALTER TABLE issuers DROP COLUMN profile_mapping;
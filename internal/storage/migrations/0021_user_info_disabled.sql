@@ -0,0 +1,4 @@
+-- +goose Up
+ALTER TABLE user_info ADD COLUMN disabled BOOL NOT NULL DEFAULT false;
+-- +goose Down
+ALTER TABLE user_info DROP COLUMN disabled;
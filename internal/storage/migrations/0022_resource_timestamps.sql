@@ -0,0 +1,26 @@
+-- +goose Up
+ALTER TABLE issuers
+ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+ALTER TABLE user_info
+ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+ADD COLUMN last_exchange_at TIMESTAMPTZ;
+
+ALTER TABLE oauth_clients
+ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+ADD COLUMN last_token_issued_at TIMESTAMPTZ;
+
+ALTER TABLE groups
+ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+-- +goose Down
+ALTER TABLE groups DROP COLUMN updated_at, DROP COLUMN created_at;
+
+ALTER TABLE oauth_clients DROP COLUMN last_token_issued_at, DROP COLUMN updated_at, DROP COLUMN created_at;
+
+ALTER TABLE user_info DROP COLUMN last_exchange_at, DROP COLUMN updated_at, DROP COLUMN created_at;
+
+ALTER TABLE issuers DROP COLUMN updated_at, DROP COLUMN created_at;
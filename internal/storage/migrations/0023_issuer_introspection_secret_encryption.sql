@@ -0,0 +1,4 @@
+-- +goose Up
+ALTER TABLE issuers ADD COLUMN introspection_client_secret_encrypted BYTES;
+-- +goose Down
+ALTER TABLE issuers DROP COLUMN introspection_client_secret_encrypted;
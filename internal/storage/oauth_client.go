@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,17 +16,27 @@ import (
 )
 
 var oauthClientCols = struct {
-	ID       string
-	OwnerID  string
-	Name     string
-	Secret   string
-	Audience string
+	ID                string
+	OwnerID           string
+	Name              string
+	Secret            string
+	Audience          string
+	Scopes            string
+	GrantTypes        string
+	CreatedAt         string
+	UpdatedAt         string
+	LastTokenIssuedAt string
 }{
-	ID:       "id",
-	OwnerID:  "owner_id",
-	Name:     "name",
-	Secret:   "secret",
-	Audience: "audience",
+	ID:                "id",
+	OwnerID:           "owner_id",
+	Name:              "name",
+	Secret:            "secret",
+	Audience:          "audience",
+	Scopes:            "scopes",
+	GrantTypes:        "grant_types",
+	CreatedAt:         "created_at",
+	UpdatedAt:         "updated_at",
+	LastTokenIssuedAt: "last_token_issued_at",
 }
 
 var (
@@ -35,8 +46,15 @@ var (
 		oauthClientCols.Name,
 		oauthClientCols.Secret,
 		oauthClientCols.Audience,
+		oauthClientCols.Scopes,
+		oauthClientCols.GrantTypes,
 	}
 	oauthClientColumnsStr = strings.Join(oauthClientColumns, ", ")
+
+	// oauthClientSelectColumnsStr adds the columns set by the database to the OAuth client columns.
+	oauthClientSelectColumnsStr = strings.Join(append(slices.Clone(oauthClientColumns),
+		oauthClientCols.CreatedAt, oauthClientCols.UpdatedAt, oauthClientCols.LastTokenIssuedAt,
+	), ", ")
 )
 
 type oauthClientManager struct {
@@ -88,9 +106,9 @@ func (s *oauthClientManager) CreateOAuthClient(ctx context.Context, client types
         INSERT INTO oauth_clients (
            %s
         ) VALUES
-        ($1, $2, $3, $4, $5) RETURNING id;
+        ($1, $2, $3, $4, $5, $6, $7) RETURNING %s, %s, %s;
        `
-	q = fmt.Sprintf(q, oauthClientColumnsStr)
+	q = fmt.Sprintf(q, oauthClientColumnsStr, oauthClientCols.ID, oauthClientCols.CreatedAt, oauthClientCols.UpdatedAt)
 
 	hashedSecret, err := s.hasher.Hash(ctx, []byte(client.Secret))
 	if err != nil {
@@ -112,9 +130,11 @@ func (s *oauthClientManager) CreateOAuthClient(ctx context.Context, client types
 		client.Name,
 		client.Secret,
 		strings.Join(client.Audience, " "),
+		strings.Join(client.Scopes, " "),
+		strings.Join(client.GrantTypes, " "),
 	)
 
-	err = row.Scan(&client.ID)
+	err = row.Scan(&client.ID, &client.CreatedAt, &client.UpdatedAt)
 	if err != nil {
 		return emptyModel, err
 	}
@@ -129,6 +149,11 @@ func (*oauthClientManager) DeleteOAuthClient(ctx context.Context, clientID gidx.
 		return err
 	}
 
+	_, err = tx.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE client_id = $1;`, clientID)
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.ExecContext(ctx, `DELETE FROM oauth_clients WHERE id = $1;`, clientID)
 
 	return err
@@ -140,7 +165,7 @@ func (s *oauthClientManager) LookupOAuthClientByID(ctx context.Context, clientID
 		return types.OAuthClient{}, types.ErrOAuthClientNotFound
 	}
 
-	q := fmt.Sprintf(`SELECT %s FROM oauth_clients WHERE id = $1`, oauthClientColumnsStr)
+	q := fmt.Sprintf(`SELECT %s FROM oauth_clients WHERE id = $1`, oauthClientSelectColumnsStr)
 
 	var row *sql.Row
 
@@ -155,17 +180,7 @@ func (s *oauthClientManager) LookupOAuthClientByID(ctx context.Context, clientID
 		return types.OAuthClient{}, err
 	}
 
-	var model types.OAuthClient
-
-	var aud string
-
-	err = row.Scan(
-		&model.ID,
-		&model.OwnerID,
-		&model.Name,
-		&model.Secret,
-		&aud,
-	)
+	model, err := scanOAuthClient(row)
 
 	switch err {
 	case nil:
@@ -175,8 +190,6 @@ func (s *oauthClientManager) LookupOAuthClientByID(ctx context.Context, clientID
 		return types.OAuthClient{}, err
 	}
 
-	model.Audience = strings.Fields(aud)
-
 	return model, nil
 }
 
@@ -184,7 +197,7 @@ func (s *oauthClientManager) LookupOAuthClientByID(ctx context.Context, clientID
 func (s *oauthClientManager) GetOwnerOAuthClients(ctx context.Context, id gidx.PrefixedID, pagination crdbx.Paginator) (types.OAuthClients, error) {
 	paginate := crdbx.Paginate(pagination, crdbx.ContextAsOfSystemTime(ctx, "-1m"))
 
-	query := fmt.Sprintf("SELECT %s FROM oauth_clients %s WHERE owner_id = $1 %s %s %s", oauthClientColumnsStr,
+	query := fmt.Sprintf("SELECT %s FROM oauth_clients %s WHERE owner_id = $1 %s %s %s", oauthClientSelectColumnsStr,
 		paginate.AsOfSystemTime(),
 		paginate.AndWhere(2), //nolint:mnd
 		paginate.OrderClause(),
@@ -201,26 +214,46 @@ func (s *oauthClientManager) GetOwnerOAuthClients(ctx context.Context, id gidx.P
 	var clients types.OAuthClients
 
 	for rows.Next() {
-		var (
-			model types.OAuthClient
-			aud   string
-		)
-
-		err = rows.Scan(
-			&model.ID,
-			&model.OwnerID,
-			&model.Name,
-			&model.Secret,
-			&aud,
-		)
+		model, err := scanOAuthClient(rows)
 		if err != nil {
 			return nil, err
 		}
 
-		model.Audience = strings.Fields(aud)
-
 		clients = append(clients, model)
 	}
 
 	return clients, nil
 }
+
+func scanOAuthClient(row rowScanner) (types.OAuthClient, error) {
+	var (
+		model             types.OAuthClient
+		aud               string
+		scopes            string
+		grantTypes        string
+		lastTokenIssuedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&model.ID,
+		&model.OwnerID,
+		&model.Name,
+		&model.Secret,
+		&aud,
+		&scopes,
+		&grantTypes,
+		&model.CreatedAt,
+		&model.UpdatedAt,
+		&lastTokenIssuedAt,
+	)
+	if err != nil {
+		return types.OAuthClient{}, err
+	}
+
+	model.Audience = strings.Fields(aud)
+	model.Scopes = strings.Fields(scopes)
+	model.GrantTypes = strings.Fields(grantTypes)
+	model.LastTokenIssuedAt = lastTokenIssuedAt.Time
+
+	return model, nil
+}
@@ -63,10 +63,12 @@ func TestOAuthClientManager(t *testing.T) {
 	assert.NoError(t, err)
 
 	defaultClient := types.OAuthClient{
-		OwnerID:  ownerID,
-		Name:     "my-client",
-		Secret:   "foobar",
-		Audience: []string{"aud1", "aud2"},
+		OwnerID:    ownerID,
+		Name:       "my-client",
+		Secret:     "foobar",
+		Audience:   []string{"aud1", "aud2"},
+		Scopes:     []string{"read", "write"},
+		GrantTypes: []string{types.GrantTypeClientCredentials, types.GrantTypeRefreshToken},
 	}
 
 	seedCtx, err := beginTxContext(context.Background(), db)
@@ -154,10 +156,12 @@ func TestOAuthClientManager(t *testing.T) {
 			{
 				Name: "Success",
 				Input: types.OAuthClient{
-					OwnerID:  ownerID,
-					Name:     "newclient",
-					Secret:   secret,
-					Audience: []string{"abc", "def", "ghi"},
+					OwnerID:    ownerID,
+					Name:       "newclient",
+					Secret:     secret,
+					Audience:   []string{"abc", "def", "ghi"},
+					Scopes:     []string{"read"},
+					GrantTypes: types.DefaultOAuthClientGrantTypes(),
 				},
 				SetupFn:   setupWithTx,
 				CleanupFn: cleanupWithTx,
@@ -168,6 +172,8 @@ func TestOAuthClientManager(t *testing.T) {
 					assert.Equal(t, ownerID, client.OwnerID)
 					assert.Equal(t, "newclient", client.Name)
 					assert.Equal(t, []string{"abc", "def", "ghi"}, client.Audience)
+					assert.Equal(t, []string{"read"}, client.Scopes)
+					assert.Equal(t, types.DefaultOAuthClientGrantTypes(), client.GrantTypes)
 				},
 			},
 		}
@@ -3,6 +3,7 @@ package storage
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"go.infratographer.com/identity-api/internal/types"
 )
@@ -27,6 +28,15 @@ func colBindingsToParams(bindings []colBinding) (string, []any) {
 	return bindingsStr, args
 }
 
+// withUpdatedAt adds setting the given updated at column to the current time to the update params.
+func withUpdatedAt(params, column string) string {
+	if params == "" {
+		return column + " = now()"
+	}
+
+	return params + ", " + column + " = now()"
+}
+
 func bindIfNotNil[T any](bindings []colBinding, column string, value *T) []colBinding {
 	if value != nil {
 		binding := colBinding{
@@ -46,6 +56,47 @@ func issuerUpdateToColBindings(update types.IssuerUpdate) ([]colBinding, error)
 	bindings = bindIfNotNil(bindings, issuerCols.Name, update.Name)
 	bindings = bindIfNotNil(bindings, issuerCols.URI, update.URI)
 	bindings = bindIfNotNil(bindings, issuerCols.JWKSURI, update.JWKSURI)
+	bindings = bindIfNotNil(bindings, issuerCols.UserInfoURI, update.UserInfoURI)
+	bindings = bindIfNotNil(bindings, issuerCols.Discovery, update.Discovery)
+	bindings = bindIfNotNil(bindings, issuerCols.ClientID, update.ClientID)
+	bindings = bindIfNotNil(bindings, issuerCols.Introspection, update.IntrospectionURI)
+	bindings = bindIfNotNil(bindings, issuerCols.IntrospectionID, update.IntrospectionClientID)
+
+	if update.AllowedAudiences != nil {
+		audStr := strings.Join(update.AllowedAudiences, " ")
+
+		bindings = bindIfNotNil(bindings, issuerCols.AllowedAudiences, &audStr)
+	}
+
+	if update.AllowedAlgorithms != nil {
+		algStr := strings.Join(update.AllowedAlgorithms, " ")
+
+		bindings = bindIfNotNil(bindings, issuerCols.AllowedAlgorithms, &algStr)
+	}
+
+	if update.RequiredAudiences != nil {
+		audStr := strings.Join(update.RequiredAudiences, " ")
+
+		bindings = bindIfNotNil(bindings, issuerCols.RequiredAudiences, &audStr)
+	}
+
+	if update.MaxTokenAge != nil {
+		maxAge := int64(*update.MaxTokenAge / time.Second)
+
+		bindings = bindIfNotNil(bindings, issuerCols.MaxTokenAge, &maxAge)
+	}
+
+	if update.ClockSkew != nil {
+		skew := int64(*update.ClockSkew / time.Second)
+
+		bindings = bindIfNotNil(bindings, issuerCols.ClockSkew, &skew)
+	}
+
+	if update.UserInfoUpdateMode != nil {
+		mode := string(*update.UserInfoUpdateMode)
+
+		bindings = bindIfNotNil(bindings, issuerCols.UpdateMode, &mode)
+	}
 
 	if update.ClaimMappings != nil {
 		mappingRepr, err := update.ClaimMappings.MarshalJSON()
@@ -69,6 +120,39 @@ func issuerUpdateToColBindings(update types.IssuerUpdate) ([]colBinding, error)
 		bindings = bindIfNotNil(bindings, issuerCols.Conditions, &condStr)
 	}
 
+	if update.ActorConditions != nil {
+		condRepr, err := update.ActorConditions.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+
+		condStr := string(condRepr)
+
+		bindings = bindIfNotNil(bindings, issuerCols.ActorConditions, &condStr)
+	}
+
+	if update.ScopeMapping != nil {
+		mappingRepr, err := update.ScopeMapping.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+
+		mappingStr := string(mappingRepr)
+
+		bindings = bindIfNotNil(bindings, issuerCols.ScopeMapping, &mappingStr)
+	}
+
+	if update.ProfileMapping != nil {
+		mappingRepr, err := update.ProfileMapping.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+
+		mappingStr := string(mappingRepr)
+
+		bindings = bindIfNotNil(bindings, issuerCols.ProfileMapping, &mappingStr)
+	}
+
 	return bindings, nil
 }
 
@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ory/fosite"
+	"go.infratographer.com/x/gidx"
+)
+
+var refreshTokenCols = struct {
+	Signature   string
+	RequestID   string
+	ClientID    string
+	Subject     string
+	Active      string
+	RequestedAt string
+	ExpiresAt   string
+	Request     string
+}{
+	Signature:   "signature",
+	RequestID:   "request_id",
+	ClientID:    "client_id",
+	Subject:     "subject",
+	Active:      "active",
+	RequestedAt: "requested_at",
+	ExpiresAt:   "expires_at",
+	Request:     "request",
+}
+
+var (
+	refreshTokenColumns = []string{
+		refreshTokenCols.Signature,
+		refreshTokenCols.RequestID,
+		refreshTokenCols.ClientID,
+		refreshTokenCols.Subject,
+		refreshTokenCols.Active,
+		refreshTokenCols.RequestedAt,
+		refreshTokenCols.ExpiresAt,
+		refreshTokenCols.Request,
+	}
+	refreshTokenColumnsStr = strings.Join(refreshTokenColumns, ", ")
+)
+
+type refreshTokenService struct {
+	db      *sql.DB
+	clients fosite.ClientManager
+}
+
+func newRefreshTokenService(db *sql.DB, clients fosite.ClientManager) (*refreshTokenService, error) {
+	return &refreshTokenService{
+		db:      db,
+		clients: clients,
+	}, nil
+}
+
+// CreateRefreshTokenSession implements oauth2.RefreshTokenStorage
+func (s *refreshTokenService) CreateRefreshTokenSession(ctx context.Context, signature string, _ string, request fosite.Requester) error {
+	conn, err := contextConn(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	storedBytes, err := marshalRequest(request)
+	if err != nil {
+		return err
+	}
+
+	var expiresAt *time.Time
+
+	if exp := request.GetSession().GetExpiresAt(fosite.RefreshToken); !exp.IsZero() {
+		expiresAt = &exp
+	}
+
+	q := fmt.Sprintf(`INSERT INTO refresh_tokens (%s) VALUES ($1, $2, $3, $4, true, $5, $6, $7)`, refreshTokenColumnsStr)
+
+	_, err = conn.ExecContext(
+		ctx,
+		q,
+		signature,
+		request.GetID(),
+		requestClientID(request),
+		sessionSubject(request.GetSession()),
+		request.GetRequestedAt(),
+		expiresAt,
+		string(storedBytes),
+	)
+
+	return err
+}
+
+// GetRefreshTokenSession implements oauth2.RefreshTokenStorage. If the refresh token has been rotated or
+// revoked, the request is returned along with fosite.ErrInactiveToken.
+func (s *refreshTokenService) GetRefreshTokenSession(ctx context.Context, signature string, session fosite.Session) (fosite.Requester, error) {
+	conn, err := contextConn(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	q := fmt.Sprintf(`SELECT %s FROM refresh_tokens WHERE signature = $1`, refreshTokenColumnsStr)
+
+	var (
+		sig, requestID, clientID, subject string
+		active                            bool
+		requestedAt                       time.Time
+		expiresAt                         sql.NullTime
+		storedBytes                       []byte
+	)
+
+	err = conn.QueryRowContext(ctx, q, signature).Scan(
+		&sig,
+		&requestID,
+		&clientID,
+		&subject,
+		&active,
+		&requestedAt,
+		&expiresAt,
+		&storedBytes,
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, fosite.ErrNotFound
+	case err != nil:
+		return nil, err
+	default:
+	}
+
+	request, err := unmarshalRequest(ctx, s.clients, storedBytes, requestID, clientID, requestedAt, session)
+	if err != nil {
+		return nil, err
+	}
+
+	if !active {
+		return request, fosite.ErrInactiveToken
+	}
+
+	return request, nil
+}
+
+// DeleteRefreshTokenSession implements oauth2.RefreshTokenStorage
+func (s *refreshTokenService) DeleteRefreshTokenSession(ctx context.Context, signature string) error {
+	conn, err := contextConn(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE signature = $1`, signature)
+
+	return err
+}
+
+// RotateRefreshToken implements oauth2.RefreshTokenStorage. Every refresh token issued for the request is
+// deactivated, so that reuse of a rotated refresh token can be detected.
+func (s *refreshTokenService) RotateRefreshToken(ctx context.Context, requestID string, _ string) error {
+	return s.RevokeRefreshToken(ctx, requestID)
+}
+
+// RevokeRefreshToken implements oauth2.TokenRevocationStorage
+func (s *refreshTokenService) RevokeRefreshToken(ctx context.Context, requestID string) error {
+	conn, err := contextConn(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, `UPDATE refresh_tokens SET active = false WHERE request_id = $1`, requestID)
+
+	return err
+}
+
+// RevokeSubjectRefreshTokens revokes all refresh tokens issued to the given subject.
+func (s *refreshTokenService) RevokeSubjectRefreshTokens(ctx context.Context, subject gidx.PrefixedID) error {
+	conn, err := contextConn(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE subject = $1`, subject)
+
+	return err
+}
@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach-go/v2/testserver"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+var (
+	_ oauth2.TokenRevocationStorage = &engine{}
+	_ types.RefreshTokenService     = &refreshTokenService{}
+)
+
+func TestRefreshTokenService(t *testing.T) {
+	t.Parallel()
+
+	db, shutdown := testserver.NewDBForTest(t, testserver.CustomVersionOpt(TestServerCRDBVersion))
+
+	err := runMigrations(db)
+	if err != nil {
+		shutdown()
+		t.Fatal(err)
+	}
+
+	t.Cleanup(shutdown)
+
+	ownerID := gidx.MustNewID("testten")
+
+	oauthClientStore, err := newOAuthClientManager(db)
+	require.NoError(t, err)
+
+	seedCtx, err := beginTxContext(context.Background(), db)
+	require.NoError(t, err)
+
+	client, err := oauthClientStore.CreateOAuthClient(seedCtx, types.OAuthClient{
+		OwnerID:    ownerID,
+		Name:       "my-client",
+		Secret:     "foobar",
+		Audience:   []string{},
+		Scopes:     []string{"read"},
+		GrantTypes: []string{types.GrantTypeTokenExchange, types.GrantTypeRefreshToken},
+	})
+	require.NoError(t, err)
+	require.NoError(t, commitContextTx(seedCtx))
+
+	refreshTokenSvc, err := newRefreshTokenService(db, oauthClientStore)
+	require.NoError(t, err)
+
+	subject := gidx.MustNewID(types.IdentityUserIDPrefix)
+
+	newRequest := func(requestID string) *fosite.Request {
+		request := fosite.NewRequest()
+
+		request.ID = requestID
+		request.RequestedAt = time.Now().UTC().Round(time.Second)
+		request.Client = client
+		request.GrantedScope = fosite.Arguments{"read"}
+		request.GrantedAudience = fosite.Arguments{"https://example.com/userinfo"}
+		request.Session = &oauth2.JWTSession{
+			JWTClaims: &jwt.JWTClaims{
+				Subject: subject.String(),
+			},
+			ExpiresAt: map[fosite.TokenType]time.Time{
+				fosite.RefreshToken: time.Now().Add(time.Hour).UTC().Round(time.Second),
+			},
+		}
+
+		return request
+	}
+
+	activeRequest := newRequest("active")
+	rotatedRequest := newRequest("rotated")
+
+	require.NoError(t, refreshTokenSvc.CreateRefreshTokenSession(context.Background(), "active-sig", "", activeRequest))
+	require.NoError(t, refreshTokenSvc.CreateRefreshTokenSession(context.Background(), "rotated-sig", "", rotatedRequest))
+	require.NoError(t, refreshTokenSvc.RotateRefreshToken(context.Background(), rotatedRequest.GetID(), "rotated-sig"))
+
+	t.Run("GetRefreshTokenSession", func(t *testing.T) {
+		t.Parallel()
+
+		runFn := func(ctx context.Context, input string) testingx.TestResult[fosite.Requester] {
+			res, err := refreshTokenSvc.GetRefreshTokenSession(ctx, input, &oauth2.JWTSession{})
+
+			return testingx.TestResult[fosite.Requester]{
+				Success: res,
+				Err:     err,
+			}
+		}
+
+		testCases := []testingx.TestCase[string, fosite.Requester]{
+			{
+				Name:  "NotFound",
+				Input: "missing-sig",
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[fosite.Requester]) {
+					assert.ErrorIs(t, res.Err, fosite.ErrNotFound)
+				},
+			},
+			{
+				Name:  "Active",
+				Input: "active-sig",
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[fosite.Requester]) {
+					require.NoError(t, res.Err)
+
+					assert.Equal(t, activeRequest.GetID(), res.Success.GetID())
+					assert.Equal(t, client.ID.String(), res.Success.GetClient().GetID())
+					assert.Equal(t, activeRequest.GetGrantedScopes(), res.Success.GetGrantedScopes())
+					assert.Equal(t, activeRequest.GetGrantedAudience(), res.Success.GetGrantedAudience())
+
+					session, ok := res.Success.GetSession().(*oauth2.JWTSession)
+					require.True(t, ok)
+
+					assert.Equal(t, subject.String(), session.JWTClaims.Subject)
+					assert.Equal(t, activeRequest.GetSession().GetExpiresAt(fosite.RefreshToken), session.GetExpiresAt(fosite.RefreshToken))
+				},
+			},
+			{
+				Name:  "Rotated",
+				Input: "rotated-sig",
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[fosite.Requester]) {
+					assert.ErrorIs(t, res.Err, fosite.ErrInactiveToken)
+					require.NotNil(t, res.Success)
+					assert.Equal(t, rotatedRequest.GetID(), res.Success.GetID())
+				},
+			},
+		}
+
+		testingx.RunTests(context.Background(), t, testCases, runFn)
+	})
+
+	t.Run("RevokeSubjectRefreshTokens", func(t *testing.T) {
+		t.Parallel()
+
+		otherSubject := gidx.MustNewID(types.IdentityUserIDPrefix)
+
+		request := newRequest("other")
+		request.Session.(*oauth2.JWTSession).JWTClaims.Subject = otherSubject.String()
+
+		require.NoError(t, refreshTokenSvc.CreateRefreshTokenSession(context.Background(), "other-sig", "", request))
+
+		runFn := func(ctx context.Context, input gidx.PrefixedID) testingx.TestResult[any] {
+			return testingx.TestResult[any]{
+				Err: refreshTokenSvc.RevokeSubjectRefreshTokens(ctx, input),
+			}
+		}
+
+		testCases := []testingx.TestCase[gidx.PrefixedID, any]{
+			{
+				Name:  "Success",
+				Input: otherSubject,
+				CheckFn: func(ctx context.Context, t *testing.T, res testingx.TestResult[any]) {
+					require.NoError(t, res.Err)
+
+					_, err := refreshTokenSvc.GetRefreshTokenSession(ctx, "other-sig", &oauth2.JWTSession{})
+					assert.ErrorIs(t, err, fosite.ErrNotFound)
+				},
+			},
+		}
+
+		testingx.RunTests(context.Background(), t, testCases, runFn)
+	})
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/oauth2"
+)
+
+// storedRequest is the serialized form of the token request a token was issued for.
+type storedRequest struct {
+	RequestedScope    []string        `json:"requested_scope"`
+	GrantedScope      []string        `json:"granted_scope"`
+	RequestedAudience []string        `json:"requested_audience"`
+	GrantedAudience   []string        `json:"granted_audience"`
+	Form              url.Values      `json:"form"`
+	Session           json.RawMessage `json:"session"`
+}
+
+// execQueryer is implemented by both *sql.DB and *sql.Tx.
+type execQueryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// contextConn returns the transaction in the context if one exists, or the given database otherwise.
+func contextConn(ctx context.Context, db *sql.DB) (execQueryer, error) {
+	tx, err := getContextTx(ctx)
+
+	switch err {
+	case nil:
+		return tx, nil
+	case ErrorMissingContextTx:
+		return db, nil
+	default:
+		return nil, err
+	}
+}
+
+// marshalRequest serializes the given request, including its session.
+func marshalRequest(request fosite.Requester) ([]byte, error) {
+	session, err := json.Marshal(request.GetSession())
+	if err != nil {
+		return nil, err
+	}
+
+	stored := storedRequest{
+		RequestedScope:    request.GetRequestedScopes(),
+		GrantedScope:      request.GetGrantedScopes(),
+		RequestedAudience: request.GetRequestedAudience(),
+		GrantedAudience:   request.GetGrantedAudience(),
+		Form:              request.GetRequestForm(),
+		Session:           session,
+	}
+
+	return json.Marshal(stored)
+}
+
+// unmarshalRequest rebuilds a request serialized with marshalRequest, decoding its session into the given
+// session and looking up its client with the given client manager.
+func unmarshalRequest(ctx context.Context, clients fosite.ClientManager, data []byte, requestID, clientID string, requestedAt time.Time, session fosite.Session) (*fosite.Request, error) {
+	var stored storedRequest
+
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+
+	if session != nil {
+		if err := json.Unmarshal(stored.Session, session); err != nil {
+			return nil, err
+		}
+	}
+
+	request := fosite.NewRequest()
+
+	if len(clientID) > 0 {
+		client, err := clients.GetClient(ctx, clientID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", fosite.ErrNotFound, err)
+		}
+
+		request.Client = client
+	}
+
+	request.ID = requestID
+	request.RequestedAt = requestedAt
+	request.RequestedScope = stored.RequestedScope
+	request.GrantedScope = stored.GrantedScope
+	request.RequestedAudience = stored.RequestedAudience
+	request.GrantedAudience = stored.GrantedAudience
+	request.Form = stored.Form
+	request.Session = session
+
+	return request, nil
+}
+
+// sessionSubject returns the subject of the tokens issued for the given session. For JWT sessions this is
+// the subject claim rather than the upstream subject.
+func sessionSubject(session fosite.Session) string {
+	if jwtSession, ok := session.(oauth2.JWTSessionContainer); ok {
+		if claims := jwtSession.GetJWTClaims(); claims != nil {
+			if sub, ok := claims.ToMapClaims()["sub"].(string); ok {
+				return sub
+			}
+		}
+	}
+
+	return session.GetSubject()
+}
+
+// requestClientID returns the ID of the client the request was made by, or an empty string if the
+// request was not authenticated with a client.
+func requestClientID(request fosite.Requester) string {
+	if client := request.GetClient(); client != nil {
+		return client.GetID()
+	}
+
+	return ""
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"go.infratographer.com/x/gidx"
+)
+
+// revokeAccessTokensQuery adds unexpired access tokens matching the given condition to the deny-list.
+const revokeAccessTokensQuery = `INSERT INTO revoked_tokens (jti, subject, client_id, expires_at)
+SELECT jti, subject, client_id, expires_at FROM access_tokens WHERE %s = $1 AND expires_at > now()
+ON CONFLICT (jti) DO NOTHING`
+
+// RevokeAccessToken implements oauth2.TokenRevocationStorage. Access tokens are stateless JWTs, so every
+// access token issued for the given request is added to the deny-list rather than deleted.
+func (s *accessTokenService) RevokeAccessToken(ctx context.Context, requestID string) error {
+	conn, err := contextConn(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, revokeTokensQuery(accessTokenCols.RequestID), requestID)
+
+	return err
+}
+
+// IsAccessTokenRevoked checks whether the access token with the given jti has been revoked.
+func (s *accessTokenService) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	conn, err := contextConn(ctx, s.db)
+	if err != nil {
+		return false, err
+	}
+
+	var revoked bool
+
+	err = conn.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)`, jti).Scan(&revoked)
+
+	return revoked, err
+}
+
+// RevokeSubjectTokens revokes all access and refresh tokens issued to the given subject.
+func (s *accessTokenService) RevokeSubjectTokens(ctx context.Context, subject gidx.PrefixedID) error {
+	return s.revokeTokens(ctx, accessTokenCols.Subject, subject)
+}
+
+// RevokeClientTokens revokes all access and refresh tokens issued to the given OAuth client.
+func (s *accessTokenService) RevokeClientTokens(ctx context.Context, clientID gidx.PrefixedID) error {
+	return s.revokeTokens(ctx, accessTokenCols.ClientID, clientID)
+}
+
+// revokeTokens adds the access tokens with the given column value to the deny-list and deletes the
+// matching refresh tokens. Both tables share the subject and client_id columns.
+func (s *accessTokenService) revokeTokens(ctx context.Context, col string, value gidx.PrefixedID) error {
+	tx, err := getContextTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, revokeTokensQuery(col), value); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM refresh_tokens WHERE %s = $1`, col), value)
+
+	return err
+}
+
+func revokeTokensQuery(col string) string {
+	return fmt.Sprintf(revokeAccessTokensQuery, col)
+}
@@ -0,0 +1,137 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach-go/v2/testserver"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+var _ types.TokenRevocationService = &accessTokenService{}
+
+func TestTokenRevocation(t *testing.T) {
+	t.Parallel()
+
+	db, shutdown := testserver.NewDBForTest(t, testserver.CustomVersionOpt(TestServerCRDBVersion))
+
+	err := runMigrations(db)
+	if err != nil {
+		shutdown()
+		t.Fatal(err)
+	}
+
+	t.Cleanup(shutdown)
+
+	oauthClientStore, err := newOAuthClientManager(db)
+	require.NoError(t, err)
+
+	accessTokenSvc, err := newAccessTokenService(db, oauthClientStore)
+	require.NoError(t, err)
+
+	createToken := func(jti string, subject gidx.PrefixedID) *fosite.Request {
+		request := fosite.NewRequest()
+
+		request.ID = jti + "-request"
+		request.Session = &oauth2.JWTSession{
+			JWTClaims: &jwt.JWTClaims{
+				Subject: subject.String(),
+			},
+			ExpiresAt: map[fosite.TokenType]time.Time{
+				fosite.AccessToken: time.Now().Add(time.Hour),
+			},
+		}
+
+		require.NoError(t, accessTokenSvc.CreateAccessTokenSession(context.Background(), jti, request))
+
+		return request
+	}
+
+	t.Run("RevokeAccessToken", func(t *testing.T) {
+		t.Parallel()
+
+		request := createToken("revoke-request-jti", gidx.MustNewID(types.IdentityUserIDPrefix))
+
+		runFn := func(ctx context.Context, input string) testingx.TestResult[any] {
+			return testingx.TestResult[any]{
+				Err: accessTokenSvc.RevokeAccessToken(ctx, input),
+			}
+		}
+
+		testCases := []testingx.TestCase[string, any]{
+			{
+				Name:  "Success",
+				Input: request.GetID(),
+				CheckFn: func(ctx context.Context, t *testing.T, res testingx.TestResult[any]) {
+					require.NoError(t, res.Err)
+
+					revoked, err := accessTokenSvc.IsAccessTokenRevoked(ctx, "revoke-request-jti")
+					require.NoError(t, err)
+					assert.True(t, revoked)
+
+					found, err := accessTokenSvc.GetAccessTokenSession(ctx, "revoke-request-jti", &oauth2.JWTSession{})
+					assert.ErrorIs(t, err, fosite.ErrInactiveToken)
+					require.NotNil(t, found)
+					assert.Equal(t, request.GetID(), found.GetID())
+				},
+			},
+		}
+
+		testingx.RunTests(context.Background(), t, testCases, runFn)
+	})
+
+	t.Run("RevokeSubjectTokens", func(t *testing.T) {
+		t.Parallel()
+
+		subject := gidx.MustNewID(types.IdentityUserIDPrefix)
+
+		createToken("revoke-subject-jti", subject)
+		createToken("other-subject-jti", gidx.MustNewID(types.IdentityUserIDPrefix))
+
+		runFn := func(ctx context.Context, input gidx.PrefixedID) testingx.TestResult[any] {
+			ctx, err := beginTxContext(ctx, db)
+			require.NoError(t, err)
+
+			err = accessTokenSvc.RevokeSubjectTokens(ctx, input)
+			if err != nil {
+				require.NoError(t, rollbackContextTx(ctx))
+			} else {
+				require.NoError(t, commitContextTx(ctx))
+			}
+
+			return testingx.TestResult[any]{
+				Err: err,
+			}
+		}
+
+		testCases := []testingx.TestCase[gidx.PrefixedID, any]{
+			{
+				Name:  "Success",
+				Input: subject,
+				CheckFn: func(ctx context.Context, t *testing.T, res testingx.TestResult[any]) {
+					require.NoError(t, res.Err)
+
+					revoked, err := accessTokenSvc.IsAccessTokenRevoked(ctx, "revoke-subject-jti")
+					require.NoError(t, err)
+					assert.True(t, revoked)
+
+					revoked, err = accessTokenSvc.IsAccessTokenRevoked(ctx, "other-subject-jti")
+					require.NoError(t, err)
+					assert.False(t, revoked)
+				},
+			},
+		}
+
+		testingx.RunTests(context.Background(), t, testCases, runFn)
+	})
+}
@@ -0,0 +1,310 @@
+package storage
+
+import (
+	"context"
+	"crypto/cipher"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// signingKeyEncryptionInfo binds keys derived from the configured secret to signing key encryption.
+const signingKeyEncryptionInfo = "identity-api signing keys"
+
+var signingKeyCols = struct {
+	ID         string
+	Algorithm  string
+	State      string
+	PrivateKey string
+	ActivateAt string
+	RetiredAt  string
+	CreatedAt  string
+}{
+	ID:         "id",
+	Algorithm:  "algorithm",
+	State:      "state",
+	PrivateKey: "private_key",
+	ActivateAt: "activate_at",
+	RetiredAt:  "retired_at",
+	CreatedAt:  "created_at",
+}
+
+var signingKeyColsStr = strings.Join([]string{
+	signingKeyCols.ID,
+	signingKeyCols.Algorithm,
+	signingKeyCols.State,
+	signingKeyCols.PrivateKey,
+	signingKeyCols.ActivateAt,
+	signingKeyCols.RetiredAt,
+	signingKeyCols.CreatedAt,
+}, ", ")
+
+// WithSigningKeyEncryptionKey enables storing signing keys, encrypting their private keys with a key
+// derived from the given secret.
+func WithSigningKeyEncryptionKey(secret string) EngineOption {
+	return func(e *engine) error {
+		return e.signingKeyService.setEncryptionKey(secret)
+	}
+}
+
+type signingKeyService struct {
+	db   *sql.DB
+	aead cipher.AEAD
+}
+
+func newSigningKeyService(db *sql.DB) (*signingKeyService, error) {
+	return &signingKeyService{
+		db: db,
+	}, nil
+}
+
+func (s *signingKeyService) setEncryptionKey(secret string) error {
+	if secret == "" {
+		return nil
+	}
+
+	aead, err := newAEAD(secret, signingKeyEncryptionInfo)
+	if err != nil {
+		return err
+	}
+
+	s.aead = aead
+
+	return nil
+}
+
+// encrypt encrypts a private key, binding it to the ID of its signing key.
+func (s *signingKeyService) encrypt(id gidx.PrefixedID, privateKey []byte) ([]byte, error) {
+	return seal(s.aead, privateKey, []byte(id))
+}
+
+func (s *signingKeyService) decrypt(id gidx.PrefixedID, ciphertext []byte) ([]byte, error) {
+	privateKey, err := open(s.aead, ciphertext, []byte(id))
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrorSigningKeyDecrypt, id, err)
+	}
+
+	return privateKey, nil
+}
+
+// CreateSigningKey stores a new signing key, encrypting its private key.
+func (s *signingKeyService) CreateSigningKey(ctx context.Context, key types.SigningKey) (*types.SigningKey, error) {
+	if s.aead == nil {
+		return nil, types.ErrSigningKeysDisabled
+	}
+
+	conn, err := contextConn(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := s.encrypt(key.ID, key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	q := fmt.Sprintf(
+		"INSERT INTO signing_keys (%s, %s, %s, %s, %s) VALUES ($1, $2, $3, $4, $5) RETURNING %s",
+		signingKeyCols.ID, signingKeyCols.Algorithm, signingKeyCols.State,
+		signingKeyCols.PrivateKey, signingKeyCols.ActivateAt, signingKeyColsStr,
+	)
+
+	row := conn.QueryRowContext(ctx, q, key.ID, key.Algorithm, key.State, ciphertext, nullTime(key.ActivateAt))
+
+	return s.scanSigningKey(row)
+}
+
+// GetSigningKeyByID retrieves a signing key by its ID.
+func (s *signingKeyService) GetSigningKeyByID(ctx context.Context, id gidx.PrefixedID) (*types.SigningKey, error) {
+	if s.aead == nil {
+		return nil, types.ErrSigningKeysDisabled
+	}
+
+	conn, err := contextConn(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	q := fmt.Sprintf("SELECT %s FROM signing_keys WHERE %s = $1", signingKeyColsStr, signingKeyCols.ID)
+
+	return s.scanSigningKey(conn.QueryRowContext(ctx, q, id))
+}
+
+// ListSigningKeys retrieves all signing keys, ordered by creation time.
+func (s *signingKeyService) ListSigningKeys(ctx context.Context) (types.SigningKeys, error) {
+	if s.aead == nil {
+		return nil, types.ErrSigningKeysDisabled
+	}
+
+	conn, err := contextConn(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	q := fmt.Sprintf(
+		"SELECT %s FROM signing_keys ORDER BY %s, %s",
+		signingKeyColsStr, signingKeyCols.CreatedAt, signingKeyCols.ID,
+	)
+
+	rows, err := conn.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close() //nolint:errcheck // Not needed to check returned error.
+
+	var out types.SigningKeys
+
+	for rows.Next() {
+		key, err := s.scanSigningKey(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, key)
+	}
+
+	return out, rows.Err()
+}
+
+// ActivateSigningKey activates a signing key, which signs tokens from the given time. Keys activated
+// earlier stay active, so they keep signing tokens until then.
+func (s *signingKeyService) ActivateSigningKey(ctx context.Context, id gidx.PrefixedID, at time.Time) (*types.SigningKey, error) {
+	key, err := s.lockSigningKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key.State {
+	case types.SigningKeyStateRetired:
+		return nil, types.ErrSigningKeyRetired
+	case types.SigningKeyStateActive:
+		if !key.ActivateAt.After(at) {
+			return key, nil
+		}
+	}
+
+	return s.updateSigningKey(ctx, id,
+		fmt.Sprintf("%s = $2, %s = $3", signingKeyCols.State, signingKeyCols.ActivateAt),
+		types.SigningKeyStateActive, at,
+	)
+}
+
+// RetireSigningKey retires a signing key. Active keys can only be retired once a key activated after
+// them signs tokens, so there is always a key to sign with.
+func (s *signingKeyService) RetireSigningKey(ctx context.Context, id gidx.PrefixedID) (*types.SigningKey, error) {
+	key, err := s.lockSigningKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key.State {
+	case types.SigningKeyStateRetired:
+		return key, nil
+	case types.SigningKeyStateActive:
+		tx, err := getContextTx(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		q := fmt.Sprintf(
+			"SELECT EXISTS (SELECT 1 FROM signing_keys WHERE %[1]s IN ($1, $2) AND %[2]s > $3 AND %[2]s <= now())",
+			signingKeyCols.State, signingKeyCols.ActivateAt,
+		)
+
+		var replaced bool
+
+		err = tx.QueryRowContext(ctx, q, types.SigningKeyStatePending, types.SigningKeyStateActive, key.ActivateAt).Scan(&replaced)
+		if err != nil {
+			return nil, err
+		}
+
+		if !replaced {
+			return nil, types.ErrSigningKeyInUse
+		}
+	}
+
+	return s.updateSigningKey(ctx, id,
+		fmt.Sprintf("%s = $2, %s = now()", signingKeyCols.State, signingKeyCols.RetiredAt),
+		types.SigningKeyStateRetired,
+	)
+}
+
+// lockSigningKey retrieves a signing key, locking it until the context transaction ends.
+func (s *signingKeyService) lockSigningKey(ctx context.Context, id gidx.PrefixedID) (*types.SigningKey, error) {
+	if s.aead == nil {
+		return nil, types.ErrSigningKeysDisabled
+	}
+
+	tx, err := getContextTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := fmt.Sprintf("SELECT %s FROM signing_keys WHERE %s = $1 FOR UPDATE", signingKeyColsStr, signingKeyCols.ID)
+
+	return s.scanSigningKey(tx.QueryRowContext(ctx, q, id))
+}
+
+func (s *signingKeyService) updateSigningKey(ctx context.Context, id gidx.PrefixedID, set string, args ...any) (*types.SigningKey, error) {
+	tx, err := getContextTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := fmt.Sprintf(
+		"UPDATE signing_keys SET %s WHERE %s = $1 RETURNING %s",
+		set, signingKeyCols.ID, signingKeyColsStr,
+	)
+
+	return s.scanSigningKey(tx.QueryRowContext(ctx, q, append([]any{id}, args...)...))
+}
+
+func (s *signingKeyService) scanSigningKey(row rowScanner) (*types.SigningKey, error) {
+	var (
+		key        types.SigningKey
+		ciphertext []byte
+		activateAt sql.NullTime
+		retiredAt  sql.NullTime
+	)
+
+	err := row.Scan(
+		&key.ID,
+		&key.Algorithm,
+		&key.State,
+		&ciphertext,
+		&activateAt,
+		&retiredAt,
+		&key.CreatedAt,
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, types.ErrSigningKeyNotFound
+	case err != nil:
+		return nil, err
+	}
+
+	key.PrivateKey, err = s.decrypt(key.ID, ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	key.ActivateAt = activateAt.Time
+	key.RetiredAt = retiredAt.Time
+
+	return &key, nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{
+		Time:  t,
+		Valid: !t.IsZero(),
+	}
+}
@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach-go/v2/testserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+var _ types.SigningKeyService = &signingKeyService{}
+
+func TestSigningKeyService(t *testing.T) {
+	t.Parallel()
+
+	db, shutdown := testserver.NewDBForTest(t, testserver.CustomVersionOpt(TestServerCRDBVersion))
+
+	err := runMigrations(db)
+	if err != nil {
+		shutdown()
+		t.Fatal(err)
+	}
+
+	t.Cleanup(shutdown)
+
+	svc, err := newSigningKeyService(db)
+	require.NoError(t, err)
+
+	require.NoError(t, svc.setEncryptionKey("abcd1234abcd1234abcd1234abcd1234"))
+
+	createKey := func(state types.SigningKeyState, activateAt time.Time) *types.SigningKey {
+		key, err := svc.CreateSigningKey(context.Background(), types.SigningKey{
+			ID:         gidx.MustNewID(types.IdentitySigningKeyIDPrefix),
+			Algorithm:  "ES256",
+			State:      state,
+			PrivateKey: []byte("private key"),
+			ActivateAt: activateAt,
+		})
+		require.NoError(t, err)
+
+		return key
+	}
+
+	withTx := func(ctx context.Context, fn func(ctx context.Context) (*types.SigningKey, error)) testingx.TestResult[*types.SigningKey] {
+		ctx, err := beginTxContext(ctx, db)
+		if err != nil {
+			return testingx.TestResult[*types.SigningKey]{
+				Err: err,
+			}
+		}
+
+		key, err := fn(ctx)
+		if err != nil {
+			_ = rollbackContextTx(ctx)
+
+			return testingx.TestResult[*types.SigningKey]{
+				Err: err,
+			}
+		}
+
+		return testingx.TestResult[*types.SigningKey]{
+			Success: key,
+			Err:     commitContextTx(ctx),
+		}
+	}
+
+	t.Run("Encryption", func(t *testing.T) {
+		t.Parallel()
+
+		key := createKey(types.SigningKeyStatePending, time.Time{})
+
+		assert.Equal(t, []byte("private key"), key.PrivateKey)
+
+		var stored []byte
+
+		err := db.QueryRow(`SELECT private_key FROM signing_keys WHERE id = $1`, key.ID).Scan(&stored)
+		require.NoError(t, err)
+		assert.NotContains(t, string(stored), "private key")
+
+		other, err := newSigningKeyService(db)
+		require.NoError(t, err)
+
+		require.NoError(t, other.setEncryptionKey("another secret"))
+
+		_, err = other.GetSigningKeyByID(context.Background(), key.ID)
+		assert.ErrorIs(t, err, ErrorSigningKeyDecrypt)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+
+		disabled, err := newSigningKeyService(db)
+		require.NoError(t, err)
+
+		_, err = disabled.ListSigningKeys(context.Background())
+		assert.ErrorIs(t, err, types.ErrSigningKeysDisabled)
+	})
+
+	t.Run("ActivateSigningKey", func(t *testing.T) {
+		t.Parallel()
+
+		pending := createKey(types.SigningKeyStatePending, time.Time{})
+		retired := createKey(types.SigningKeyStateRetired, time.Time{})
+
+		at := time.Now().Add(time.Minute).Truncate(time.Microsecond)
+
+		runFn := func(ctx context.Context, input gidx.PrefixedID) testingx.TestResult[*types.SigningKey] {
+			return withTx(ctx, func(ctx context.Context) (*types.SigningKey, error) {
+				return svc.ActivateSigningKey(ctx, input, at)
+			})
+		}
+
+		testCases := []testingx.TestCase[gidx.PrefixedID, *types.SigningKey]{
+			{
+				Name:  "Success",
+				Input: pending.ID,
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[*types.SigningKey]) {
+					require.NoError(t, res.Err)
+					assert.Equal(t, types.SigningKeyStateActive, res.Success.State)
+					assert.True(t, at.Equal(res.Success.ActivateAt))
+				},
+			},
+			{
+				Name:  "Retired",
+				Input: retired.ID,
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[*types.SigningKey]) {
+					assert.ErrorIs(t, res.Err, types.ErrSigningKeyRetired)
+				},
+			},
+			{
+				Name:  "NotFound",
+				Input: gidx.MustNewID(types.IdentitySigningKeyIDPrefix),
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[*types.SigningKey]) {
+					assert.ErrorIs(t, res.Err, types.ErrSigningKeyNotFound)
+				},
+			},
+		}
+
+		testingx.RunTests(context.Background(), t, testCases, runFn)
+	})
+
+	t.Run("RetireSigningKey", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+
+		replaced := createKey(types.SigningKeyStateActive, now.Add(-2*time.Hour))
+		createKey(types.SigningKeyStateActive, now.Add(-time.Hour))
+
+		// Activation times are compared with the database clock, so keep well clear of it.
+		inUse := createKey(types.SigningKeyStateActive, now.Add(time.Hour))
+		pending := createKey(types.SigningKeyStatePending, time.Time{})
+
+		runFn := func(ctx context.Context, input gidx.PrefixedID) testingx.TestResult[*types.SigningKey] {
+			return withTx(ctx, func(ctx context.Context) (*types.SigningKey, error) {
+				return svc.RetireSigningKey(ctx, input)
+			})
+		}
+
+		checkRetired := func(_ context.Context, t *testing.T, res testingx.TestResult[*types.SigningKey]) {
+			require.NoError(t, res.Err)
+			assert.Equal(t, types.SigningKeyStateRetired, res.Success.State)
+			assert.False(t, res.Success.RetiredAt.IsZero())
+		}
+
+		testCases := []testingx.TestCase[gidx.PrefixedID, *types.SigningKey]{
+			{
+				Name:    "Pending",
+				Input:   pending.ID,
+				CheckFn: checkRetired,
+			},
+			{
+				Name:    "Replaced",
+				Input:   replaced.ID,
+				CheckFn: checkRetired,
+			},
+			{
+				Name:  "InUse",
+				Input: inUse.ID,
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[*types.SigningKey]) {
+					assert.ErrorIs(t, res.Err, types.ErrSigningKeyInUse)
+				},
+			},
+		}
+
+		testingx.RunTests(context.Background(), t, testCases, runFn)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -28,17 +29,29 @@ var (
 )
 
 var userInfoCols = struct {
-	ID       string
-	Name     string
-	Email    string
-	Subject  string
-	IssuerID string
+	ID             string
+	Name           string
+	Email          string
+	Subject        string
+	IssuerID       string
+	FetchedAt      string
+	Attributes     string
+	Disabled       string
+	CreatedAt      string
+	UpdatedAt      string
+	LastExchangeAt string
 }{
-	ID:       "id",
-	Name:     "name",
-	Email:    "email",
-	Subject:  "sub",
-	IssuerID: "iss_id",
+	ID:             "id",
+	Name:           "name",
+	Email:          "email",
+	Subject:        "sub",
+	IssuerID:       "iss_id",
+	FetchedAt:      "fetched_at",
+	Attributes:     "attributes",
+	Disabled:       "disabled",
+	CreatedAt:      "created_at",
+	UpdatedAt:      "updated_at",
+	LastExchangeAt: "last_exchange_at",
 }
 
 func generateSubjectID(prefix, iss, sub string) (gidx.PrefixedID, error) {
@@ -81,6 +94,12 @@ func (s userInfoService) LookupUserInfoByClaims(ctx context.Context, iss, sub st
 		userInfoCols.Name,
 		userInfoCols.Email,
 		userInfoCols.Subject,
+		userInfoCols.FetchedAt,
+		userInfoCols.Attributes,
+		userInfoCols.Disabled,
+		userInfoCols.CreatedAt,
+		userInfoCols.UpdatedAt,
+		userInfoCols.LastExchangeAt,
 	}, "ui")
 
 	selectCols = append(selectCols, "i."+issuerCols.URI)
@@ -111,14 +130,28 @@ func (s userInfoService) LookupUserInfoByClaims(ctx context.Context, iss, sub st
 		return types.UserInfo{}, err
 	}
 
-	var ui types.UserInfo
+	var (
+		ui             types.UserInfo
+		fetchedAt      sql.NullTime
+		attributes     []byte
+		lastExchangeAt sql.NullTime
+	)
 
-	err = row.Scan(&ui.Name, &ui.Email, &ui.Subject, &ui.Issuer)
+	err = row.Scan(&ui.Name, &ui.Email, &ui.Subject, &fetchedAt, &attributes, &ui.Disabled,
+		&ui.CreatedAt, &ui.UpdatedAt, &lastExchangeAt, &ui.Issuer)
 
-	if errors.Is(err, sql.ErrNoRows) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
 		return types.UserInfo{}, types.ErrUserInfoNotFound
+	case err != nil:
+		return types.UserInfo{}, err
 	}
 
+	ui.FetchedAt = fetchedAt.Time
+	ui.LastExchangeAt = lastExchangeAt.Time
+
+	ui.Attributes, err = unmarshalAttributes(attributes)
+
 	return ui, err
 }
 
@@ -128,6 +161,12 @@ func (s userInfoService) LookupUserInfoByID(ctx context.Context, id gidx.Prefixe
 		userInfoCols.Name,
 		userInfoCols.Email,
 		userInfoCols.Subject,
+		userInfoCols.FetchedAt,
+		userInfoCols.Attributes,
+		userInfoCols.Disabled,
+		userInfoCols.CreatedAt,
+		userInfoCols.UpdatedAt,
+		userInfoCols.LastExchangeAt,
 	}, "ui")
 
 	selectCols = append(selectCols, "i."+issuerCols.URI)
@@ -153,14 +192,28 @@ func (s userInfoService) LookupUserInfoByID(ctx context.Context, id gidx.Prefixe
 		return types.UserInfo{}, err
 	}
 
-	var ui types.UserInfo
+	var (
+		ui             types.UserInfo
+		fetchedAt      sql.NullTime
+		attributes     []byte
+		lastExchangeAt sql.NullTime
+	)
 
-	err = row.Scan(&ui.ID, &ui.Name, &ui.Email, &ui.Subject, &ui.Issuer)
+	err = row.Scan(&ui.ID, &ui.Name, &ui.Email, &ui.Subject, &fetchedAt, &attributes, &ui.Disabled,
+		&ui.CreatedAt, &ui.UpdatedAt, &lastExchangeAt, &ui.Issuer)
 
-	if errors.Is(err, sql.ErrNoRows) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
 		return types.UserInfo{}, types.ErrUserInfoNotFound
+	case err != nil:
+		return types.UserInfo{}, err
 	}
 
+	ui.FetchedAt = fetchedAt.Time
+	ui.LastExchangeAt = lastExchangeAt.Time
+
+	ui.Attributes, err = unmarshalAttributes(attributes)
+
 	return ui, err
 }
 
@@ -207,6 +260,12 @@ func (s *userInfoService) LookupUserInfosByIssuerID(ctx context.Context, id gidx
 		userInfoCols.Name,
 		userInfoCols.Email,
 		userInfoCols.Subject,
+		userInfoCols.FetchedAt,
+		userInfoCols.Attributes,
+		userInfoCols.Disabled,
+		userInfoCols.CreatedAt,
+		userInfoCols.UpdatedAt,
+		userInfoCols.LastExchangeAt,
 	}, "user_info")
 
 	selectCols = append(selectCols, "issuers."+issuerCols.URI)
@@ -235,9 +294,23 @@ func (s *userInfoService) LookupUserInfosByIssuerID(ctx context.Context, id gidx
 	var users types.UserInfos
 
 	for rows.Next() {
-		var model types.UserInfo
+		var (
+			model          types.UserInfo
+			fetchedAt      sql.NullTime
+			attributes     []byte
+			lastExchangeAt sql.NullTime
+		)
+
+		err = rows.Scan(&model.ID, &model.Name, &model.Email, &model.Subject, &fetchedAt, &attributes, &model.Disabled,
+			&model.CreatedAt, &model.UpdatedAt, &lastExchangeAt, &model.Issuer)
+		if err != nil {
+			return nil, err
+		}
 
-		err = rows.Scan(&model.ID, &model.Name, &model.Email, &model.Subject, &model.Issuer)
+		model.FetchedAt = fetchedAt.Time
+		model.LastExchangeAt = lastExchangeAt.Time
+
+		model.Attributes, err = unmarshalAttributes(attributes)
 		if err != nil {
 			return nil, err
 		}
@@ -249,7 +322,10 @@ func (s *userInfoService) LookupUserInfosByIssuerID(ctx context.Context, id gidx
 }
 
 // StoreUserInfo is used to store user information by issuer and
-// subject pairs. UserInfo is unique to issuer/subject pairs.
+// subject pairs. UserInfo is unique to issuer/subject pairs. Storing
+// user info for an existing pair updates its name, email and attributes,
+// and the time it was fetched if set. The time the user was updated is only
+// changed if its name, email or attributes change.
 func (s userInfoService) StoreUserInfo(ctx context.Context, userInfo types.UserInfo) (types.UserInfo, error) {
 	if len(userInfo.Issuer) == 0 {
 		return types.UserInfo{}, fmt.Errorf("%w: issuer is empty", types.ErrInvalidUserInfo)
@@ -285,6 +361,8 @@ func (s userInfoService) StoreUserInfo(ctx context.Context, userInfo types.UserI
 		userInfoCols.Email,
 		userInfoCols.Subject,
 		userInfoCols.IssuerID,
+		userInfoCols.FetchedAt,
+		userInfoCols.Attributes,
 	}, ",")
 
 	var newID gidx.PrefixedID
@@ -299,31 +377,138 @@ func (s userInfoService) StoreUserInfo(ctx context.Context, userInfo types.UserI
 	}
 
 	q := fmt.Sprintf(`INSERT INTO user_info (%[1]s) VALUES (
-            $1, $2, $3, $4, $5
+            $1, $2, $3, $4, $5, $6, $7
 	) ON CONFLICT (%[2]s, %[3]s)
-        DO UPDATE SET %[2]s = excluded.%[2]s, %[3]s = excluded.%[3]s
-        RETURNING id`,
+        DO UPDATE SET %[4]s = excluded.%[4]s, %[5]s = excluded.%[5]s,
+            %[6]s = COALESCE(excluded.%[6]s, user_info.%[6]s), %[7]s = excluded.%[7]s,
+            %[8]s = CASE
+                WHEN user_info.%[4]s IS DISTINCT FROM excluded.%[4]s
+                    OR user_info.%[5]s IS DISTINCT FROM excluded.%[5]s
+                    OR user_info.%[7]s IS DISTINCT FROM excluded.%[7]s
+                THEN now() ELSE user_info.%[8]s
+            END
+        RETURNING %[9]s, %[10]s, %[8]s, %[11]s`,
 		insertCols,
 		userInfoCols.Subject,
 		userInfoCols.IssuerID,
+		userInfoCols.Name,
+		userInfoCols.Email,
+		userInfoCols.FetchedAt,
+		userInfoCols.Attributes,
+		userInfoCols.UpdatedAt,
+		userInfoCols.ID,
+		userInfoCols.CreatedAt,
+		userInfoCols.LastExchangeAt,
 	)
 
+	attributes, err := marshalAttributes(userInfo.Attributes)
+	if err != nil {
+		return types.UserInfo{}, err
+	}
+
 	row = tx.QueryRowContext(ctx, q,
-		newID, userInfo.Name, userInfo.Email, userInfo.Subject, issuerID,
+		newID, userInfo.Name, userInfo.Email, userInfo.Subject, issuerID, nullTime(userInfo.FetchedAt), attributes,
 	)
 
-	var userID gidx.PrefixedID
+	var (
+		userID         gidx.PrefixedID
+		lastExchangeAt sql.NullTime
+	)
 
-	err = row.Scan(&userID)
+	err = row.Scan(&userID, &userInfo.CreatedAt, &userInfo.UpdatedAt, &lastExchangeAt)
 	if err != nil {
 		return types.UserInfo{}, err
 	}
 
 	userInfo.ID = userID
+	userInfo.LastExchangeAt = lastExchangeAt.Time
 
 	return userInfo, err
 }
 
+// UpdateUserInfo updates the user with the given ID.
+func (s userInfoService) UpdateUserInfo(ctx context.Context, id gidx.PrefixedID, update types.UserInfoUpdate) (types.UserInfo, error) {
+	tx, err := getContextTx(ctx)
+	if err != nil {
+		return types.UserInfo{}, err
+	}
+
+	if update.Disabled != nil {
+		q := fmt.Sprintf(
+			"UPDATE user_info SET %s = $2, %s = now() WHERE %s = $1",
+			userInfoCols.Disabled, userInfoCols.UpdatedAt, userInfoCols.ID,
+		)
+
+		res, err := tx.ExecContext(ctx, q, id, *update.Disabled)
+		if err != nil {
+			return types.UserInfo{}, err
+		}
+
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return types.UserInfo{}, err
+		}
+
+		if rowsAffected == 0 {
+			return types.UserInfo{}, types.ErrUserInfoNotFound
+		}
+	}
+
+	return s.LookupUserInfoByID(ctx, id)
+}
+
+// DeleteUserInfo deletes the user with the given ID. The group memberships and tokens of the user
+// are not removed.
+func (s userInfoService) DeleteUserInfo(ctx context.Context, id gidx.PrefixedID) error {
+	tx, err := getContextTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	q := fmt.Sprintf("DELETE FROM user_info WHERE %s = $1", userInfoCols.ID)
+
+	res, err := tx.ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return types.ErrUserInfoNotFound
+	}
+
+	return nil
+}
+
+// marshalAttributes encodes user attributes for storage. Users without attributes are stored with an
+// empty object.
+func marshalAttributes(attributes map[string]any) ([]byte, error) {
+	if len(attributes) == 0 {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(attributes)
+}
+
+// unmarshalAttributes decodes stored user attributes. Users without attributes have nil attributes.
+func unmarshalAttributes(data []byte) (map[string]any, error) {
+	var attributes map[string]any
+
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return nil, err
+	}
+
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+
+	return attributes, nil
+}
+
 func parseClaim(claims map[string]any, key string, required bool) (string, error) {
 	rawVal, ok := claims[key]
 	if !ok {
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/cockroach-go/v2/testserver"
 	"github.com/stretchr/testify/assert"
@@ -98,6 +99,14 @@ func TestUserInfoStore(t *testing.T) {
 		Subject: "32cb2842-4a5d-45cc-b4f3-63cfcdf23e63",
 	}
 
+	userFetched := types.UserInfo{
+		Name:      "Radahn",
+		Email:     "rad@ahn.co",
+		Issuer:    issuer.URI,
+		Subject:   "sub0|radahn",
+		FetchedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
 	// This user ID should be deterministically generated, so we precompute it here rather
 	// than use generateSubjectID
 	expUserInfoID, err := gidx.Parse("idntusr-JJ5-CXOzTNil-ncNcX8UIGzsDYSRGj1Ktc6oI-s9fSs")
@@ -115,6 +124,8 @@ func TestUserInfoStore(t *testing.T) {
 
 	var userInfoRemappedSubStored types.UserInfo
 
+	var userFetchedStored types.UserInfo
+
 	// seed the DB
 	{
 		ctx, err := beginTxContext(ctx, db)
@@ -132,6 +143,11 @@ func TestUserInfoStore(t *testing.T) {
 			assert.FailNow(t, "insert user failed")
 		}
 
+		userFetchedStored, err = svc.StoreUserInfo(ctx, userFetched)
+		if !assert.NoError(t, err) {
+			assert.FailNow(t, "insert user failed")
+		}
+
 		err = commitContextTx(ctx)
 		if !assert.NoError(t, err) {
 			assert.FailNow(t, "commit transaction insert user failed")
@@ -179,8 +195,12 @@ func TestUserInfoStore(t *testing.T) {
 				Input:   lookupType{issuer: user.Issuer, subject: user.Subject},
 				SetupFn: setupFn,
 				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[types.UserInfo]) {
+					exp := user
+					exp.CreatedAt = userInfoStored.CreatedAt
+					exp.UpdatedAt = userInfoStored.UpdatedAt
+
 					assert.NoError(t, res.Err)
-					assert.Equal(t, user, res.Success)
+					assert.Equal(t, exp, res.Success)
 				},
 				CleanupFn: cleanupFn,
 			},
@@ -239,6 +259,16 @@ func TestUserInfoStore(t *testing.T) {
 				},
 				CleanupFn: cleanupFn,
 			},
+			{
+				Name:    "FetchedAt",
+				Input:   userFetchedStored.ID,
+				SetupFn: setupFn,
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[types.UserInfo]) {
+					assert.NoError(t, res.Err)
+					assert.True(t, userFetched.FetchedAt.Equal(res.Success.FetchedAt), "unexpected fetched at %s", res.Success.FetchedAt)
+				},
+				CleanupFn: cleanupFn,
+			},
 			{
 				Name:    "InvalidID",
 				Input:   gidx.MustNewID("invldid"),
@@ -253,6 +283,91 @@ func TestUserInfoStore(t *testing.T) {
 		testingx.RunTests(context.Background(), t, cases, runFn)
 	})
 
+	t.Run("UpdateUserInfo", func(t *testing.T) {
+		t.Parallel()
+
+		disabled := true
+
+		type updateInput struct {
+			id     gidx.PrefixedID
+			update types.UserInfoUpdate
+		}
+
+		runFn := func(ctx context.Context, input updateInput) testingx.TestResult[types.UserInfo] {
+			out, err := svc.UpdateUserInfo(ctx, input.id, input.update)
+
+			return testingx.TestResult[types.UserInfo]{
+				Success: out,
+				Err:     err,
+			}
+		}
+
+		cases := []testingx.TestCase[updateInput, types.UserInfo]{
+			{
+				Name:    "Disable",
+				Input:   updateInput{id: expUserInfoID, update: types.UserInfoUpdate{Disabled: &disabled}},
+				SetupFn: setupFn,
+				CheckFn: func(ctx context.Context, t *testing.T, res testingx.TestResult[types.UserInfo]) {
+					require.NoError(t, res.Err)
+					assert.True(t, res.Success.Disabled)
+					assert.Equal(t, userInfoStored.Name, res.Success.Name)
+
+					byClaims, err := svc.LookupUserInfoByClaims(ctx, user.Issuer, user.Subject)
+					require.NoError(t, err)
+					assert.True(t, byClaims.Disabled)
+				},
+				CleanupFn: cleanupFn,
+			},
+			{
+				Name:    "NotFound",
+				Input:   updateInput{id: gidx.MustNewID(types.IdentityUserIDPrefix), update: types.UserInfoUpdate{Disabled: &disabled}},
+				SetupFn: setupFn,
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[types.UserInfo]) {
+					assert.ErrorIs(t, res.Err, types.ErrUserInfoNotFound)
+				},
+				CleanupFn: cleanupFn,
+			},
+		}
+
+		testingx.RunTests(context.Background(), t, cases, runFn)
+	})
+
+	t.Run("DeleteUserInfo", func(t *testing.T) {
+		t.Parallel()
+
+		runFn := func(ctx context.Context, input gidx.PrefixedID) testingx.TestResult[any] {
+			return testingx.TestResult[any]{
+				Err: svc.DeleteUserInfo(ctx, input),
+			}
+		}
+
+		cases := []testingx.TestCase[gidx.PrefixedID, any]{
+			{
+				Name:    "Success",
+				Input:   expUserInfoIDRemappedSub,
+				SetupFn: setupFn,
+				CheckFn: func(ctx context.Context, t *testing.T, res testingx.TestResult[any]) {
+					require.NoError(t, res.Err)
+
+					_, err := svc.LookupUserInfoByID(ctx, expUserInfoIDRemappedSub)
+					assert.ErrorIs(t, err, types.ErrUserInfoNotFound)
+				},
+				CleanupFn: cleanupFn,
+			},
+			{
+				Name:    "NotFound",
+				Input:   gidx.MustNewID(types.IdentityUserIDPrefix),
+				SetupFn: setupFn,
+				CheckFn: func(_ context.Context, t *testing.T, res testingx.TestResult[any]) {
+					assert.ErrorIs(t, res.Err, types.ErrUserInfoNotFound)
+				},
+				CleanupFn: cleanupFn,
+			},
+		}
+
+		testingx.RunTests(context.Background(), t, cases, runFn)
+	})
+
 	t.Run("ParseUserInfoFromClaims", func(t *testing.T) {
 		t.Parallel()
 
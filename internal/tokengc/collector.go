@@ -0,0 +1,93 @@
+package tokengc
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+const (
+	// DefaultInterval is how often expired tokens are removed if no interval is configured.
+	DefaultInterval = time.Hour
+	// DefaultRetention is how long tokens are kept after they expire if no retention is configured.
+	DefaultRetention = 7 * 24 * time.Hour
+)
+
+// Collector removes expired tokens from storage.
+type Collector struct {
+	tokenSvc  types.AccessTokenService
+	interval  time.Duration
+	retention time.Duration
+	logger    *zap.Logger
+}
+
+// Opt represents an option for configuring a Collector.
+type Opt func(*Collector)
+
+// WithLogger sets the logger for the Collector.
+func WithLogger(logger *zap.Logger) Opt {
+	return func(c *Collector) {
+		c.logger = logger
+	}
+}
+
+// NewCollector creates a Collector given a token service and token GC configuration.
+func NewCollector(tokenSvc types.AccessTokenService, config fositex.TokenGCConfig, opts ...Opt) *Collector {
+	interval := config.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	retention := config.Retention
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+
+	c := &Collector{
+		tokenSvc:  tokenSvc,
+		interval:  interval,
+		retention: retention,
+		logger:    zap.NewNop(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Run removes expired tokens every interval until the given context is canceled.
+func (c *Collector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+
+	defer ticker.Stop()
+
+	for {
+		c.Collect(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect removes tokens which expired longer than the retention period ago.
+func (c *Collector) Collect(ctx context.Context) {
+	before := time.Now().Add(-c.retention)
+
+	count, err := c.tokenSvc.DeleteExpiredTokens(ctx, before)
+	if err != nil {
+		c.logger.Error("failed to remove expired tokens", zap.Error(err))
+
+		return
+	}
+
+	c.logger.Debug("removed expired tokens", zap.Int64("count", count), zap.Time("expired_before", before))
+}
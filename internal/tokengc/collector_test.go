@@ -0,0 +1,80 @@
+package tokengc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.infratographer.com/identity-api/internal/fositex"
+	"go.infratographer.com/identity-api/internal/testingx"
+)
+
+type mockTokenService struct {
+	before time.Time
+	err    error
+}
+
+func (s *mockTokenService) DeleteExpiredTokens(_ context.Context, before time.Time) (int64, error) {
+	s.before = before
+
+	return 1, s.err
+}
+
+// TestCollect checks that tokens are removed once they have been expired for the retention period.
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	type input struct {
+		config fositex.TokenGCConfig
+		err    error
+	}
+
+	runFn := func(ctx context.Context, in input) testingx.TestResult[time.Duration] {
+		svc := &mockTokenService{
+			err: in.err,
+		}
+
+		start := time.Now()
+
+		NewCollector(svc, in.config).Collect(ctx)
+
+		return testingx.TestResult[time.Duration]{
+			Success: start.Sub(svc.before),
+		}
+	}
+
+	testCases := []testingx.TestCase[input, time.Duration]{
+		{
+			Name:  "DefaultRetention",
+			Input: input{},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[time.Duration]) {
+				assert.InDelta(t, DefaultRetention, result.Success, float64(time.Second))
+			},
+		},
+		{
+			Name: "ConfiguredRetention",
+			Input: input{
+				config: fositex.TokenGCConfig{
+					Retention: time.Hour,
+				},
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[time.Duration]) {
+				assert.InDelta(t, time.Hour, result.Success, float64(time.Second))
+			},
+		},
+		{
+			Name: "StorageError",
+			Input: input{
+				err: errors.New("boom"),
+			},
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[time.Duration]) {
+				assert.InDelta(t, DefaultRetention, result.Success, float64(time.Second))
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
@@ -0,0 +1,2 @@
+// Package tokengc periodically removes expired access and refresh tokens from storage.
+package tokengc
@@ -1,12 +1,50 @@
 package types
 
 import (
+	"fmt"
+	"slices"
+	"time"
+
 	"github.com/ory/fosite"
 	"go.infratographer.com/x/gidx"
 
 	v1 "go.infratographer.com/identity-api/pkg/api/v1"
 )
 
+const (
+	// GrantTypeClientCredentials is the RFC 6749 client credentials grant type.
+	GrantTypeClientCredentials = "client_credentials"
+	// GrantTypeTokenExchange is the RFC 8693 token exchange grant type.
+	GrantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	// GrantTypeRefreshToken is the RFC 6749 refresh token grant type.
+	GrantTypeRefreshToken = "refresh_token"
+)
+
+// DefaultOAuthClientGrantTypes returns the grant types allowed for OAuth clients created without explicit grant types.
+func DefaultOAuthClientGrantTypes() []string {
+	return []string{
+		GrantTypeClientCredentials,
+		GrantTypeTokenExchange,
+	}
+}
+
+// ValidateOAuthClientGrantTypes checks that every given grant type is supported for OAuth clients.
+func ValidateOAuthClientGrantTypes(grantTypes []string) error {
+	supported := []string{
+		GrantTypeClientCredentials,
+		GrantTypeTokenExchange,
+		GrantTypeRefreshToken,
+	}
+
+	for _, grantType := range grantTypes {
+		if !slices.Contains(supported, grantType) {
+			return fmt.Errorf("%w: %s", ErrInvalidGrantType, grantType)
+		}
+	}
+
+	return nil
+}
+
 // OAuthClients represents a list of token issuers.
 type OAuthClients []OAuthClient
 
@@ -23,11 +61,19 @@ func (i OAuthClients) ToV1OAuthClients() ([]v1.OAuthClient, error) {
 
 // OAuthClient is an OAuth 2.0 Client
 type OAuthClient struct {
-	ID       gidx.PrefixedID
-	OwnerID  gidx.PrefixedID
-	Name     string
-	Secret   string
-	Audience []string
+	ID         gidx.PrefixedID
+	OwnerID    gidx.PrefixedID
+	Name       string
+	Secret     string
+	Audience   []string
+	Scopes     []string
+	GrantTypes []string
+	// CreatedAt is the time the client was created.
+	CreatedAt time.Time
+	// UpdatedAt is the time the client was last updated.
+	UpdatedAt time.Time
+	// LastTokenIssuedAt is the time a token was last issued to the client. It is zero if none has been.
+	LastTokenIssuedAt time.Time
 }
 
 // GetAudience implements fosite.Client
@@ -36,8 +82,8 @@ func (c OAuthClient) GetAudience() fosite.Arguments {
 }
 
 // GetGrantTypes implements fosite.Client
-func (OAuthClient) GetGrantTypes() fosite.Arguments {
-	panic("unimplemented")
+func (c OAuthClient) GetGrantTypes() fosite.Arguments {
+	return fosite.Arguments(c.GrantTypes)
 }
 
 // GetHashedSecret implements fosite.Client
@@ -62,7 +108,7 @@ func (OAuthClient) GetResponseTypes() fosite.Arguments {
 
 // GetScopes implements fosite.Client
 func (c OAuthClient) GetScopes() fosite.Arguments {
-	panic("unimplemented")
+	return fosite.Arguments(c.Scopes)
 }
 
 // IsPublic implements fosite.Client
@@ -77,6 +123,14 @@ func (c OAuthClient) ToV1OAuthClient() v1.OAuthClient {
 	client.ID = c.ID
 	client.Name = c.Name
 	client.Audience = c.Audience
+	client.Scopes = c.Scopes
+	client.GrantTypes = c.GrantTypes
+	client.CreatedAt = c.CreatedAt
+	client.UpdatedAt = c.UpdatedAt
+
+	if !c.LastTokenIssuedAt.IsZero() {
+		client.LastTokenIssuedAt = &c.LastTokenIssuedAt
+	}
 
 	return client
 }
@@ -27,4 +27,10 @@ const (
 
 	// IdentityGroupIDPrefix represents the full identity id prefix for a group resource.
 	IdentityGroupIDPrefix = IdentityService + IdentityGroupResource
+
+	// IdentitySigningKeyResource represents the signing key resource type in an ID.
+	IdentitySigningKeyResource = "key"
+
+	// IdentitySigningKeyIDPrefix represents the full identity id prefix for a signing key resource.
+	IdentitySigningKeyIDPrefix = IdentityService + IdentitySigningKeyResource
 )
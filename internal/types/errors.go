@@ -42,6 +42,29 @@ var (
 
 	// ErrInvalidCEL is returned if the CEL expression is invalid.
 	ErrInvalidCEL = fmt.Errorf("%w: invalid CEL expression", ErrInvalidArgument)
+
+	// ErrInvalidGrantType is returned if an OAuth client grant type is not supported.
+	ErrInvalidGrantType = fmt.Errorf("%w: unsupported grant type", ErrInvalidArgument)
+
+	// ErrInvalidAlgorithm is returned if an issuer JWS algorithm is not supported.
+	ErrInvalidAlgorithm = fmt.Errorf("%w: unsupported signing algorithm", ErrInvalidArgument)
+
+	// ErrSigningKeyNotFound is returned if the signing key doesn't exist.
+	ErrSigningKeyNotFound = fmt.Errorf("%w: signing key not found", ErrNotFound)
+
+	// ErrSigningKeyRetired is returned if a retired signing key is activated.
+	ErrSigningKeyRetired = fmt.Errorf("%w: signing key is retired", ErrInvalidArgument)
+
+	// ErrSigningKeyInUse is returned if the signing key used to sign tokens is retired before another
+	// key has been activated.
+	ErrSigningKeyInUse = fmt.Errorf("%w: signing key is in use", ErrInvalidArgument)
+
+	// ErrIssuerSecretsDisabled is returned if an issuer is given an introspection client secret while
+	// no encryption key is configured.
+	ErrIssuerSecretsDisabled = fmt.Errorf("%w: introspection client secrets require an encryption key", ErrInvalidArgument)
+
+	// ErrSigningKeysDisabled is returned if signing keys are managed while no encryption key is configured.
+	ErrSigningKeysDisabled = errors.New("signing keys are not stored in the database")
 )
 
 // ErrorInvalidTokenRequest represents an error where an access token request failed.
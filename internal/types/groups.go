@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"time"
 
 	"go.infratographer.com/x/gidx"
 
@@ -19,14 +20,20 @@ type Group struct {
 	Name string
 	// Description is the group's description
 	Description string
+	// CreatedAt is the time the group was created
+	CreatedAt time.Time
+	// UpdatedAt is the time the group was last updated
+	UpdatedAt time.Time
 }
 
 // ToV1Group converts a group to an API group.
 func (g *Group) ToV1Group() (v1.Group, error) {
 	group := v1.Group{
-		ID:      g.ID,
-		Name:    g.Name,
-		OwnerID: &g.OwnerID,
+		ID:        g.ID,
+		Name:      g.Name,
+		OwnerID:   &g.OwnerID,
+		CreatedAt: g.CreatedAt,
+		UpdatedAt: g.UpdatedAt,
 	}
 
 	if g.Description != "" {
@@ -57,6 +64,8 @@ type GroupService interface {
 	ListGroupsByOwner(ctx context.Context, ownerID gidx.PrefixedID, pagination crdbx.Paginator) (Groups, error)
 	// ListGroupsBySubject retrieves a list of groups that a subject is a member of.
 	ListGroupsBySubject(ctx context.Context, subject gidx.PrefixedID, pagination crdbx.Paginator) (Groups, error)
+	// ListGroupIDsBySubject retrieves the IDs of all groups that a subject is a member of.
+	ListGroupIDsBySubject(ctx context.Context, subject gidx.PrefixedID) ([]gidx.PrefixedID, error)
 
 	// AddGroupMembers adds subjects to a group.
 	AddGroupMembers(ctx context.Context, groupID gidx.PrefixedID, subjects ...gidx.PrefixedID) error
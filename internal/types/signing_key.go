@@ -0,0 +1,89 @@
+package types
+
+import (
+	"context"
+	"time"
+
+	"go.infratographer.com/x/gidx"
+
+	v1 "go.infratographer.com/identity-api/pkg/api/v1"
+)
+
+const (
+	// SigningKeyStatePending represents a key which is published but does not sign tokens until it is activated.
+	SigningKeyStatePending SigningKeyState = "pending"
+	// SigningKeyStateActive represents a key which signs tokens from its activation time.
+	SigningKeyStateActive SigningKeyState = "active"
+	// SigningKeyStateRetired represents a key which no longer signs tokens.
+	SigningKeyStateRetired SigningKeyState = "retired"
+)
+
+// SigningKeyState represents the lifecycle state of a signing key.
+type SigningKeyState string
+
+// SigningKeys represents a list of signing keys.
+type SigningKeys []*SigningKey
+
+// ToV1SigningKeys converts a slice of signing keys to a slice of API signing keys.
+func (k SigningKeys) ToV1SigningKeys() []v1.SigningKey {
+	out := make([]v1.SigningKey, len(k))
+
+	for i, key := range k {
+		out[i] = key.ToV1SigningKey()
+	}
+
+	return out
+}
+
+// SigningKey represents a token signing key stored in the database.
+type SigningKey struct {
+	// ID is the ID of the key, which is also its key ID in the JWKS.
+	ID gidx.PrefixedID
+	// Algorithm is the JWT signing algorithm of the key.
+	Algorithm string
+	// State is the lifecycle state of the key.
+	State SigningKeyState
+	// PrivateKey is the PKCS #8 encoded private key. It is only ever stored encrypted.
+	PrivateKey []byte
+	// ActivateAt is the time from which the key signs tokens, if it has been activated or scheduled.
+	ActivateAt time.Time
+	// RetiredAt is the time the key was retired, if it has been retired.
+	RetiredAt time.Time
+	// CreatedAt is the time the key was created.
+	CreatedAt time.Time
+}
+
+// ToV1SigningKey converts a signing key to an API signing key. The private key is never included.
+func (k *SigningKey) ToV1SigningKey() v1.SigningKey {
+	out := v1.SigningKey{
+		ID:        k.ID,
+		Algorithm: k.Algorithm,
+		State:     v1.SigningKeyState(k.State),
+		CreatedAt: k.CreatedAt,
+	}
+
+	if !k.ActivateAt.IsZero() {
+		out.ActivateAt = &k.ActivateAt
+	}
+
+	if !k.RetiredAt.IsZero() {
+		out.RetiredAt = &k.RetiredAt
+	}
+
+	return out
+}
+
+// SigningKeyService represents a service for managing signing keys.
+type SigningKeyService interface {
+	// CreateSigningKey stores a new signing key.
+	CreateSigningKey(ctx context.Context, key SigningKey) (*SigningKey, error)
+	// GetSigningKeyByID retrieves a signing key by its ID.
+	GetSigningKeyByID(ctx context.Context, id gidx.PrefixedID) (*SigningKey, error)
+	// ListSigningKeys retrieves all signing keys, ordered by creation time.
+	ListSigningKeys(ctx context.Context) (SigningKeys, error)
+	// ActivateSigningKey activates a signing key, which signs tokens from the given time.
+	ActivateSigningKey(ctx context.Context, id gidx.PrefixedID, at time.Time) (*SigningKey, error)
+	// RetireSigningKey retires a signing key. A key which signs tokens can only be retired once
+	// another key has been activated after it.
+	RetireSigningKey(ctx context.Context, id gidx.PrefixedID) (*SigningKey, error)
+}
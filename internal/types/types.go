@@ -5,6 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"slices"
+	"time"
 
 	"github.com/google/cel-go/cel"
 	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
@@ -47,13 +50,60 @@ type Issuer struct {
 	// URI represents the issuer URI as found in the "iss" claim of a JWT.
 	URI string
 	// JWKSURI represents the URI where the issuer's JWKS lives. Must be accessible by identity-api.
+	// May be empty if the issuer only issues opaque tokens.
 	JWKSURI string
+	// UserInfoURI represents the URI of the issuer's OIDC userinfo endpoint. Must be accessible by identity-api.
+	UserInfoURI string
+	// Discovery represents whether JWKSURI and UserInfoURI are discovered from the issuer's OIDC
+	// provider metadata, which is re-checked periodically.
+	Discovery bool
+	// IntrospectionURI represents the URI of the issuer's RFC 7662 token introspection endpoint,
+	// used to validate opaque access tokens. Must be accessible by identity-api.
+	IntrospectionURI string
+	// IntrospectionClientID represents the client ID used to authenticate to the introspection endpoint.
+	IntrospectionClientID string
+	// IntrospectionClientSecret represents the client secret used to authenticate to the introspection endpoint.
+	IntrospectionClientSecret string
+	// AllowedAudiences represents the audiences that may be requested when exchanging tokens from this
+	// issuer without an authenticated client.
+	AllowedAudiences []string
+	// ClientID represents the client ID identity-api is registered with at the issuer. ID tokens
+	// are only accepted as subject tokens if their "aud" claim contains this value.
+	ClientID string
+	// AllowedAlgorithms represents the JWS algorithms JWTs from this issuer may be signed with. If
+	// empty, any algorithm the signing key allows is accepted.
+	AllowedAlgorithms []string
+	// RequiredAudiences represents the audiences JWTs from this issuer are accepted for. If set, the
+	// "aud" claim must contain at least one of them.
+	RequiredAudiences []string
+	// MaxTokenAge represents how long after their "iat" claim JWTs from this issuer are accepted. If
+	// set, the "iat" claim is required.
+	MaxTokenAge time.Duration
+	// ClockSkew represents the leeway allowed when checking the time-based claims of JWTs from this issuer.
+	ClockSkew time.Duration
+	// UserInfoUpdateMode represents when the stored profiles of existing users are updated from
+	// exchanged tokens. If empty, UserInfoUpdateModeOnChange is used.
+	UserInfoUpdateMode UserInfoUpdateMode
 	// ClaimMappings represents a map of claims to a CEL expression that will be evaluated
 	ClaimMappings ClaimsMapping
 	// ClaimConditions A CEL expressions to restrict authentication to a subset of identities
 	// whose claims must match the expressions. By default all identities
 	// issued by the issuer are allowed to authenticate
 	ClaimConditions *ClaimConditions
+	// ActorConditions is a CEL expression evaluated against the subject token claims ("claims")
+	// and the actor token claims ("actor") to decide whether the actor may act on behalf of
+	// the subject in a delegated token exchange.
+	ActorConditions *ClaimConditions
+	// ScopeMapping is a CEL expression evaluated against the subject token claims ("claims") which
+	// produces the list of scopes that may be granted in a token exchange. If unset, no scopes are granted.
+	ScopeMapping *ScopeMapping
+	// ProfileMapping is a CEL expression evaluated against the subject token claims ("claims") which
+	// produces the attributes stored in the profile of the user. If unset, users have no attributes.
+	ProfileMapping *ProfileMapping
+	// CreatedAt is the time the issuer was created.
+	CreatedAt time.Time
+	// UpdatedAt is the time the issuer was last updated.
+	UpdatedAt time.Time
 }
 
 // ToV1Issuer converts an issuer to an API issuer.
@@ -63,40 +113,133 @@ func (i Issuer) ToV1Issuer() (v1.Issuer, error) {
 		return v1.Issuer{}, err
 	}
 
-	claimConditions := ""
+	claimConditions, err := i.ClaimConditions.Repr()
+	if err != nil {
+		return v1.Issuer{}, err
+	}
 
-	if i.ClaimConditions != nil {
-		ast := i.ClaimConditions.AST()
+	actorConditions, err := i.ActorConditions.Repr()
+	if err != nil {
+		return v1.Issuer{}, err
+	}
 
-		if ast != nil {
-			str, err := cel.AstToString(ast)
-			if err != nil {
-				return v1.Issuer{}, err
-			}
+	scopeMapping, err := i.ScopeMapping.Repr()
+	if err != nil {
+		return v1.Issuer{}, err
+	}
 
-			claimConditions = str
-		}
+	profileMapping, err := i.ProfileMapping.Repr()
+	if err != nil {
+		return v1.Issuer{}, err
+	}
+
+	allowedAudiences := i.AllowedAudiences
+	if allowedAudiences == nil {
+		allowedAudiences = []string{}
+	}
+
+	allowedAlgorithms := i.AllowedAlgorithms
+	if allowedAlgorithms == nil {
+		allowedAlgorithms = []string{}
+	}
+
+	requiredAudiences := i.RequiredAudiences
+	if requiredAudiences == nil {
+		requiredAudiences = []string{}
 	}
 
 	out := v1.Issuer{
-		ID:              i.ID,
-		Name:            i.Name,
-		URI:             i.URI,
-		JWKSURI:         i.JWKSURI,
-		ClaimMappings:   claimsMappingRepr,
-		ClaimConditions: claimConditions,
+		ID:                    i.ID,
+		Name:                  i.Name,
+		URI:                   i.URI,
+		JWKSURI:               i.JWKSURI,
+		UserInfoURI:           i.UserInfoURI,
+		Discovery:             i.Discovery,
+		ClientID:              i.ClientID,
+		IntrospectionURI:      i.IntrospectionURI,
+		IntrospectionClientID: i.IntrospectionClientID,
+		AllowedAudiences:      allowedAudiences,
+		AllowedAlgorithms:     allowedAlgorithms,
+		RequiredAudiences:     requiredAudiences,
+		MaxTokenAge:           int(i.MaxTokenAge / time.Second),
+		ClockSkew:             int(i.ClockSkew / time.Second),
+		UserInfoUpdateMode:    v1.UserInfoUpdateMode(i.UserInfoUpdateMode.OrDefault()),
+		ClaimMappings:         claimsMappingRepr,
+		ClaimConditions:       claimConditions,
+		ActorConditions:       actorConditions,
+		ScopeMapping:          scopeMapping,
+		ProfileMapping:        profileMapping,
+		CreatedAt:             i.CreatedAt,
+		UpdatedAt:             i.UpdatedAt,
 	}
 
 	return out, nil
 }
 
+// UserInfoUpdateMode represents when the stored profiles of existing users are updated from exchanged tokens.
+type UserInfoUpdateMode string
+
+const (
+	// UserInfoUpdateModeNever keeps the profile of a user as it was first stored.
+	UserInfoUpdateModeNever UserInfoUpdateMode = "never"
+	// UserInfoUpdateModeAlways replaces the profile of a user with the one from each exchanged token,
+	// clearing values the token lacks.
+	UserInfoUpdateModeAlways UserInfoUpdateMode = "always"
+	// UserInfoUpdateModeOnChange updates the values of the profile of a user that differ in an
+	// exchanged token, keeping values the token lacks.
+	UserInfoUpdateModeOnChange UserInfoUpdateMode = "on_change"
+)
+
+// OrDefault returns the mode, or UserInfoUpdateModeOnChange if it is empty.
+func (m UserInfoUpdateMode) OrDefault() UserInfoUpdateMode {
+	if m == "" {
+		return UserInfoUpdateModeOnChange
+	}
+
+	return m
+}
+
+// ValidateIssuerAlgorithms checks that every given JWS algorithm may be allowed for issuers. Only
+// asymmetric algorithms are supported, as issuer keys are fetched from their JWKS.
+func ValidateIssuerAlgorithms(algs []string) error {
+	supported := []string{
+		"RS256", "RS384", "RS512",
+		"PS256", "PS384", "PS512",
+		"ES256", "ES384", "ES512",
+		"EdDSA",
+	}
+
+	for _, alg := range algs {
+		if !slices.Contains(supported, alg) {
+			return fmt.Errorf("%w: %s", ErrInvalidAlgorithm, alg)
+		}
+	}
+
+	return nil
+}
+
 // IssuerUpdate represents an update operation on an issuer.
 type IssuerUpdate struct {
-	Name            *string
-	URI             *string
-	JWKSURI         *string
-	ClaimMappings   ClaimsMapping
-	ClaimConditions *ClaimConditions
+	Name                      *string
+	URI                       *string
+	JWKSURI                   *string
+	UserInfoURI               *string
+	Discovery                 *bool
+	ClientID                  *string
+	IntrospectionURI          *string
+	IntrospectionClientID     *string
+	IntrospectionClientSecret *string
+	AllowedAudiences          []string
+	AllowedAlgorithms         []string
+	RequiredAudiences         []string
+	MaxTokenAge               *time.Duration
+	ClockSkew                 *time.Duration
+	UserInfoUpdateMode        *UserInfoUpdateMode
+	ClaimMappings             ClaimsMapping
+	ClaimConditions           *ClaimConditions
+	ActorConditions           *ClaimConditions
+	ScopeMapping              *ScopeMapping
+	ProfileMapping            *ProfileMapping
 }
 
 // IssuerService represents a service for managing issuers.
@@ -105,6 +248,7 @@ type IssuerService interface {
 	GetIssuerByID(ctx context.Context, id gidx.PrefixedID) (*Issuer, error)
 	GetOwnerIssuers(ctx context.Context, id gidx.PrefixedID, pagination crdbx.Paginator) (Issuers, error)
 	GetIssuerByURI(ctx context.Context, uri string) (*Issuer, error)
+	ListDiscoveryIssuers(ctx context.Context) (Issuers, error)
 	UpdateIssuer(ctx context.Context, id gidx.PrefixedID, update IssuerUpdate) (*Issuer, error)
 	DeleteIssuer(ctx context.Context, id gidx.PrefixedID) error
 }
@@ -293,6 +437,166 @@ func (c *ClaimConditions) AST() *cel.Ast {
 	return c.ast
 }
 
+// Repr produces a human-readable CEL expression for the conditions, or an empty string if
+// there are none.
+func (c *ClaimConditions) Repr() (string, error) {
+	if c == nil || c.ast == nil {
+		return "", nil
+	}
+
+	return cel.AstToString(c.ast)
+}
+
+// ScopeMapping is a CEL expression producing the list of scopes that may be granted for a token
+type ScopeMapping struct {
+	ast *cel.Ast
+}
+
+// NewScopeMapping creates a ScopeMapping from the given CEL expression.
+func NewScopeMapping(expr string) (*ScopeMapping, error) {
+	if expr == "" {
+		return &ScopeMapping{}, nil
+	}
+
+	ast, err := celutils.ParseCEL(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expressions referencing claims directly are dynamically typed, so their result is checked on evaluation.
+	switch ast.OutputType().TypeName() {
+	case "list", "dyn":
+	default:
+		return nil, fmt.Errorf(
+			"%w: expected list output type, got %s",
+			ErrInvalidCEL,
+			ast.OutputType().TypeName(),
+		)
+	}
+
+	return &ScopeMapping{ast: ast}, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m *ScopeMapping) MarshalJSON() ([]byte, error) {
+	if m.ast == nil {
+		return nil, nil
+	}
+
+	expr, err := cel.AstToCheckedExpr(m.ast)
+	if err != nil {
+		return nil, err
+	}
+
+	return prototext.Marshal(expr)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *ScopeMapping) UnmarshalJSON(data []byte) error {
+	if string(data) == "" {
+		m.ast = nil
+		return nil
+	}
+
+	var expr exprpb.CheckedExpr
+	if err := prototext.Unmarshal(data, &expr); err != nil {
+		return err
+	}
+
+	m.ast = cel.CheckedExprToAst(&expr)
+
+	return nil
+}
+
+// AST returns the underlying *cel.Ast.
+func (m *ScopeMapping) AST() *cel.Ast {
+	return m.ast
+}
+
+// Repr produces a human-readable CEL expression for the mapping, or an empty string if there is none.
+func (m *ScopeMapping) Repr() (string, error) {
+	if m == nil || m.ast == nil {
+		return "", nil
+	}
+
+	return cel.AstToString(m.ast)
+}
+
+// ProfileMapping is a CEL expression producing the attributes stored in the profile of a user.
+type ProfileMapping struct {
+	ast *cel.Ast
+}
+
+// NewProfileMapping creates a ProfileMapping from the given CEL expression.
+func NewProfileMapping(expr string) (*ProfileMapping, error) {
+	if expr == "" {
+		return &ProfileMapping{}, nil
+	}
+
+	ast, err := celutils.ParseCEL(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expressions referencing claims directly are dynamically typed, so their result is checked on evaluation.
+	switch ast.OutputType().TypeName() {
+	case "map", "dyn":
+	default:
+		return nil, fmt.Errorf(
+			"%w: expected map output type, got %s",
+			ErrInvalidCEL,
+			ast.OutputType().TypeName(),
+		)
+	}
+
+	return &ProfileMapping{ast: ast}, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m *ProfileMapping) MarshalJSON() ([]byte, error) {
+	if m.ast == nil {
+		return nil, nil
+	}
+
+	expr, err := cel.AstToCheckedExpr(m.ast)
+	if err != nil {
+		return nil, err
+	}
+
+	return prototext.Marshal(expr)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *ProfileMapping) UnmarshalJSON(data []byte) error {
+	if string(data) == "" {
+		m.ast = nil
+		return nil
+	}
+
+	var expr exprpb.CheckedExpr
+	if err := prototext.Unmarshal(data, &expr); err != nil {
+		return err
+	}
+
+	m.ast = cel.CheckedExprToAst(&expr)
+
+	return nil
+}
+
+// AST returns the underlying *cel.Ast.
+func (m *ProfileMapping) AST() *cel.Ast {
+	return m.ast
+}
+
+// Repr produces a human-readable CEL expression for the mapping, or an empty string if there is none.
+func (m *ProfileMapping) Repr() (string, error) {
+	if m == nil || m.ast == nil {
+		return "", nil
+	}
+
+	return cel.AstToString(m.ast)
+}
+
 // UserInfo contains information about the user from the source OIDC provider.
 // As defined in https://openid.net/specs/openid-connect-core-1_0.html#StandardClaims
 type UserInfo struct {
@@ -301,6 +605,38 @@ type UserInfo struct {
 	Email   string          `json:"email,omitempty"`
 	Issuer  string          `json:"iss"`
 	Subject string          `json:"sub"`
+	// Attributes are the additional attributes of the user, produced by the profile mapping of the issuer.
+	Attributes map[string]any `json:"attributes,omitempty"`
+	// FetchedAt is when the user info was last fetched from the issuer's userinfo endpoint.
+	FetchedAt time.Time `json:"-"`
+	// Disabled users may not be issued tokens.
+	Disabled bool `json:"-"`
+	// CreatedAt is when the user was first stored.
+	CreatedAt time.Time `json:"-"`
+	// UpdatedAt is when the profile of the user, or whether they are disabled, last changed.
+	UpdatedAt time.Time `json:"-"`
+	// LastExchangeAt is when the user last exchanged a token. It is zero if they never have.
+	LastExchangeAt time.Time `json:"-"`
+}
+
+// UserInfoUpdate represents an update operation on a user.
+type UserInfoUpdate struct {
+	Disabled *bool
+}
+
+// ProfileEqual reports whether the profile data of the user info, its name, email and attributes,
+// equals that of other.
+func (u UserInfo) ProfileEqual(other UserInfo) bool {
+	return u.Name == other.Name && u.Email == other.Email && EqualAttributes(u.Attributes, other.Attributes)
+}
+
+// EqualAttributes reports whether the given user attributes are equal. Nil and empty attributes are equal.
+func EqualAttributes(a, b map[string]any) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
 }
 
 // ToV1User converts an user info to an API user info.
@@ -318,12 +654,29 @@ func (u UserInfo) ToV1User() (v1.User, error) {
 		email = &u.Email
 	}
 
+	var attributes *map[string]any
+
+	if len(u.Attributes) != 0 {
+		attributes = &u.Attributes
+	}
+
+	var lastExchangeAt *time.Time
+
+	if !u.LastExchangeAt.IsZero() {
+		lastExchangeAt = &u.LastExchangeAt
+	}
+
 	out := v1.User{
-		ID:      u.ID,
-		Name:    name,
-		Email:   email,
-		Issuer:  u.Issuer,
-		Subject: u.Subject,
+		ID:             u.ID,
+		Name:           name,
+		Email:          email,
+		Issuer:         u.Issuer,
+		Subject:        u.Subject,
+		Attributes:     attributes,
+		Disabled:       u.Disabled,
+		CreatedAt:      u.CreatedAt,
+		UpdatedAt:      u.UpdatedAt,
+		LastExchangeAt: lastExchangeAt,
 	}
 
 	return out, nil
@@ -347,10 +700,49 @@ type UserInfoService interface {
 	// StoreUserInfo stores the userInfo into the storage backend.
 	StoreUserInfo(ctx context.Context, userInfo UserInfo) (UserInfo, error)
 
+	// UpdateUserInfo updates the user with the given ID.
+	UpdateUserInfo(ctx context.Context, id gidx.PrefixedID, update UserInfoUpdate) (UserInfo, error)
+
+	// DeleteUserInfo deletes the user with the given ID.
+	DeleteUserInfo(ctx context.Context, id gidx.PrefixedID) error
+
 	// ParseUserInfoFromClaims parses OIDC ID token claims from the given claim map.
 	ParseUserInfoFromClaims(claims map[string]any) (UserInfo, error)
 }
 
+// RefreshTokenService defines the storage interface for refresh tokens.
+type RefreshTokenService interface {
+	// RevokeSubjectRefreshTokens revokes all refresh tokens issued to the given subject.
+	RevokeSubjectRefreshTokens(ctx context.Context, subject gidx.PrefixedID) error
+}
+
+// AccessTokenService defines the storage interface for issued access tokens.
+type AccessTokenService interface {
+	// DeleteExpiredTokens removes access and refresh tokens which expired before the given time.
+	DeleteExpiredTokens(ctx context.Context, before time.Time) (int64, error)
+}
+
+// TokenRevocationService defines the storage interface for the deny-list of revoked access tokens.
+type TokenRevocationService interface {
+	// IsAccessTokenRevoked checks whether the access token with the given jti has been revoked.
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+	// RevokeSubjectTokens revokes all access and refresh tokens issued to the given subject.
+	RevokeSubjectTokens(ctx context.Context, subject gidx.PrefixedID) error
+	// RevokeClientTokens revokes all access and refresh tokens issued to the given OAuth client.
+	RevokeClientTokens(ctx context.Context, clientID gidx.PrefixedID) error
+}
+
+// LastSeenService defines the storage interface for recording when users and OAuth clients were last
+// issued tokens.
+type LastSeenService interface {
+	// SetUsersLastExchangeAt records the time each of the given users last exchanged a token. Times
+	// before the recorded time of a user are ignored.
+	SetUsersLastExchangeAt(ctx context.Context, times map[gidx.PrefixedID]time.Time) error
+	// SetOAuthClientsLastTokenIssuedAt records the time a token was last issued to each of the given
+	// OAuth clients. Times before the recorded time of a client are ignored.
+	SetOAuthClientsLastTokenIssuedAt(ctx context.Context, times map[gidx.PrefixedID]time.Time) error
+}
+
 // OAuthClientManager defines the storage interface for OAuth clients.
 type OAuthClientManager interface {
 	CreateOAuthClient(ctx context.Context, client OAuthClient) (OAuthClient, error)
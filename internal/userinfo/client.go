@@ -0,0 +1,75 @@
+package userinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+const (
+	// DefaultTimeout is the default timeout for userinfo requests.
+	DefaultTimeout = 10 * time.Second
+
+	claimSubject = "sub"
+
+	maxResponseBytes = 1 << 20
+)
+
+// Client fetches user info from the OIDC userinfo endpoints of issuers.
+type Client struct {
+	httpClient *http.Client
+}
+
+// NewClient creates a new userinfo client. If httpClient is nil, a client with DefaultTimeout is used.
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: DefaultTimeout,
+		}
+	}
+
+	return &Client{
+		httpClient: httpClient,
+	}
+}
+
+// FetchUserInfo requests the user info of the given access token from the given userinfo endpoint,
+// returning the claims in the response. Errors wrap types.ErrFetchUserInfo.
+func (c *Client) FetchUserInfo(ctx context.Context, endpoint, token string) (map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", types.ErrFetchUserInfo, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", types.ErrFetchUserInfo, err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: unexpected status code %d", types.ErrFetchUserInfo, resp.StatusCode)
+	}
+
+	var claims map[string]any
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %w", types.ErrFetchUserInfo, err)
+	}
+
+	// Per OIDC Core section 5.3.2, the sub claim is always returned.
+	if sub, ok := claims[claimSubject].(string); !ok || sub == "" {
+		return nil, fmt.Errorf("%w: response is missing '%s'", types.ErrFetchUserInfo, claimSubject)
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,83 @@
+package userinfo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.infratographer.com/identity-api/internal/testingx"
+	"go.infratographer.com/identity-api/internal/types"
+)
+
+// TestFetchUserInfo checks that userinfo responses are interpreted correctly.
+func TestFetchUserInfo(t *testing.T) {
+	t.Parallel()
+
+	responses := map[string]map[string]any{
+		"valid": {
+			"sub":   "foo",
+			"name":  "Foo Bar",
+			"email": "foo@example.com",
+		},
+		"nosub": {
+			"name": "Foo Bar",
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, ok := responses[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	client := NewClient(srv.Client())
+
+	runFn := func(ctx context.Context, token string) testingx.TestResult[map[string]any] {
+		out, err := client.FetchUserInfo(ctx, srv.URL, token)
+
+		return testingx.TestResult[map[string]any]{
+			Success: out,
+			Err:     err,
+		}
+	}
+
+	testCases := []testingx.TestCase[string, map[string]any]{
+		{
+			Name:  "Success",
+			Input: "valid",
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[map[string]any]) {
+				assert.NoError(t, result.Err)
+				assert.Equal(t, responses["valid"], result.Success)
+			},
+		},
+		{
+			Name:  "MissingSubject",
+			Input: "nosub",
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[map[string]any]) {
+				assert.ErrorIs(t, result.Err, types.ErrFetchUserInfo)
+			},
+		},
+		{
+			Name:  "Unauthorized",
+			Input: "invalid",
+			CheckFn: func(_ context.Context, t *testing.T, result testingx.TestResult[map[string]any]) {
+				assert.ErrorIs(t, result.Err, types.ErrFetchUserInfo)
+			},
+		},
+	}
+
+	testingx.RunTests(context.Background(), t, testCases, runFn)
+}
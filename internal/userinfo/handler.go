@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 
 	"go.infratographer.com/x/echojwtx"
@@ -17,10 +18,11 @@ import (
 	v1 "go.infratographer.com/identity-api/pkg/api/v1"
 )
 
-// Store is an interface providing userinfo and group services
+// Store is an interface providing userinfo, group and token revocation services
 type Store interface {
 	types.UserInfoService
 	types.GroupService
+	types.TokenRevocationService
 }
 
 // Handler provides the endpoint for /userinfo
@@ -102,8 +104,36 @@ func (h *Handler) listUserGroups(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, collection)
 }
 
+// requireUnrevokedToken rejects requests authenticated with an access token which has been revoked.
+func (h *Handler) requireUnrevokedToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		token, ok := ctx.Get("user").(*jwt.Token)
+		if !ok {
+			return echo.NewHTTPError(http.StatusUnauthorized, "missing access token")
+		}
+
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok {
+			return echo.NewHTTPError(http.StatusUnauthorized, "invalid access token claims")
+		}
+
+		jti, _ := claims["jti"].(string)
+
+		revoked, err := h.store.IsAccessTokenRevoked(ctx.Request().Context(), jti)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to check token revocation").SetInternal(err)
+		}
+
+		if revoked {
+			return echo.NewHTTPError(http.StatusUnauthorized, "access token has been revoked")
+		}
+
+		return next(ctx)
+	}
+}
+
 // Routes registers the userinfo handler in a echo.Group
 func (h *Handler) Routes(rg *echo.Group) {
-	rg.GET("userinfo", h.handle)
-	rg.GET("userinfo/groups", h.listUserGroups)
+	rg.GET("userinfo", h.handle, h.requireUnrevokedToken)
+	rg.GET("userinfo/groups", h.listUserGroups, h.requireUnrevokedToken)
 }
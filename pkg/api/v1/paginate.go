@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"net/url"
+	"time"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/identity-api/internal/crdbx"
+)
+
+// sortFieldID is the sort field of collections sorted by ID only.
+const sortFieldID = "id"
+
+// sortFields returns the fields a collection sorted by the given field is ordered by. Items with
+// equal values are ordered by ID.
+func sortFields(field string) []string {
+	if field == "" || field == sortFieldID {
+		return []string{sortFieldID}
+	}
+
+	return []string{field, sortFieldID}
+}
+
+// newSortedCursor creates a cursor to the items after the given item of a collection sorted by the
+// given field, holding the value of the field and the ID of the item. A nil value is NULL, and is
+// kept in the cursor as an empty value.
+func newSortedCursor(field string, id gidx.PrefixedID, value *time.Time) (*crdbx.Cursor, error) {
+	if field == "" || field == sortFieldID {
+		return crdbx.NewCursor(sortFieldID, id.String())
+	}
+
+	values := url.Values{}
+
+	values.Set(sortFieldID, id.String())
+	values.Set(field, "")
+
+	if value != nil {
+		values.Set(field, value.UTC().Format(time.RFC3339Nano))
+	}
+
+	return crdbx.NewCursorFromValues(values)
+}
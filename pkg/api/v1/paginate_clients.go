@@ -1,6 +1,10 @@
 package v1
 
-import "go.infratographer.com/identity-api/internal/crdbx"
+import (
+	"time"
+
+	"go.infratographer.com/identity-api/internal/crdbx"
+)
 
 var _ crdbx.Paginator = GetOwnerOAuthClientsParams{}
 
@@ -18,9 +22,17 @@ func (p GetOwnerOAuthClientsParams) GetLimit() int {
 	return *p.Limit
 }
 
-// GetOnlyFields implements crdbx.Paginator setting the only permitted field to `id`.
+// GetOnlyFields implements crdbx.Paginator permitting the sort field, followed by `id`.
 func (p GetOwnerOAuthClientsParams) GetOnlyFields() []string {
-	return []string{"id"}
+	return sortFields(string(p.sortField()))
+}
+
+func (p GetOwnerOAuthClientsParams) sortField() OAuthClientSortField {
+	if p.Sort == nil {
+		return OAuthClientSortFieldID
+	}
+
+	return *p.Sort
 }
 
 // SetPagination sets the pagination on the provided collection.
@@ -28,7 +40,20 @@ func (p GetOwnerOAuthClientsParams) SetPagination(collection *OAuthClientCollect
 	collection.Pagination.Limit = crdbx.Limit(p.GetLimit())
 
 	if count := len(collection.Clients); count != 0 && count == collection.Pagination.Limit {
-		cursor, err := crdbx.NewCursor("id", collection.Clients[count-1].ID.String())
+		item := collection.Clients[count-1]
+
+		var value *time.Time
+
+		switch p.sortField() {
+		case OAuthClientSortFieldCreatedAt:
+			value = &item.CreatedAt
+		case OAuthClientSortFieldUpdatedAt:
+			value = &item.UpdatedAt
+		case OAuthClientSortFieldLastTokenIssuedAt:
+			value = item.LastTokenIssuedAt
+		}
+
+		cursor, err := newSortedCursor(string(p.sortField()), item.ID, value)
 		if err != nil {
 			return err
 		}
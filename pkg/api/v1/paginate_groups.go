@@ -1,6 +1,10 @@
 package v1
 
-import "go.infratographer.com/identity-api/internal/crdbx"
+import (
+	"time"
+
+	"go.infratographer.com/identity-api/internal/crdbx"
+)
 
 var _ crdbx.Paginator = ListGroupsParams{}
 
@@ -18,9 +22,17 @@ func (p ListGroupsParams) GetLimit() int {
 	return *p.Limit
 }
 
-// GetOnlyFields implements crdbx.Paginator setting the only permitted field to `id`.
+// GetOnlyFields implements crdbx.Paginator permitting the sort field, followed by `id`.
 func (p ListGroupsParams) GetOnlyFields() []string {
-	return []string{"id"}
+	return sortFields(string(p.sortField()))
+}
+
+func (p ListGroupsParams) sortField() GroupSortField {
+	if p.Sort == nil {
+		return GroupSortFieldID
+	}
+
+	return *p.Sort
 }
 
 // SetPagination sets the pagination on the provided collection.
@@ -28,7 +40,18 @@ func (p ListGroupsParams) SetPagination(collection *GroupCollection) error {
 	collection.Pagination.Limit = crdbx.Limit(p.GetLimit())
 
 	if count := len(collection.Groups); count != 0 && count == collection.Pagination.Limit {
-		cursor, err := crdbx.NewCursor("id", collection.Groups[count-1].ID.String())
+		item := collection.Groups[count-1]
+
+		var value *time.Time
+
+		switch p.sortField() {
+		case GroupSortFieldCreatedAt:
+			value = &item.CreatedAt
+		case GroupSortFieldUpdatedAt:
+			value = &item.UpdatedAt
+		}
+
+		cursor, err := newSortedCursor(string(p.sortField()), item.ID, value)
 		if err != nil {
 			return err
 		}
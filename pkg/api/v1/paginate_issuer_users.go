@@ -1,6 +1,10 @@
 package v1
 
-import "go.infratographer.com/identity-api/internal/crdbx"
+import (
+	"time"
+
+	"go.infratographer.com/identity-api/internal/crdbx"
+)
 
 var _ crdbx.Paginator = GetIssuerUsersParams{}
 
@@ -18,9 +22,17 @@ func (p GetIssuerUsersParams) GetLimit() int {
 	return *p.Limit
 }
 
-// GetOnlyFields implements crdbx.Paginator setting the only permitted field to `id`.
+// GetOnlyFields implements crdbx.Paginator permitting the sort field, followed by `id`.
 func (p GetIssuerUsersParams) GetOnlyFields() []string {
-	return []string{"id"}
+	return sortFields(string(p.sortField()))
+}
+
+func (p GetIssuerUsersParams) sortField() UserSortField {
+	if p.Sort == nil {
+		return UserSortFieldID
+	}
+
+	return *p.Sort
 }
 
 // SetPagination sets the pagination on the provided collection.
@@ -28,7 +40,20 @@ func (p GetIssuerUsersParams) SetPagination(collection *UserCollection) error {
 	collection.Pagination.Limit = crdbx.Limit(p.GetLimit())
 
 	if count := len(collection.Users); count != 0 && count == collection.Pagination.Limit {
-		cursor, err := crdbx.NewCursor("id", collection.Users[count-1].ID.String())
+		item := collection.Users[count-1]
+
+		var value *time.Time
+
+		switch p.sortField() {
+		case UserSortFieldCreatedAt:
+			value = &item.CreatedAt
+		case UserSortFieldUpdatedAt:
+			value = &item.UpdatedAt
+		case UserSortFieldLastExchangeAt:
+			value = item.LastExchangeAt
+		}
+
+		cursor, err := newSortedCursor(string(p.sortField()), item.ID, value)
 		if err != nil {
 			return err
 		}
@@ -1,6 +1,10 @@
 package v1
 
-import "go.infratographer.com/identity-api/internal/crdbx"
+import (
+	"time"
+
+	"go.infratographer.com/identity-api/internal/crdbx"
+)
 
 var _ crdbx.Paginator = ListOwnerIssuersParams{}
 
@@ -18,9 +22,17 @@ func (p ListOwnerIssuersParams) GetLimit() int {
 	return *p.Limit
 }
 
-// GetOnlyFields implements crdbx.Paginator setting the only permitted field to `id`.
+// GetOnlyFields implements crdbx.Paginator permitting the sort field, followed by `id`.
 func (p ListOwnerIssuersParams) GetOnlyFields() []string {
-	return []string{"id"}
+	return sortFields(string(p.sortField()))
+}
+
+func (p ListOwnerIssuersParams) sortField() IssuerSortField {
+	if p.Sort == nil {
+		return IssuerSortFieldID
+	}
+
+	return *p.Sort
 }
 
 // SetPagination sets the pagination on the provided collection.
@@ -28,7 +40,18 @@ func (p ListOwnerIssuersParams) SetPagination(collection *IssuerCollection) erro
 	collection.Pagination.Limit = crdbx.Limit(p.GetLimit())
 
 	if count := len(collection.Issuers); count != 0 && count == collection.Pagination.Limit {
-		cursor, err := crdbx.NewCursor("id", collection.Issuers[count-1].ID.String())
+		item := collection.Issuers[count-1]
+
+		var value *time.Time
+
+		switch p.sortField() {
+		case IssuerSortFieldCreatedAt:
+			value = &item.CreatedAt
+		case IssuerSortFieldUpdatedAt:
+			value = &item.UpdatedAt
+		}
+
+		cursor, err := newSortedCursor(string(p.sortField()), item.ID, value)
 		if err != nil {
 			return err
 		}
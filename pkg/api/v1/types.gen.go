@@ -11,12 +11,67 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"go.infratographer.com/identity-api/internal/crdbx"
 	"go.infratographer.com/x/gidx"
 )
 
+// Defines values for CreateSigningKeyAlgorithm.
+const (
+	SigningKeyAlgorithmES256 CreateSigningKeyAlgorithm = "ES256"
+	SigningKeyAlgorithmES384 CreateSigningKeyAlgorithm = "ES384"
+	SigningKeyAlgorithmEdDSA CreateSigningKeyAlgorithm = "EdDSA"
+	SigningKeyAlgorithmRS256 CreateSigningKeyAlgorithm = "RS256"
+	SigningKeyAlgorithmRS384 CreateSigningKeyAlgorithm = "RS384"
+	SigningKeyAlgorithmRS512 CreateSigningKeyAlgorithm = "RS512"
+)
+
+// Defines values for GroupSortField.
+const (
+	GroupSortFieldCreatedAt GroupSortField = "created_at"
+	GroupSortFieldID        GroupSortField = "id"
+	GroupSortFieldUpdatedAt GroupSortField = "updated_at"
+)
+
+// Defines values for IssuerSortField.
+const (
+	IssuerSortFieldCreatedAt IssuerSortField = "created_at"
+	IssuerSortFieldID        IssuerSortField = "id"
+	IssuerSortFieldUpdatedAt IssuerSortField = "updated_at"
+)
+
+// Defines values for OAuthClientSortField.
+const (
+	OAuthClientSortFieldCreatedAt         OAuthClientSortField = "created_at"
+	OAuthClientSortFieldID                OAuthClientSortField = "id"
+	OAuthClientSortFieldLastTokenIssuedAt OAuthClientSortField = "last_token_issued_at"
+	OAuthClientSortFieldUpdatedAt         OAuthClientSortField = "updated_at"
+)
+
+// Defines values for SigningKeyState.
+const (
+	SigningKeyStateActive  SigningKeyState = "active"
+	SigningKeyStatePending SigningKeyState = "pending"
+	SigningKeyStateRetired SigningKeyState = "retired"
+)
+
+// Defines values for UserInfoUpdateMode.
+const (
+	UserInfoUpdateModeAlways   UserInfoUpdateMode = "always"
+	UserInfoUpdateModeNever    UserInfoUpdateMode = "never"
+	UserInfoUpdateModeOnChange UserInfoUpdateMode = "on_change"
+)
+
+// Defines values for UserSortField.
+const (
+	UserSortFieldCreatedAt      UserSortField = "created_at"
+	UserSortFieldID             UserSortField = "id"
+	UserSortFieldLastExchangeAt UserSortField = "last_exchange_at"
+	UserSortFieldUpdatedAt      UserSortField = "updated_at"
+)
+
 // AddGroupMembers defines model for AddGroupMembers.
 type AddGroupMembers struct {
 	// MemberIDs IDs of the members to add to the group
@@ -40,6 +95,21 @@ type CreateGroup struct {
 
 // CreateIssuer defines model for CreateIssuer.
 type CreateIssuer struct {
+	// ActorConditions A CEL expression deciding whether an actor may act on behalf of a subject
+	// authenticated by this issuer in a delegated token exchange. The subject
+	// token claims are available as "claims" and the actor token claims as
+	// "actor". If unset, delegation is only allowed when the subject token
+	// contains a matching "may_act" claim
+	ActorConditions *string `json:"actor_conditions,omitempty"`
+
+	// AllowedAlgorithms JWS algorithms JWTs from this issuer may be signed with. If empty, any
+	// algorithm the signing key allows is accepted
+	AllowedAlgorithms *[]string `json:"allowed_algorithms,omitempty"`
+
+	// AllowedAudiences Audiences that may be requested when exchanging tokens from this issuer
+	// without an authenticated OAuth client
+	AllowedAudiences *[]string `json:"allowed_audiences,omitempty"`
+
 	// ClaimConditions A CEL expressions to restrict authentication to a subset of identities
 	// whose claims must match the expressions. By default all identities
 	// issued by the issuer are allowed to authenticate
@@ -48,14 +118,66 @@ type CreateIssuer struct {
 	// ClaimMappings CEL expressions mapping token claims to other claims
 	ClaimMappings *map[string]string `json:"claim_mappings,omitempty"`
 
-	// JWKSURI JWKS URI
-	JWKSURI string `json:"jwks_uri"`
+	// ClientID Client ID identity-api is registered with at the issuer. ID tokens are only
+	// accepted as subject tokens if their "aud" claim contains this value
+	ClientID *string `json:"client_id,omitempty"`
+
+	// ClockSkew Leeway in seconds allowed when checking the time-based claims of JWTs from this issuer
+	ClockSkew *int `json:"clock_skew,omitempty"`
+
+	// Discovery Discover jwks_uri and userinfo_uri from the issuer's OIDC provider metadata at
+	// "/.well-known/openid-configuration", and re-check them periodically. Discovered
+	// values replace any given values. Defaults to true if neither jwks_uri nor
+	// introspection_uri is set
+	Discovery *bool `json:"discovery,omitempty"`
+
+	// IntrospectionClientID Client ID used to authenticate to the introspection endpoint
+	IntrospectionClientID *string `json:"introspection_client_id,omitempty"`
+
+	// IntrospectionClientSecret Client secret used to authenticate to the introspection endpoint. Never returned by the API. Requires oauth.issuerSecrets.encryptionKey to be configured
+	IntrospectionClientSecret *string `json:"introspection_client_secret,omitempty"`
+
+	// IntrospectionURI RFC 7662 token introspection endpoint used to validate opaque access tokens
+	// issued by this issuer. At least one of jwks_uri or introspection_uri must be set
+	IntrospectionURI *string `json:"introspection_uri,omitempty"`
+
+	// JWKSURI JWKS URI. At least one of jwks_uri or introspection_uri must be set, unless
+	// discovery is enabled
+	JWKSURI *string `json:"jwks_uri,omitempty"`
+
+	// MaxTokenAge Seconds after their "iat" claim JWTs from this issuer are accepted. If set,
+	// the "iat" claim is required. Zero disables the check
+	MaxTokenAge *int `json:"max_token_age,omitempty"`
 
 	// Name A human-readable name for the issuer
 	Name string `json:"name"`
 
+	// ProfileMapping A CEL expression producing a map of attributes stored in the profile of a
+	// user authenticated by this issuer, such as their department or locale. The
+	// subject token claims are available as "claims". If unset, users have no
+	// attributes
+	ProfileMapping *string `json:"profile_mapping,omitempty"`
+
+	// RequiredAudiences Audiences JWTs from this issuer are accepted for. If set, the "aud" claim
+	// must contain at least one of them
+	RequiredAudiences *[]string `json:"required_audiences,omitempty"`
+
+	// ScopeMapping A CEL expression producing the list of scopes that may be granted to a
+	// subject authenticated by this issuer in a token exchange. The subject token
+	// claims are available as "claims". If unset, no scopes are granted
+	ScopeMapping *string `json:"scope_mapping,omitempty"`
+
 	// URI URI for the issuer. Must match the "iss" claim value in incoming JWTs
 	URI string `json:"uri"`
+
+	// UserInfoUpdateMode When the stored name, email and attributes of existing users are updated from exchanged
+	// tokens. "never" keeps them as first stored, "always" replaces them with the token's, clearing
+	// values the token lacks, and "on_change" updates the values the token has that differ.
+	// A user updated event is published when they change. Defaults to "on_change"
+	UserInfoUpdateMode *UserInfoUpdateMode `json:"user_info_update_mode,omitempty"`
+
+	// UserInfoURI OIDC userinfo endpoint of the issuer, used to fetch user names and emails missing from exchanged tokens
+	UserInfoURI *string `json:"userinfo_uri,omitempty"`
 }
 
 // CreateOAuthClient defines model for CreateOAuthClient.
@@ -63,10 +185,31 @@ type CreateOAuthClient struct {
 	// Audience Audiences that this client can request
 	Audience *[]string `json:"audience,omitempty"`
 
+	// GrantTypes Grant types that this client can use. Supported values are "client_credentials",
+	// "urn:ietf:params:oauth:grant-type:token-exchange" and "refresh_token". Defaults to
+	// "client_credentials" and "urn:ietf:params:oauth:grant-type:token-exchange".
+	GrantTypes *[]string `json:"grant_types,omitempty"`
+
 	// Name A human-readable name for the client
 	Name string `json:"name"`
+
+	// Scopes Scopes that this client can request
+	Scopes *[]string `json:"scopes,omitempty"`
+}
+
+// CreateSigningKey defines model for CreateSigningKey.
+type CreateSigningKey struct {
+	// ActivateAt The time from which the key signs tokens. If unset, the key does not sign tokens
+	// until it is activated.
+	ActivateAt *time.Time `json:"activate_at,omitempty"`
+
+	// Algorithm The JWT signing algorithm of the key
+	Algorithm CreateSigningKeyAlgorithm `json:"algorithm"`
 }
 
+// CreateSigningKeyAlgorithm The JWT signing algorithm of the key
+type CreateSigningKeyAlgorithm string
+
 // DeleteResponse defines model for DeleteResponse.
 type DeleteResponse struct {
 	// Success Always true.
@@ -75,6 +218,9 @@ type DeleteResponse struct {
 
 // Group defines model for Group.
 type Group struct {
+	// CreatedAt The time the group was created
+	CreatedAt time.Time `json:"created_at"`
+
 	// Description a description for the group
 	Description *string `json:"description,omitempty"`
 
@@ -86,10 +232,31 @@ type Group struct {
 
 	// OwnerID ID of the owner of the group
 	OwnerID *gidx.PrefixedID `json:"owner_id,omitempty"`
+
+	// UpdatedAt The time the group was last updated
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// GroupSortField defines model for GroupSortField.
+type GroupSortField string
+
 // Issuer defines model for Issuer.
 type Issuer struct {
+	// ActorConditions A CEL expression deciding whether an actor may act on behalf of a subject
+	// authenticated by this issuer in a delegated token exchange. The subject
+	// token claims are available as "claims" and the actor token claims as
+	// "actor". If unset, delegation is only allowed when the subject token
+	// contains a matching "may_act" claim
+	ActorConditions string `json:"actor_conditions"`
+
+	// AllowedAlgorithms JWS algorithms JWTs from this issuer may be signed with. If empty, any
+	// algorithm the signing key allows is accepted
+	AllowedAlgorithms []string `json:"allowed_algorithms"`
+
+	// AllowedAudiences Audiences that may be requested when exchanging tokens from this issuer
+	// without an authenticated OAuth client
+	AllowedAudiences []string `json:"allowed_audiences"`
+
 	// ClaimConditions A CEL expressions to restrict authentication to a subset of identities
 	// whose claims must match the expressions. By default all identities
 	// issued by the issuer are allowed to authenticate
@@ -98,21 +265,91 @@ type Issuer struct {
 	// ClaimMappings CEL expressions mapping token claims to other claims
 	ClaimMappings map[string]string `json:"claim_mappings"`
 
+	// ClientID Client ID identity-api is registered with at the issuer. ID tokens are only
+	// accepted as subject tokens if their "aud" claim contains this value
+	ClientID string `json:"client_id"`
+
+	// ClockSkew Leeway in seconds allowed when checking the time-based claims of JWTs from this issuer
+	ClockSkew int `json:"clock_skew"`
+
+	// CreatedAt The time the issuer was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// Discovery Whether jwks_uri and userinfo_uri are discovered from the issuer's OIDC provider
+	// metadata and re-checked periodically
+	Discovery bool `json:"discovery"`
+
 	// ID ID of the issuer
 	ID gidx.PrefixedID `json:"id"`
 
+	// IntrospectionClientID Client ID used to authenticate to the introspection endpoint
+	IntrospectionClientID string `json:"introspection_client_id"`
+
+	// IntrospectionURI RFC 7662 token introspection endpoint used to validate opaque access tokens
+	// issued by this issuer. At least one of jwks_uri or introspection_uri must be set
+	IntrospectionURI string `json:"introspection_uri"`
+
 	// JWKSURI JWKS URI
 	JWKSURI string `json:"jwks_uri"`
 
+	// MaxTokenAge Seconds after their "iat" claim JWTs from this issuer are accepted. If set,
+	// the "iat" claim is required. Zero disables the check
+	MaxTokenAge int `json:"max_token_age"`
+
 	// Name A human-readable name for the issuer
 	Name string `json:"name"`
 
+	// ProfileMapping A CEL expression producing a map of attributes stored in the profile of a
+	// user authenticated by this issuer, such as their department or locale. The
+	// subject token claims are available as "claims". If unset, users have no
+	// attributes
+	ProfileMapping string `json:"profile_mapping"`
+
+	// RequiredAudiences Audiences JWTs from this issuer are accepted for. If set, the "aud" claim
+	// must contain at least one of them
+	RequiredAudiences []string `json:"required_audiences"`
+
+	// ScopeMapping A CEL expression producing the list of scopes that may be granted to a
+	// subject authenticated by this issuer in a token exchange. The subject token
+	// claims are available as "claims". If unset, no scopes are granted
+	ScopeMapping string `json:"scope_mapping"`
+
+	// UpdatedAt The time the issuer was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+
 	// URI URI for the issuer. Must match the "iss" claim value in incoming JWTs
 	URI string `json:"uri"`
+
+	// UserInfoUpdateMode When the stored name, email and attributes of existing users are updated from exchanged
+	// tokens. "never" keeps them as first stored, "always" replaces them with the token's, clearing
+	// values the token lacks, and "on_change" updates the values the token has that differ.
+	// A user updated event is published when they change. Defaults to "on_change"
+	UserInfoUpdateMode UserInfoUpdateMode `json:"user_info_update_mode"`
+
+	// UserInfoURI OIDC userinfo endpoint of the issuer, used to fetch user names and emails missing from exchanged tokens
+	UserInfoURI string `json:"userinfo_uri"`
 }
 
+// IssuerSortField defines model for IssuerSortField.
+type IssuerSortField string
+
 // IssuerUpdate defines model for IssuerUpdate.
 type IssuerUpdate struct {
+	// ActorConditions A CEL expression deciding whether an actor may act on behalf of a subject
+	// authenticated by this issuer in a delegated token exchange. The subject
+	// token claims are available as "claims" and the actor token claims as
+	// "actor". If unset, delegation is only allowed when the subject token
+	// contains a matching "may_act" claim
+	ActorConditions *string `json:"actor_conditions,omitempty"`
+
+	// AllowedAlgorithms JWS algorithms JWTs from this issuer may be signed with. If empty, any
+	// algorithm the signing key allows is accepted
+	AllowedAlgorithms *[]string `json:"allowed_algorithms,omitempty"`
+
+	// AllowedAudiences Audiences that may be requested when exchanging tokens from this issuer
+	// without an authenticated OAuth client
+	AllowedAudiences *[]string `json:"allowed_audiences,omitempty"`
+
 	// ClaimConditions A CEL expressions to restrict authentication to a subset of identities
 	// whose claims must match the expressions. By default all identities
 	// issued by the issuer are allowed to authenticate
@@ -121,14 +358,64 @@ type IssuerUpdate struct {
 	// ClaimMappings CEL expressions mapping token claims to other claims
 	ClaimMappings *map[string]string `json:"claim_mappings,omitempty"`
 
+	// ClientID Client ID identity-api is registered with at the issuer. ID tokens are only
+	// accepted as subject tokens if their "aud" claim contains this value
+	ClientID *string `json:"client_id,omitempty"`
+
+	// ClockSkew Leeway in seconds allowed when checking the time-based claims of JWTs from this issuer
+	ClockSkew *int `json:"clock_skew,omitempty"`
+
+	// Discovery Discover jwks_uri and userinfo_uri from the issuer's OIDC provider metadata at
+	// "/.well-known/openid-configuration", and re-check them periodically. Discovered
+	// values replace any given values
+	Discovery *bool `json:"discovery,omitempty"`
+
+	// IntrospectionClientID Client ID used to authenticate to the introspection endpoint
+	IntrospectionClientID *string `json:"introspection_client_id,omitempty"`
+
+	// IntrospectionClientSecret Client secret used to authenticate to the introspection endpoint. Never returned by the API. Requires oauth.issuerSecrets.encryptionKey to be configured
+	IntrospectionClientSecret *string `json:"introspection_client_secret,omitempty"`
+
+	// IntrospectionURI RFC 7662 token introspection endpoint used to validate opaque access tokens
+	// issued by this issuer. At least one of jwks_uri or introspection_uri must be set
+	IntrospectionURI *string `json:"introspection_uri,omitempty"`
+
 	// JWKSURI JWKS URI
 	JWKSURI *string `json:"jwks_uri,omitempty"`
 
+	// MaxTokenAge Seconds after their "iat" claim JWTs from this issuer are accepted. If set,
+	// the "iat" claim is required. Zero disables the check
+	MaxTokenAge *int `json:"max_token_age,omitempty"`
+
 	// Name A human-readable name for the issuer
 	Name *string `json:"name,omitempty"`
 
+	// ProfileMapping A CEL expression producing a map of attributes stored in the profile of a
+	// user authenticated by this issuer, such as their department or locale. The
+	// subject token claims are available as "claims". If unset, users have no
+	// attributes
+	ProfileMapping *string `json:"profile_mapping,omitempty"`
+
+	// RequiredAudiences Audiences JWTs from this issuer are accepted for. If set, the "aud" claim
+	// must contain at least one of them
+	RequiredAudiences *[]string `json:"required_audiences,omitempty"`
+
+	// ScopeMapping A CEL expression producing the list of scopes that may be granted to a
+	// subject authenticated by this issuer in a token exchange. The subject token
+	// claims are available as "claims". If unset, no scopes are granted
+	ScopeMapping *string `json:"scope_mapping,omitempty"`
+
 	// URI URI for the issuer. Must match the "iss" claim value in incoming JWTs
 	URI *string `json:"uri,omitempty"`
+
+	// UserInfoUpdateMode When the stored name, email and attributes of existing users are updated from exchanged
+	// tokens. "never" keeps them as first stored, "always" replaces them with the token's, clearing
+	// values the token lacks, and "on_change" updates the values the token has that differ.
+	// A user updated event is published when they change. Defaults to "on_change"
+	UserInfoUpdateMode *UserInfoUpdateMode `json:"user_info_update_mode,omitempty"`
+
+	// UserInfoURI OIDC userinfo endpoint of the issuer, used to fetch user names and emails missing from exchanged tokens
+	UserInfoURI *string `json:"userinfo_uri,omitempty"`
 }
 
 // OAuthClient defines model for OAuthClient.
@@ -136,16 +423,35 @@ type OAuthClient struct {
 	// Audience Grantable audiences
 	Audience []string `json:"audience"`
 
+	// CreatedAt The time the client was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// GrantTypes Allowed grant types
+	GrantTypes []string `json:"grant_types"`
+
 	// ID OAuth 2.0 Client ID
 	ID gidx.PrefixedID `json:"id"`
 
+	// LastTokenIssuedAt The time a token was last issued to the client. It is recorded periodically, so
+	// it may lag behind by up to a minute
+	LastTokenIssuedAt *time.Time `json:"last_token_issued_at,omitempty"`
+
 	// Name Description of Client
 	Name string `json:"name"`
 
+	// Scopes Grantable scopes
+	Scopes []string `json:"scopes"`
+
 	// Secret OAuth2.0 Client Secret
 	Secret *string `json:"secret,omitempty"`
+
+	// UpdatedAt The time the client was last updated
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// OAuthClientSortField defines model for OAuthClientSortField.
+type OAuthClientSortField string
+
 // Pagination collection response pagination
 type Pagination struct {
 	// Limit the limit used for the collection response
@@ -155,6 +461,30 @@ type Pagination struct {
 	Next *crdbx.Cursor `json:"next,omitempty"`
 }
 
+// SigningKey defines model for SigningKey.
+type SigningKey struct {
+	// ActivateAt The time from which the key signs tokens
+	ActivateAt *time.Time `json:"activate_at,omitempty"`
+
+	// Algorithm The JWT signing algorithm of the key
+	Algorithm string `json:"algorithm"`
+
+	// CreatedAt The time the key was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// ID The ID of the key, published as the "kid" of the key in the JWKS
+	ID gidx.PrefixedID `json:"id"`
+
+	// RetiredAt The time the key was retired
+	RetiredAt *time.Time `json:"retired_at,omitempty"`
+
+	// State The lifecycle state of the key
+	State SigningKeyState `json:"state"`
+}
+
+// SigningKeyState The lifecycle state of the key
+type SigningKeyState string
+
 // UpdateGroup defines model for UpdateGroup.
 type UpdateGroup struct {
 	// Description a description for the group
@@ -164,8 +494,24 @@ type UpdateGroup struct {
 	Name *string `json:"name,omitempty"`
 }
 
+// UpdateUser defines model for UpdateUser.
+type UpdateUser struct {
+	// Disabled Whether the user is disabled. Disabling a user revokes the tokens issued to them, and
+	// refuses to issue them tokens until they are re-enabled
+	Disabled *bool `json:"disabled,omitempty"`
+}
+
 // User defines model for User.
 type User struct {
+	// Attributes Additional attributes of the user, produced by the profile mapping of its issuer
+	Attributes *map[string]interface{} `json:"attributes,omitempty"`
+
+	// CreatedAt The time the user was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// Disabled Whether the user is disabled. Disabled users may not be issued tokens
+	Disabled bool `json:"disabled"`
+
 	// Email Email of the user
 	Email *string `json:"email,omitempty"`
 
@@ -175,19 +521,47 @@ type User struct {
 	// Issuer OAuth 2.0 Issuer of the user
 	Issuer string `json:"iss"`
 
+	// LastExchangeAt The time the user last exchanged a token. It is recorded periodically, so it may
+	// lag behind by up to a minute
+	LastExchangeAt *time.Time `json:"last_exchange_at,omitempty"`
+
 	// Name Name of the user
 	Name *string `json:"name,omitempty"`
 
 	// Subject OAuth 2.0 Subject for the user
 	Subject string `json:"sub"`
+
+	// UpdatedAt The time the profile of the user or whether they are disabled last changed
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// UserInfoUpdateMode When the stored name, email and attributes of existing users are updated from exchanged
+// tokens. "never" keeps them as first stored, "always" replaces them with the token's, clearing
+// values the token lacks, and "on_change" updates the values the token has that differ.
+// A user updated event is published when they change. Defaults to "on_change"
+type UserInfoUpdateMode string
+
+// UserSortField defines model for UserSortField.
+type UserSortField string
+
 // GroupID defines model for groupID.
 type GroupID = gidx.PrefixedID
 
+// GroupSort defines model for groupSort.
+type GroupSort = GroupSortField
+
 // IssuerID defines model for issuerID.
 type IssuerID = gidx.PrefixedID
 
+// IssuerSort defines model for issuerSort.
+type IssuerSort = IssuerSortField
+
+// KeyID defines model for keyID.
+type KeyID = gidx.PrefixedID
+
+// OauthClientSort defines model for oauthClientSort.
+type OauthClientSort = OAuthClientSortField
+
 // OwnerID defines model for ownerID.
 type OwnerID = gidx.PrefixedID
 
@@ -200,6 +574,9 @@ type PageLimit = int
 // SubjectID defines model for subjectID.
 type SubjectID = gidx.PrefixedID
 
+// UserSort defines model for userSort.
+type UserSort = UserSortField
+
 // GroupCollection defines model for GroupCollection.
 type GroupCollection struct {
 	Groups []Group `json:"groups"`
@@ -241,6 +618,11 @@ type OAuthClientCollection struct {
 	Pagination Pagination `json:"pagination"`
 }
 
+// SigningKeyCollection defines model for SigningKeyCollection.
+type SigningKeyCollection struct {
+	SigningKeys []SigningKey `json:"signing_keys"`
+}
+
 // UserCollection defines model for UserCollection.
 type UserCollection struct {
 	// Pagination collection response pagination
@@ -264,6 +646,10 @@ type GetIssuerUsersParams struct {
 
 	// Limit limits the response collections
 	Limit *PageLimit `form:"limit,omitempty" json:"limit,omitempty" query:"limit"`
+
+	// Sort the field to sort the collection by, in ascending order. Users which have not
+	// exchanged a token sort first by last_exchange_at
+	Sort *UserSort `form:"sort,omitempty" json:"sort,omitempty" query:"sort"`
 }
 
 // GetOwnerOAuthClientsParams defines parameters for GetOwnerOAuthClients.
@@ -273,6 +659,10 @@ type GetOwnerOAuthClientsParams struct {
 
 	// Limit limits the response collections
 	Limit *PageLimit `form:"limit,omitempty" json:"limit,omitempty" query:"limit"`
+
+	// Sort the field to sort the collection by, in ascending order. Clients which have not been
+	// issued a token sort first by last_token_issued_at
+	Sort *OauthClientSort `form:"sort,omitempty" json:"sort,omitempty" query:"sort"`
 }
 
 // ListGroupsParams defines parameters for ListGroups.
@@ -282,6 +672,9 @@ type ListGroupsParams struct {
 
 	// Limit limits the response collections
 	Limit *PageLimit `form:"limit,omitempty" json:"limit,omitempty" query:"limit"`
+
+	// Sort the field to sort the collection by, in ascending order
+	Sort *GroupSort `form:"sort,omitempty" json:"sort,omitempty" query:"sort"`
 }
 
 // ListOwnerIssuersParams defines parameters for ListOwnerIssuers.
@@ -291,6 +684,9 @@ type ListOwnerIssuersParams struct {
 
 	// Limit limits the response collections
 	Limit *PageLimit `form:"limit,omitempty" json:"limit,omitempty" query:"limit"`
+
+	// Sort the field to sort the collection by, in ascending order
+	Sort *IssuerSort `form:"sort,omitempty" json:"sort,omitempty" query:"sort"`
 }
 
 // ListUserGroupsParams defines parameters for ListUserGroups.
@@ -323,52 +719,108 @@ type CreateGroupJSONRequestBody = CreateGroup
 // CreateIssuerJSONRequestBody defines body for CreateIssuer for application/json ContentType.
 type CreateIssuerJSONRequestBody = CreateIssuer
 
+// CreateSigningKeyJSONRequestBody defines body for CreateSigningKey for application/json ContentType.
+type CreateSigningKeyJSONRequestBody = CreateSigningKey
+
+// UpdateUserJSONRequestBody defines body for UpdateUser for application/json ContentType.
+type UpdateUserJSONRequestBody = UpdateUser
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xb3XPbuBH/VzBsH9oZWrTvnuo3x77x6Jo0qRVPbpJ4MhC5kpCQAA8Abase/u8dfPAb",
-	"pChZduXUT5ZJYLH72w/sLsAHL2RJyihQKbzTBy/FHCcggev/lpxl6fRC/YxAhJykkjDqnXokQmyBMNID",
-	"PN8j6mGK5crzPYoT8E7Lub7H4c+McIi8U8kz8D0RriDBiqhcp2qokJzQped790dLdmQfLkl0P/nAYUHu",
-	"IdJ0yrdHJEkZl4ZfuVKD2YTQBceSLTlOV8AnIUuC+0AR8fLczrWcXVrOct8jQmTABySkyAxxy0iiAxRv",
-	"WsiU+x67o4PiIQ6CZTwEpEe6pSyIHJ6o7y1nue+leAnnGReMd4WVK0ChfockQ+o/DiKLpVD/cpAZp4Xk",
-	"f2bA15XoZpY3VtKQR/P7yXkxaWsxSQRUErk+wikJCJXAKY4DTdXKznBKjkIWwRLoEdxLjo8kXmpnNayX",
-	"POcWlLckIbKLSaweiwKMlFEBKGRxDKEaIHrw0LNccChml8C9sUwaQopHkc2/QyiHjNQOcVtnNf/w7HNW",
-	"8qbeFDhrIHQQOi8BV49CRiVQvRhO05iEWL0JvgvzuhIm5SwFLglUQVr/IhIS/eOvHBbeqfeXoArugZku",
-	"Ar2w0pOVHnOO19aDCMUFM0MkPlQjjVwF6l8KZhrUbsq1mNFjrmY1VY1rxqeUbunkfhGt9wfVNxI10Xqs",
-	"bdAsjtt4OnccsV+YtSD7QRopUgXY7yCZA98r4L0wt7bkp3TMRIu1d+2PZ2DAQAzk+7SQmrR+pYY9WYsh",
-	"rpk12cZejMVkWuMjmVn6yUJZwc6jMSsI5b73/iyTq/OYAJV7gSzUpMZDVlv/yXAreHo0bppZdG7J5b53",
-	"LfZkabvJ6XuZ2MY+FbtdlFtoGZKPxsqQ0fmUWV0xdxZFtYAuujg0Q2JzhemFUIRVgmjdXWXLOIqKHLqs",
-	"/XaJpKPDYX9Yu8n9toRXNsPqSiqyMAThEFMliog05bwDDkpSiJCdt8jiWDFpeZ4zFgPumn6ximLtnAOW",
-	"YLKtDjsNHh46uq39jxaqaqnB3UQ5L/LgLhH1fNPsFv+aVMW8DbCOqINJ8i1kNCKmWOisfobOf3uL4D7l",
-	"IIQaYiottWwoEc7kShU6xlu1WakcX4DU4dIUQQTEV3q3YqouUesJlGRCogTLcKVFqlGfoDdrFMECZ7FE",
-	"OI4bNHT4jdB8rWeZYIyw0nAcszvQ9lzjCL5SF8pG5gSnKaGmlMGRER/HHxrodKY2oWkDY0kiyX4ALUSV",
-	"DDG5Am7/9zoRwfe+3/0Q3zJOuuD//umfM3R9Ne2I0XQyNUyN6jWhM7TKEkyPOOAIz2NoWlTZF+nI62Tq",
-	"+mramjpB75oK/aq23K+ekRnd4lh5JkWEhixRCP3+6aPYIJOWx2XUhqsaapWV1/fEjqnjLCJAQxc69o2q",
-	"obFEckUEMlsfCjFFigMQsj8+OrbfXdRglhzv2RcQg4QdAuVZfIfXAql4OdkuEj5DDDQVRnsDK/Yv97RW",
-	"1+xi8471mEhre3HfhjnVY7Zh+33ZmxvkvZ3aRkX/xLKl9fQa7g843A/bTU8w3t7EX3eVkbtK3YVaW0vH",
-	"evyuC1X+dp1GWMKr170mWS/LHZrdjG0yp0uOqdSyFmPEVmmSKxSaXsEvk2PbL0A62D3Nhn9Ry0zYwi7o",
-	"0pGAkIPsYbbG68yM25TD1UNOia4KJB8a7YzmWrU2QXnOU+s1+C2txe7TImU5+hXKBERV8tkl7vke3OMk",
-	"jcE7PTn22+dD+niIRyrNOFEAw70cPK8rVlIDFd8N+h7+9O9/fP5jtZr/8UZ8np2sPtOrOCQnx/gy/s/b",
-	"T/GPPhN4luO6lvYMsjeOIGN2gEPvFNj+W5dDSDCJu2R/U4+L/CQT7ig27Mpqvf04MnFVNNVCZiceZNZ1",
-	"xt6P6b8UohtkF9l8iCd7dljqZQRXdoo7cCgIzKI3WpmELlh3/RmEGSdyjT7qnXIG/JaEgP42+zj7O3qH",
-	"KV5CokLW2YcpIgJhqn8pHhP1Uu0gs48zFDK6IMuM6yAjdL1IpHbZngWapD3fuwUuDEvHk+PJiS6gUqA4",
-	"Jd6p9+vkePKrbprKlVZsoDzw9iSwvefgwfyYXuRGRFXzql/KbjVP00gHcvW8vov5jQswX9zaCWs7jONA",
-	"ulh6b+fReX7TOjz+5fh4q+73UJe61RBw9JpnZQ8U1YYpW0oSrE/zDQ1tDvWmvVK7Pvf/Uk8VTPK7NBtj",
-	"UyGXIP/PtdE4oNlFFZcgBVK+zROT+OM5y2SlmSrtmPSrJ/dLjzIH8cGDvc7V8qd2YmTNwB4TztdoeqGW",
-	"cbndpd1nWip2gVMNCYpbZS/HJVAhaIH1pbnaUHOCVnqsNLgBwkuQmsybtbbsA8TQXjPZpwkbJCduKFNV",
-	"4TgqIp1btfD0EaPx2qQ9mEaNJCrEFM0BZXpe1EW+nqw9DnjdH37DovXeMK/z1so/VcTLD1PdlYp6PWUg",
-	"HgVJdbjZ7071073q4mqfd70lQjYOTndWtL9xaO3e4sjR5kJfn/O6CJTjAvf9Hof7NcAaiGApEw7Mz6JI",
-	"6dMQMa2nQcDbB9WH5lht/p7ZufpOuXdyN4duhvSbOdR7BWmMzcnXNm5lp71q+pk0XappnDOPCLLBQ3nb",
-	"djARvIKE3ULNzBacJXXzkCsgvMdI1NQaCE8ZfKu7wwefT/ZBOkad9gJa8ECiEfXwtOg5DxZf5uzJNvol",
-	"Q5bmU38mcUC1MN9YC1t07og0vfcluQVqrb7Q19ReDhyqic0Yd64/rJUlyBeukqLTtosqTCVV6qHcllzY",
-	"l/WDK93fzSVMDfFc+O9/L2ycTz7zRvgYtZcFRaF5t857AmRQXvIcdsdrsUv+QqqPsw6sNGhdrnV4kgZG",
-	"eZE1cW3Dg7jquyUieLBfkOVB7b5ybwNQjW30o7bFmJVfhR0YxO7b3w6kGa46mxpxcy+oAXino+ouxcwd",
-	"t1pr1vZLy+MevSdp+t1krHtBblNLVvMpGUo5uyVCXzxYQXPljEbP9aXhk4XGLjDPHB8f3SfusYtxTeGO",
-	"X1ffoDl7MG+JMFdGzDhtfLjX6sr2y0/k+u3P/ZrK2ITP6MZLqVVbamlfG+vnuzU1S8if1NVeWlOzUsSY",
-	"Aq3jT7VPoZz7pDIYc/Wz9pHST+Eone/JXGcDRuiejbGR1VsvcZn7Nkk9K/a1UE8tMyD6U+xj9WT7ZaT4",
-	"td1rZIqvk9fgQf2xzau+BFQlwmNq7ep2isMCzDovpMY2H6rt9ahOkazrxFRLAxoZk0KIYn+cr3Uxgkjk",
-	"zh7Uan0ZxP9SiQeZlTS+re/mJQ7QXXrNy2edmqBQj0CMomrDalylElrcoYnNr0HL6Y0kdRONomYvLrKK",
-	"MQuXhlT/Vl14+U3+3wAAAP//sWso8z1HAAA=",
+	"H4sIAAAAAAAC/+x9bXPbOJLwX0Hxear2roqWktnbvTt/88TZlHcyM7k4qWzNMOWCyJaEMQVwANC2LqX/",
+	"ftV44SsoUbKcdWb1KbEANBr9hu5GA/wSpWJVCA5cq+j8S1RQSVegQZq/FlKUxdUl/jcDlUpWaCZ4dB6x",
+	"jIg5ocR0iOKI4Y8F1csojjhdQXRejY0jCb+XTEIWnWtZQhypdAkrikD1usCuSkvGF1EcPZwtxJn7ccGy",
+	"h8k7CXP2ANnVZbP1jK0KIbXFVy+xs5gwPpdUi4WkxRLkJBWr6cMUgUSbjRvrMHvjMNvEFslrB6u9RL0E",
+	"MmeQZ0QLooTUBH9JRZ5Dil3IbB0TxglVKfCM8QURMgPpifF7CXJdUwMBRM2l/38J8+g8+n/Tmv5T26qm",
+	"bzxWf8P5I4O+oAU7S0UGC+Bn8KAlPdN0Ybhkp3Jz4GKZUiXILXzjxHYJc45lz5BpV35N1fqeHduuKrQO",
+	"5NstrLcpm2ILjgjfwjrMODv++fHuB4PXJo4ELfXyVc6A66Nyb0IsUEXulyxdkiW9A8KFJjMAnnAjLxmh",
+	"RItb4BbunEmlyWxNcqr0jWm4sf1uqE74MeTh54vWag8UCnHPt+oykaBEKVMgpmdYMjyQ5ycbPzvMNnFU",
+	"0AW8KqUSMiwYqWlDycC/JKgy1wr/lKBLOcQyOyoau9JUZrOHySs/aO9lsgy4Znp9Rgs2ZVyD5DSfGqjR",
+	"ZizzHc4bR5S3bMUCypLjz8oToxBcNVVFDdDDjAqRA5FdgBwtoRYQ4qjK2W+Q6q22y3YJS2c9/vnJ53WF",
+	"2yaOSnXkbWdCPiqQXbOVcHhIl5Qvthst3+loBuujesT2tYkjL4Wm2TgxryoC4E+p4Bq4oR4tipylFFum",
+	"vynbXCNZSFGA1AxqL9T8j2lYqVHeE2LvZINKSdfOvjBOPTLbQLyre242TZn81SPTgva5mktYKTfUaAsI",
+	"bQqDmBMHZxN7d/R4pLphWZtaj9UcXuZ5l55Bl1odl8xmIcehNGFZTewfYTUDeVSCD5K5TaAnNVsrs6yj",
+	"c388AlsExJL8mBLSWG1cs+FI0mKBG2StV38UYbFxy3hLZqd+MlPm0Xk0zTygTRw1fN6jkCw1oMaTrDH/",
+	"k9HN4/RouhlkfeiC6F3bCO8HWB+FeC5gvLmF9XgK1jj0CdghRAv+IQRoRLRm/eiAHGXlh/HZOnjjSYXo",
+	"7iSSBfloWbFgsJ+bHZG7yLLGhqb6dGhvCe0Zri4VAkY/1XYzsRTNMh9hVcm9Q3aS0dvBsFn/vIm7K3zv",
+	"PMyArJdpCiqwTAwjCGuv8x4k4EohI27cvMzzdVThPBMiB9pXfT8LovZKAtVgsOuj08LhS4+3jb/JXMgW",
+	"udtU3nhXvg8Ef981uoO/AVUj7zaYHvY01ULepIJnzIaSvdkvyKvXbwk8FBKUwlVkkDIT19wvQS9BEsqJ",
+	"AUNWdI3/IxgEwZLm81ZEmHDMCQHXqNqQYXCjl0y5PcWETCSDHBam1cZCPvCZkA9LqAHZxjSnbKUIRQ7f",
+	"UZbTWQ6EKpJEtiWJCOWZoZnFrz1MJTyx60+iCbmak5Ir0LHHAZfKFBE8XxOa5+IeMlwxJ7rGxEJMONou",
+	"yrgilKyoTpdInSRa0fUNTXUS2SkT3mdaHDnQNzRfCMn0chVgwd8/XZO6nfz90wdF5lKsWvRD4s/AGFrE",
+	"lOmlWRSsCr2OCeXrhFcw7Bpqk2wXiKAITVMoNGQJH7YHge22WkaZMeAphATJNxG9pNrjizILSnviOoYj",
+	"Xoa2/YUmHNcmSm3kriVRdpe1m/ae+BsO7aUHLh+FIFPdRAQFB80rCokCbdwmmypioBJ+vxQKvBCuSqWt",
+	"yBiWNKBPyPdrksGclrlG9rRguDSn0SDwAmD0wAmqFk2MICx6ds0rWhSM20CfZnb5NH/XshK9oW3SdAnj",
+	"QLb1TQsijLmwf0e9nTF27paLsTpTmCZydUmaaTcUWAkLpjRIJ/SE6gZRJjjCCRLSB7U54V7G0Vi0NFm5",
+	"7YNJkkS0zLzqkkrBjSDe0bwM0bS981mU7QaZ5iK9vVG3cN9f2luAe7omjBMFKICqbW7SJaS3hppLIJqt",
+	"4GxGFWSerGIetgdRHK0YZ6tyFZ2/iAOZv4ypVNyZxE4Xo0vXRH67v1U3pWTGjqJfwvhcmB/cdJ7Of1Lk",
+	"56vLV6SQ4o5laI1A04xqSjBjlUTTyT3k+dktF/d8KgrgLDtLBZ+zRSmNxiRRbCaRcGYWjLBXpADJRMZS",
+	"mufrCfFooXEyLEDmFzlNAc0bWbA74JY3akIureoYufOOAQeml81lcSETzriWQhXWATM/M0UU6IQHvIQ4",
+	"ancfJbKl6qukd71a4AjwrBCM6x2iddUc1JSzIHIKUgl6EEHbfACSE/IToJTYpHxtjy7eXU3Ie+uLKGJO",
+	"gibuIM9MpSbAU7k2aPwAa5xkBsSLA2T7L97C7ROglKy/7Pd/e0X+869//c6Zp/DaKnLc0ZxlSApR0N9L",
+	"MPujUnZsxxJXujchF5rkQJUmggOqaCVxQpK+vJldYAZdodu9+o/vr3DRHnrIc/jhmnx8f/UIjGJS8hyU",
+	"SnhlMVBBgKO7le3EFzFwaK7ogzt+o4uAm3vtrd9cg6ysMKOVAzXg+Jh9z1l04/Ig0glHSWwPN5uF9ZAn",
+	"5BeQgmRM4SrsyYqxOwnfaTjDTvoFWZYrys8k0Axhtn32yib3dtJCijnLwW/DI/zvQoqsTHFHQG+zMD62",
+	"1pLNSg2KKC1wK2TWT3XQTZ+EowUn25zwGIOkJW6LlvwZFFTqFXCNYpKLlObWFU94a98c4Yo3PezSnIS4",
+	"M5CE19iH/RTPtHHO5W4hQa5UgkKsnDQ2+4Qb6XdbPqEdxcGNaU/nUqWiOIzDiFzOlHEiDZS287yQlGtn",
+	"tmuW7A6ztgRXVUizF0e58OhRWaEV5mbQTn18f9XRlQn5se0bJxFTqtJls83jYhhPxQpJhXzfYYucHULx",
+	"u7GeTIGm/WYlMhiTAbric/HRDPkRRzhQ3ifqr8q4RL5LvbWIeWOdcbXTzAGXit2N7VDGH4IVZbkiK6YU",
+	"LtLIdX1kaHehXav2mOPqQ1kCy5M6V9DMrPYTBk7RdsZ3RuisB0JSyn2gt5fiGEG6wV8DKv8GG4lpDM9Y",
+	"KpiQ67IohERFcC4jCmjiQ41UggknaK6SKEZPtZT8nIGen5v6OHVu3Jdzg4hJgJ0bmp95HrgcQxJJmEtQ",
+	"S7vBJVHLAU14eEI3dO8pJ3van0N2LItvSIGtogf271QMsmJ/5m9PZzVS16GUFrtDtaYBn/eDC6KsJtnD",
+	"eFwuJkEUW3Dv2TWNm2/PBChTa4QdKw+w5JrlhGmbPLFTZ5ZDcyFXiESEJuMMpw1ngFxWJozt3z99qFI1",
+	"VVdvQ2yBGHB0Vn6N3l9/95e/RnH0/vrP//Uf5t+/vPwuiqPX7vfX7vfX2eX1RfS5iwuaDQR1dkelsUAI",
+	"s6b0hZ/cTxNssjMEmywygabXwwBfDwN0q+hKSk1OFJdLyEHDAcnki/yerpUJHSf7ZYsH8sSpEdxsu1RW",
+	"+V1yTxVxQ0ZL0jFT0aGI9urSy114WCdSudx9cPCYhLcrmLvZjqnpsw/aP1cFdDtwt87DXgzNqdLEjRvJ",
+	"1e5JbuYrftzyo7gpWS2sKmGsK33Ov1TGgmXbho6yDW3ghmbtn6yxzi50r8W6UdmFxfJ0OnE6nTidTpxO",
+	"J06nE6fTCZtkG+0tOfE6yF0aPgH55DaP4QMQ5FdWHUjsOg9JeH0g0jjlgKx1xDF04rDVwRlILe7vi31D",
+	"BxunvL7L65+S76fk+yn5fkq+j06+j41ZG9vqAUHrKcv/rLL8zaQBLqGxs3QW13SKei540wUO7cjDLkQo",
+	"AgsGl0F70928Wg7rEKMDQVPczyd0zUN/S9ieXuleBD5ufqUD3Thxnd+aGZZOUyjFYn87JVpOiZZTouWU",
+	"aDklWk6Jlj90GeiphPNUwnlK9ZxSPadUzynVc6qzPGVgvmIGpn1Dfp86SlPXaMWgkQLZI4YZe3Tm6vMO",
+	"OTrbWph54fzWRV2gudcCQp6Yjem+m7wglU92hCOv0ItgW4jmFbrKitpB3sWy9JyQK223wFTIrHPCFxMl",
+	"Es6sYcnpAhMkjBvjURY2XFwxXrpQbRwvwvvkZf0XKsKrvcs5azF0PfayxAP+qmFjg4vO63tMprohxcct",
+	"r6o0tKJRW+63ZwaDT8KNTQ8OSOa4rGFoZqMMoYZm/jDUXicRw+1vqdIfEE2TX/TJxnetRxnazGs8dlC9",
+	"ZdZ4MSHu2Mk8/CKa3bBXzHn5VdVyH3gUR/BAV0UO0fnLvqOJJLTvQp6/RGWCB731TTo/E3ZEvFvwI/rp",
+	"f/77l38sl7N/fK9+uX65/IW/z1P28gV9k//v20/57ZDN+ipP0nVk3VL2cyAB8+TV1V+xNroHcvT2iEgf",
+	"sjeyLAy4Ltm4hXVMinKWM7W06SfreN0ydI7rTj66wNjusbvd4957kqBtZDCWaG7AaKIp7c4l+rBzNod0",
+	"neJGpE0UHyp9L+xbe/aMh91BVOG8d537Nc7yroLXabjw4Du/v/ezBbeUWo79UluCiDbTWtrn/shIhSh6",
+	"uwE8baCfDVdUIXDjezPl0wKZyenRWW5jbdMq4U7cuoSBz8k2na2VyRMmXMK8VGDSyqbdtPkR9mqGXuLJ",
+	"hgRMKgZurdYl/e5ZooDNqwLn4ey4fcOy4whXPZuZAye/uMzYhaB14s0nEnzuHE8NtOrlNRrJ8rHmzJD1",
+	"wDK5R/AUbMZYGY/XPtRbc7IdVTUytCYc60/4Gn9uEnCs/a3DB+TwcYIHptS2ieyB61ZkQ+9eV2FJ483P",
+	"MZzFMaT3mOjOYITYWCThTxqM/ISmZQfbVDnbRk73OmtloEYQ1A3ZK5poJPIq0gpZnXFXtsSLuaW7o/oj",
+	"Yg6mVGRp0FC47RFGIFMS0lB3Um3TlUiZ2CY7TNqjbZbggSmNVsdqLK7TzdlJiLijdzUhScTxACGJyC1A",
+	"oaz5pcq9X2tnjTHvZ65TJZE/qnE9zVFdZeP/pGKS5kCRTNXZTtVKcpreqthdmsSzEX8D0yJpu/ZGLanL",
+	"5WVsPgc5SfiFZatfGtwBN0pSe2T+gH9NfOau+ZxIa3KjG94LMbSIYrfaKK47jvRC+jz9yYHst1z4SfpN",
+	"P/NXblYnJweHoE0rNH4J7biz9Usz4Gw1NCPNVgOGmK8dGia+3JhDorkIHm2Ukuk1MSEpuQZ5x1Ig/3b9",
+	"4frfyY+U0wWYJPvFuytkOOXmf3NTs8KpKTe4/nBNWkeSylz/YzqH4QnaoKM4ugOpLEovJi8mL81ltQI4",
+	"LVh0Hv158mLyZ/NOoF6aLWSK4drdy6l7bnL6JXXngRu7xBysb4zehsHpKjMZHvy9mWSMWx/1+DVsTNNG",
+	"/izwQref+mgPdG82nzvvRX/34sVeDz5uSxd37ncGnle8rp79I41uaGtXK2qetbYwjDg03+lEttNFN6Vi",
+	"73guQPcZ8gb0vzg3mss/iBVvQCvCuN1FmeCEzlyJTzcBPBlmzybeolFT53W2FKtzuuxiDyy3cWfFtibB",
+	"3PDvhyMVdmmFWVswLMAGlh+853vS1wOEpMmfYWbsUuKGlNgX2qdf3IeMNtuEozIW7lrvbE2uLvsst93e",
+	"uCi6w+YQgeou04X/atE3YziJX6intfm7ZSo7hwugd5LwDWgD5nv7qZtnSEO76qMaOkvJSZiUBR7SBg51",
+	"nfvbomdsS1ZNUgetV2OIeZFjVvn2fco3U1GPI7wp6vxeZOuj0byJWyeljVZv8zzZXbNoUFO22KPpqn71",
+	"eVidms8ei3lbGvo8fsuUbr0ofTCj451dG5/7GdnbfgdnSHlDAKp+0/CHHwLq1yLWFgtWCBWg+UWWmUJv",
+	"A8RmS7YSvPuC93NTrC5+X1m5hp7/PkjdArzZxt9Sh5zAOk+xh1q5YSdOfyVOV2wap8wjjOz0S/WRqs32",
+	"KGEl7qAhZiZF1hAPW9oXFhIc2iDCUxpfVX/W6vk79mGSjmGn+zLJ9AvLRmRNrvx5ytYAzJ7YWshoRRzM",
+	"p/6U5jOKwOTOjIm/KOoTubb83kq955el9vbMie0T9vW3c2UB+htniT/5OYQVNpKq+DBbb6F9FT+E3P3D",
+	"VMLGEF+L/sffC1vXIr/yRvgYtlcBhed8mOcDBnJaff1muzp+VIf4L6z+gO/ThQa7O1efcDwsjOh8oSig",
+	"dYaIqHFOHVi2iwfmxTY1/eI+0rqZNj56NZhSxr6t3NW+/BDVh1f/mezofhH4MK6EvzoWYI6gdfLUMMlQ",
+	"oc2jXlo/HOnZIyvVzfPWtTJmyzPw+75e/0ndXVlfg6cW9hKduaeAk7RmLnn2tb7/+2SWt0+Yr2x+H31Y",
+	"MSAX404meqag/vZpMMWD+RqT8bb9jPDRQamrsjvfqrWoP9f/iCTQoIXYRczRSaBKBCwkq5hjjcJhCdaK",
+	"P0+ql99agrVmxJhgsad8je91BvdhFBj7MGzjS5rfnlax6oWQA9Wq94nU0KmGJdHAntuKR4QK0Lr1wbpR",
+	"4YjwW6YtZqmv4P0htshmmPBtBCeNjXFkcOKK+M/890sHlbCuuraPBe0rwMHvrvY3B9V9GqV74TejmuID",
+	"DM2VNXEb3jLeAAdpydOcwAN2hey21n1CXB26RYFKaBSpNS4HxAmflZpkovs8fbcEuvNAfUjx6lVETynV",
+	"jWm+smR3Z97/6DbIwGFJGJDz6ZdbWOPm45mCiA+cNbkenSl9Aa8yV2v65RAmfcrFPRF8Qi742r28YubD",
+	"q+U1pAHptqXD5mJDSGA8Wi2R2W9fNCR42sz0Y/k9QPzD+W0pOsxte5UEp6uMQZPnwj+1xe5aJsrdt1pS",
+	"RWYA3Ffamnpuyg3rE46IEztBZo0KFyQXfAGyaTdigvZk0N7UZsX1x/+urURBZl8cgIdiSG7s/H9sqamZ",
+	"uK/MmLTS9Av+M7oWCTv7ug+JpyjuUYNV9UIPkz7YsbVsd6J+TSh0v2aScAO+cSen+v5JIW3NdD2BXyPj",
+	"hC4o4+fuETftrn8QVm2gpj2un+9q4ha7h5fWVam5l1x/neGDIGI+nwkqM4dW7Gvx64ryhJfVHSXCuNJA",
+	"8T5KvZyseTWF0FwJSzV33GtvS/gv2XQq/kMCbRnxUe12WOvrJwHH1LL8X7HyljjaeeXAP7efF31U406L",
+	"/jgENyQ6brEZgpwEyb6z1KxpcUyl2f2Wy2AjK86eqQI9VRFbzdCv5/weLkQ16wd0dXALG5PaVH53wstn",
+	"RnqycFYTZxvKbP4zJeVZVsFdXe6IcntE34uvT1BKb/AYqqBHjMaVzp+22fEF84P6vKl+651ReeYoIjip",
+	"c6Ktm6zKiPm2gc1C/cbw1qHJLhj+iLrxCNPuQZUBcaPc37uGOZeduOyTv2bacOQ3nzf/NwDnrukpaJkA",
+	"AA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file